@@ -10,6 +10,7 @@ type AllActions struct {
 	PublicKey PublicKeyActions
 	Namespace NamespaceActions
 	Billing   BillingActions
+	Job       JobActions
 }
 
 type DeviceActions struct {
@@ -36,6 +37,10 @@ type BillingActions struct {
 	CreateCustomer, ChooseDevices, AddPaymentMethod, UpdatePaymentMethod, RemovePaymentMethod, CancelSubscription, CreateSubscription, GetSubscription int
 }
 
+type JobActions struct {
+	Create, Cancel int
+}
+
 // Actions has all available and allowed actions.
 // You should use it to get the code's action.
 var Actions = AllActions{
@@ -89,4 +94,8 @@ var Actions = AllActions{
 		CreateSubscription:  BillingCreateSubscription,
 		GetSubscription:     BillingGetSubscription,
 	},
+	Job: JobActions{
+		Create: JobCreate,
+		Cancel: JobCancel,
+	},
 }
@@ -380,6 +380,9 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Namespace.RemoveMember,
 				Actions.Namespace.EditMember,
 				Actions.Namespace.EnableSessionRecord,
+
+				Actions.Job.Create,
+				Actions.Job.Cancel,
 			},
 			requiredMocks: func() {
 			},
@@ -429,6 +432,9 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Billing.CancelSubscription,
 				Actions.Billing.CreateSubscription,
 				Actions.Billing.GetSubscription,
+
+				Actions.Job.Create,
+				Actions.Job.Cancel,
 			},
 			requiredMocks: func() {
 			},
@@ -450,6 +456,16 @@ func TestCheckPermission(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestCheckPermissionJob(t *testing.T) {
+	for _, role := range []string{RoleObserver, RoleOperator} {
+		for _, action := range []int{Actions.Job.Create, Actions.Job.Cancel} {
+			assert.ErrorIs(t, EvaluatePermission(role, action, func() error {
+				return nil
+			}), ErrForbidden)
+		}
+	}
+}
+
 func ExampleCheckRole_observer_and_observer() {
 	// If members have the same role, they cannot act over each other.
 	active := RoleObserver
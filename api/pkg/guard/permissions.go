@@ -54,6 +54,9 @@ const (
 	BillingCreateSubscription
 	BillingGetPaymentMethod
 	BillingGetSubscription
+
+	JobCreate
+	JobCancel
 )
 
 var observerPermissions = Permissions{
@@ -120,6 +123,9 @@ var adminPermissions = Permissions{
 	NamespaceRemoveMember,
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
+
+	JobCreate,
+	JobCancel,
 }
 
 var ownerPermissions = Permissions{
@@ -173,4 +179,7 @@ var ownerPermissions = Permissions{
 	BillingCancelSubscription,
 	BillingCreateSubscription,
 	BillingGetSubscription,
+
+	JobCreate,
+	JobCancel,
 }
@@ -25,7 +25,8 @@ const (
 	AuthUserURL     = "/login"
 	AuthUserURLV2   = "/auth/user"
 
-	AuthUserTokenInternalURL = "/auth/token/:id"     //nolint:gosec
+	AuthUserTokenInternalURL = "/auth/token/:id" //nolint:gosec
+	AuthMFACodeInternalURL   = "/auth/mfa/:id"
 	AuthUserTokenPublicURL   = "/auth/token/:tenant" //nolint:gosec
 
 	AuthPublicKeyURL = "/auth/ssh"
@@ -257,6 +258,24 @@ func (h *Handler) AuthGetToken(c gateway.Context) error {
 	return c.JSON(http.StatusOK, res)
 }
 
+// AuthMFACode validates the TOTP code of a user, responding with "200 OK" when it's valid.
+func (h *Handler) AuthMFACode(c gateway.Context) error {
+	var req requests.AuthMFACode
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.AuthMFACode(c.Ctx(), req.ID, req.Code); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) AuthSwapToken(c gateway.Context) error {
 	var req requests.AuthTokenSwap
 	if err := c.Bind(&req); err != nil {
@@ -91,6 +91,65 @@ func TestAuthGetToken(t *testing.T) {
 	}
 }
 
+func TestAuthMFACode(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title         string
+		id            string
+		code          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			title:         "fails when the code is not valid",
+			id:            "id",
+			code:          "abc",
+			requiredMocks: func() {},
+			expected:      http.StatusBadRequest,
+		},
+		{
+			title: "fails when the code is wrong",
+			id:    "id",
+			code:  "000000",
+			requiredMocks: func() {
+				mock.On("AuthMFACode", gomock.Anything, "id", "000000").
+					Return(svc.NewErrAuthUnathorized(nil)).Once()
+			},
+			expected: http.StatusUnauthorized,
+		},
+		{
+			title: "success when the code is right",
+			id:    "id",
+			code:  "123456",
+			requiredMocks: func() {
+				mock.On("AuthMFACode", gomock.Anything, "id", "123456").Return(nil).Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(map[string]string{"code": tc.code})
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/internal/auth/mfa/%s", tc.id), strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+
+			mock.AssertExpectations(t)
+		})
+	}
+}
+
 func TestAuthDevice(t *testing.T) {
 	mock := new(mocks.Service)
 
@@ -0,0 +1,64 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+)
+
+const (
+	CreateSSHCertificateURL = "/namespaces/:tenant/ssh/certificates"
+)
+
+func (h *Handler) CreateSSHCertificate(c gateway.Context) error {
+	req := new(requests.SSHCertificateCreate)
+
+	if err := c.Bind(req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(req); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	// NOTICE: A certificate identifies a namespace's member, so the certificates requested with an API key are issued
+	// to the member who created it, as long as the key belongs to the namespace.
+	if key := c.Request().Header.Get("X-API-KEY"); key != "" {
+		apiKey, err := h.service.GetAPIKeyByUID(c.Ctx(), key)
+		if err != nil {
+			return err
+		}
+
+		if apiKey.TenantID != req.Tenant {
+			return c.NoContent(http.StatusForbidden)
+		}
+
+		uid = apiKey.UserID
+	}
+
+	namespace, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
+	if err != nil {
+		return err
+	}
+
+	var res *responses.SSHCertificateCreate
+	err = guard.EvaluateNamespace(namespace, uid, guard.Actions.Device.Connect, func() error {
+		var err error
+		res, err = h.service.CreateSSHCertificate(c.Ctx(), req, uid)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
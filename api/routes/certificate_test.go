@@ -0,0 +1,205 @@
+package routes
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateSSHCertificate(t *testing.T) {
+	mock := new(mocks.Service)
+
+	namespace := &models.Namespace{
+		Name:     "namespace",
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		Members: []models.Member{
+			{ID: "123", Role: guard.RoleOperator},
+		},
+	}
+
+	type Expected struct {
+		certificate *responses.SSHCertificateCreate
+		status      int
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		uid           string
+		apiKey        string
+		body          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when public key is missing",
+			tenant:        "00000000-0000-4000-0000-000000000000",
+			uid:           "123",
+			body:          `{"duration": 30}`,
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description:   "fails when duration is greater than a day",
+			tenant:        "00000000-0000-4000-0000-000000000000",
+			uid:           "123",
+			body:          `{"public_key": "ssh-ed25519 AAAA", "duration": 1441}`,
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description: "fails when namespace does not exist",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         "123",
+			body:        `{"public_key": "ssh-ed25519 AAAA"}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(nil, svc.ErrNamespaceNotFound).Once()
+			},
+			expected: Expected{status: http.StatusNotFound},
+		},
+		{
+			description: "fails when namespace cannot be retrieved",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         "123",
+			body:        `{"public_key": "ssh-ed25519 AAAA"}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(nil, errors.New("error")).Once()
+			},
+			expected: Expected{status: http.StatusInternalServerError},
+		},
+		{
+			description: "fails when the API key belongs to another namespace",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			apiKey:      "key",
+			body:        `{"public_key": "ssh-ed25519 AAAA"}`,
+			requiredMocks: func() {
+				mock.On("GetAPIKeyByUID", gomock.Anything, "key").
+					Return(&models.APIKey{ID: "key", UserID: "123", TenantID: "00000000-0000-4000-0000-000000000001"}, nil).Once()
+			},
+			expected: Expected{status: http.StatusForbidden},
+		},
+		{
+			description: "succeeds issuing the certificate to the API key's creator",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			apiKey:      "key",
+			body:        `{"public_key": "ssh-ed25519 AAAA"}`,
+			requiredMocks: func() {
+				mock.On("GetAPIKeyByUID", gomock.Anything, "key").
+					Return(&models.APIKey{ID: "key", UserID: "123", TenantID: "00000000-0000-4000-0000-000000000000"}, nil).Once()
+
+				mock.On("GetNamespace", gomock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).Once()
+
+				mock.On("CreateSSHCertificate", gomock.Anything, &requests.SSHCertificateCreate{
+					TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+					PublicKey:   "ssh-ed25519 AAAA",
+				}, "123").Return(&responses.SSHCertificateCreate{Certificate: "ssh-ed25519-cert-v01@openssh.com AAAA"}, nil).Once()
+			},
+			expected: Expected{
+				certificate: &responses.SSHCertificateCreate{Certificate: "ssh-ed25519-cert-v01@openssh.com AAAA"},
+				status:      http.StatusOK,
+			},
+		},
+		{
+			description: "fails when user is not a member of the namespace",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         "456",
+			body:        `{"public_key": "ssh-ed25519 AAAA"}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).Once()
+			},
+			expected: Expected{status: http.StatusForbidden},
+		},
+		{
+			description: "fails when role has no principals",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         "123",
+			body:        `{"public_key": "ssh-ed25519 AAAA"}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).Once()
+
+				mock.On("CreateSSHCertificate", gomock.Anything, &requests.SSHCertificateCreate{
+					TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+					PublicKey:   "ssh-ed25519 AAAA",
+				}, "123").Return(nil, svc.NewErrSSHCertificatePrincipals(nil)).Once()
+			},
+			expected: Expected{status: http.StatusForbidden},
+		},
+		{
+			description: "succeeds",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			uid:         "123",
+			body:        `{"public_key": "ssh-ed25519 AAAA", "duration": 30}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).Once()
+
+				mock.On("CreateSSHCertificate", gomock.Anything, &requests.SSHCertificateCreate{
+					TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"},
+					PublicKey:   "ssh-ed25519 AAAA",
+					Duration:    30,
+				}, "123").Return(&responses.SSHCertificateCreate{
+					Certificate: "ssh-ed25519-cert-v01@openssh.com AAAA",
+					Serial:      1,
+					Principals:  []string{"deploy"},
+					ValidAfter:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					ValidBefore: time.Date(2023, 1, 1, 0, 30, 0, 0, time.UTC),
+				}, nil).Once()
+			},
+			expected: Expected{
+				certificate: &responses.SSHCertificateCreate{
+					Certificate: "ssh-ed25519-cert-v01@openssh.com AAAA",
+					Serial:      1,
+					Principals:  []string{"deploy"},
+					ValidAfter:  time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					ValidBefore: time.Date(2023, 1, 1, 0, 30, 0, 0, time.UTC),
+				},
+				status: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/namespaces/"+tc.tenant+"/ssh/certificates", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-ID", tc.uid)
+			if tc.apiKey != "" {
+				req.Header.Set("X-API-KEY", tc.apiKey)
+			}
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+
+			if tc.expected.certificate != nil {
+				var certificate *responses.SSHCertificateCreate
+				assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&certificate))
+				assert.Equal(t, tc.expected.certificate, certificate)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
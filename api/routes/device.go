@@ -24,6 +24,7 @@ const (
 	CreateTagURL                = "/devices/:uid/tags"      // Add a tag to a device.
 	UpdateTagURL                = "/devices/:uid/tags"      // Update device's tags with a new set.
 	RemoveTagURL                = "/devices/:uid/tags/:tag" // Delete a tag from a device.
+	UpdateDeviceAliasesURL      = "/devices/:uid/aliases"   // Update device's aliases with a new set.
 	UpdateDevice                = "/devices/:uid"
 )
 
@@ -193,7 +194,15 @@ func (h *Handler) LookupDevice(c gateway.Context) error {
 		return err
 	}
 
-	device, err := h.service.LookupDevice(c.Ctx(), req.Domain, req.Name)
+	var device *models.Device
+	var err error
+	// NOTICE: When the namespace isn't informed, the device is looked up on the user's default namespace.
+	if req.Domain == "" {
+		device, err = h.service.LookupDeviceByUser(c.Ctx(), req.User, req.Name)
+	} else {
+		device, err = h.service.LookupDevice(c.Ctx(), req.Domain, req.Name)
+	}
+
 	if err != nil {
 		return err
 	}
@@ -307,6 +316,30 @@ func (h *Handler) UpdateDeviceTag(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+func (h *Handler) UpdateDeviceAliases(c gateway.Context) error {
+	var req requests.DeviceUpdateAliases
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Rename, func() error {
+		return h.service.UpdateDeviceAliases(c.Ctx(), tenant, models.UID(req.UID), req.Aliases)
+	}); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) UpdateDevice(c gateway.Context) error {
 	var req requests.DeviceUpdate
 	if err := c.Bind(&req); err != nil {
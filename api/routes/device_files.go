@@ -0,0 +1,172 @@
+package routes
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	errs "github.com/shellhub-io/shellhub/api/routes/errors"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	DeviceFilesURL          = "/devices/:uid/files"
+	ListDeviceFilesURL      = "/devices/:uid/files/list"
+	DeviceFileChallengesURL = "/devices/:uid/files/challenges"
+)
+
+func (h *Handler) CreateDeviceFileChallenge(c gateway.Context) error {
+	var req requests.DeviceParam
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var challenge *models.FileChallenge
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Connect, func() error {
+		var err error
+		challenge, err = h.service.CreateDeviceFileChallenge(c.Ctx(), tenant, models.UID(req.UID))
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, challenge)
+}
+
+func (h *Handler) ListDeviceFiles(c gateway.Context) error {
+	var req requests.DeviceFile
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var files []models.File
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Connect, func() error {
+		var err error
+		files, err = h.service.ListDeviceFiles(c.Ctx(), tenant, &req)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, files)
+}
+
+func (h *Handler) DownloadDeviceFile(c gateway.Context) error {
+	var req requests.DeviceFile
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var content io.ReadCloser
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Connect, func() error {
+		var err error
+		content, err = h.service.DownloadDeviceFile(c.Ctx(), tenant, &req)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	defer content.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", path.Base(req.Path)))
+
+	return c.Stream(http.StatusOK, echo.MIMEOctetStream, content)
+}
+
+func (h *Handler) UploadDeviceFile(c gateway.Context) error {
+	var req requests.DeviceFile
+
+	// NOTICE: The request's body is the file's content, so only the path, the query and the headers are bound.
+	binder := new(echo.DefaultBinder)
+	if err := binder.BindPathParams(c, &req); err != nil {
+		return errs.NewErrUnprocessableEntity(err)
+	}
+
+	if err := binder.BindQueryParams(c, &req); err != nil {
+		return errs.NewErrUnprocessableEntity(err)
+	}
+
+	if err := binder.BindHeaders(c, &req); err != nil {
+		return errs.NewErrUnprocessableEntity(err)
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var file *models.File
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Connect, func() error {
+		var err error
+		file, err = h.service.UploadDeviceFile(c.Ctx(), tenant, &req, c.Request().Body)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, file)
+}
+
+func (h *Handler) RemoveDeviceFile(c gateway.Context) error {
+	var req requests.DeviceFile
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Connect, func() error {
+		return h.service.RemoveDeviceFile(c.Ctx(), tenant, &req)
+	}); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
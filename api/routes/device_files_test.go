@@ -0,0 +1,312 @@
+package routes
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestListDeviceFiles(t *testing.T) {
+	mock := new(mocks.Service)
+
+	type Expected struct {
+		files  []models.File
+		status int
+	}
+
+	cases := []struct {
+		description   string
+		query         string
+		signature     string
+		role          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the user is missing",
+			query:         "path=/etc&fingerprint=fingerprint",
+			role:          guard.RoleOwner,
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description:   "fails when the credentials are missing",
+			query:         "path=/etc&user=root",
+			role:          guard.RoleOwner,
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description:   "fails when the public key's signature is missing",
+			query:         "path=/etc&user=root&fingerprint=fingerprint",
+			role:          guard.RoleOwner,
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description:   "fails when the role cannot connect to the device",
+			query:         "path=/etc&user=root&fingerprint=fingerprint",
+			signature:     "signature",
+			role:          "",
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusForbidden},
+		},
+		{
+			description: "fails when the directory doesn't exist",
+			query:       "path=/missing&user=root&fingerprint=fingerprint",
+			signature:   "signature",
+			role:        guard.RoleObserver,
+			requiredMocks: func() {
+				mock.On("ListDeviceFiles", gomock.Anything, "00000000-0000-4000-0000-000000000000", &requests.DeviceFile{
+					DeviceParam: requests.DeviceParam{UID: "device"},
+					Path:        "/missing",
+					User:        "root",
+					Fingerprint: "fingerprint",
+					Challenge:   "challenge",
+					Signature:   "signature",
+				}).Return(nil, svc.NewErrDeviceFileNotFound("/missing", nil)).Once()
+			},
+			expected: Expected{status: http.StatusNotFound},
+		},
+		{
+			description: "succeeds",
+			query:       "path=/etc&user=root&fingerprint=fingerprint",
+			signature:   "signature",
+			role:        guard.RoleObserver,
+			requiredMocks: func() {
+				mock.On("ListDeviceFiles", gomock.Anything, "00000000-0000-4000-0000-000000000000", &requests.DeviceFile{
+					DeviceParam: requests.DeviceParam{UID: "device"},
+					Path:        "/etc",
+					User:        "root",
+					Fingerprint: "fingerprint",
+					Challenge:   "challenge",
+					Signature:   "signature",
+				}).Return([]models.File{{Name: "hosts", Size: 10, Mode: "-rw-r--r--"}}, nil).Once()
+			},
+			expected: Expected{
+				files:  []models.File{{Name: "hosts", Size: 10, Mode: "-rw-r--r--"}},
+				status: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/device/files/list?"+tc.query, nil)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Device-Challenge", "challenge")
+			req.Header.Set("X-Device-Signature", tc.signature)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+
+			if tc.expected.files != nil {
+				var files []models.File
+				assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&files))
+				assert.Equal(t, tc.expected.files, files)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDownloadDeviceFile(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.On("DownloadDeviceFile", gomock.Anything, "00000000-0000-4000-0000-000000000000", &requests.DeviceFile{
+		DeviceParam: requests.DeviceParam{UID: "device"},
+		Path:        "/etc/hosts",
+		User:        "root",
+		Password:    "secret",
+		Username:    "john",
+	}).Return(io.NopCloser(strings.NewReader("127.0.0.1 localhost")), nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/devices/device/files?path=/etc/hosts&user=root", nil)
+	req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+	req.Header.Set("X-Role", guard.RoleOperator)
+	req.Header.Set("X-Username", "john")
+	req.Header.Set("X-Device-Password", "secret")
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	assert.Equal(t, `attachment; filename="hosts"`, rec.Result().Header.Get("Content-Disposition"))
+	assert.Equal(t, "127.0.0.1 localhost", rec.Body.String())
+
+	mock.AssertExpectations(t)
+}
+
+func TestUploadDeviceFile(t *testing.T) {
+	mock := new(mocks.Service)
+
+	type Expected struct {
+		file   *models.File
+		status int
+	}
+
+	cases := []struct {
+		description   string
+		query         string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the path is missing",
+			query:         "user=root&fingerprint=fingerprint",
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description: "fails when the user cannot be authenticated on the device",
+			query:       "path=app.conf&user=root&fingerprint=fingerprint",
+			requiredMocks: func() {
+				mock.On("UploadDeviceFile", gomock.Anything, "00000000-0000-4000-0000-000000000000", &requests.DeviceFile{
+					DeviceParam: requests.DeviceParam{UID: "device"},
+					Path:        "app.conf",
+					User:        "root",
+					Fingerprint: "fingerprint",
+					Challenge:   "challenge",
+					Signature:   "signature",
+				}, gomock.Anything).Return(nil, svc.NewErrDeviceFileForbidden(nil)).Once()
+			},
+			expected: Expected{status: http.StatusForbidden},
+		},
+		{
+			description: "succeeds",
+			query:       "path=app.conf&user=root&fingerprint=fingerprint",
+			requiredMocks: func() {
+				mock.On("UploadDeviceFile", gomock.Anything, "00000000-0000-4000-0000-000000000000", &requests.DeviceFile{
+					DeviceParam: requests.DeviceParam{UID: "device"},
+					Path:        "app.conf",
+					User:        "root",
+					Fingerprint: "fingerprint",
+					Challenge:   "challenge",
+					Signature:   "signature",
+				}, gomock.MatchedBy(func(content io.Reader) bool {
+					data, _ := io.ReadAll(content)
+
+					return string(data) == "debug = true"
+				})).Return(&models.File{Name: "app.conf", Size: 12, Mode: "-rw-r--r--"}, nil).Once()
+			},
+			expected: Expected{
+				file:   &models.File{Name: "app.conf", Size: 12, Mode: "-rw-r--r--"},
+				status: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPut, "/api/devices/device/files?"+tc.query, strings.NewReader("debug = true"))
+			req.Header.Set("Content-Type", "application/octet-stream")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", guard.RoleAdministrator)
+			req.Header.Set("X-Device-Challenge", "challenge")
+			req.Header.Set("X-Device-Signature", "signature")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+
+			if tc.expected.file != nil {
+				var file *models.File
+				assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&file))
+				assert.Equal(t, tc.expected.file, file)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateDeviceFileChallenge(t *testing.T) {
+	mock := new(mocks.Service)
+
+	type Expected struct {
+		challenge *models.FileChallenge
+		status    int
+	}
+
+	cases := []struct {
+		description   string
+		role          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the role cannot connect to the device",
+			role:          "",
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusForbidden},
+		},
+		{
+			description: "fails when the device isn't found",
+			role:        guard.RoleObserver,
+			requiredMocks: func() {
+				mock.On("CreateDeviceFileChallenge", gomock.Anything, "00000000-0000-4000-0000-000000000000", models.UID("device")).
+					Return(nil, svc.NewErrDeviceNotFound("device", nil)).Once()
+			},
+			expected: Expected{status: http.StatusNotFound},
+		},
+		{
+			description: "succeeds",
+			role:        guard.RoleObserver,
+			requiredMocks: func() {
+				mock.On("CreateDeviceFileChallenge", gomock.Anything, "00000000-0000-4000-0000-000000000000", models.UID("device")).
+					Return(&models.FileChallenge{Challenge: "challenge"}, nil).Once()
+			},
+			expected: Expected{
+				challenge: &models.FileChallenge{Challenge: "challenge"},
+				status:    http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/device/files/challenges", nil)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+
+			if tc.expected.challenge != nil {
+				var challenge *models.FileChallenge
+				assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&challenge))
+				assert.Equal(t, tc.expected.challenge, challenge)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -518,6 +518,22 @@ func TestLookupDevice(t *testing.T) {
 				expectedStatus:  http.StatusOK,
 			},
 		},
+		{
+			title: "success when try to look up of a existing device on the user's default namespace",
+			request: requests.DeviceLookup{
+				Name:      "device1",
+				User:      "507f1f77bcf86cd799439011",
+				Username:  "user1",
+				IPAddress: "192.168.1.100",
+			},
+			requiredMocks: func(req requests.DeviceLookup) {
+				mock.On("LookupDeviceByUser", gomock.Anything, req.User, req.Name).Return(&models.Device{}, nil).Once()
+			},
+			expected: Expected{
+				expectedSession: &models.Device{},
+				expectedStatus:  http.StatusOK,
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -1003,3 +1019,95 @@ func TestUpdateDevice(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateDeviceAliases(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           string
+		updatePayload  requests.DeviceUpdateAliases
+		requiredMocks  func(req requests.DeviceUpdateAliases)
+		expectedStatus int
+	}{
+		{
+			title: "fails when validate because have a duplicate alias",
+			role:  guard.RoleOwner,
+			updatePayload: requests.DeviceUpdateAliases{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Aliases:     []string{"web01", "web01"},
+			},
+			requiredMocks:  func(req requests.DeviceUpdateAliases) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when validate because the alias isn't a valid device name",
+			role:  guard.RoleOwner,
+			updatePayload: requests.DeviceUpdateAliases{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Aliases:     []string{"web.01"},
+			},
+			requiredMocks:  func(req requests.DeviceUpdateAliases) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the role cannot rename devices",
+			role:  guard.RoleObserver,
+			updatePayload: requests.DeviceUpdateAliases{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Aliases:     []string{"web01"},
+			},
+			requiredMocks:  func(req requests.DeviceUpdateAliases) {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the alias is duplicated",
+			role:  guard.RoleOwner,
+			updatePayload: requests.DeviceUpdateAliases{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Aliases:     []string{"web01"},
+			},
+			requiredMocks: func(req requests.DeviceUpdateAliases) {
+				mock.On("UpdateDeviceAliases", gomock.Anything, "tenant-id", models.UID("1234"), req.Aliases).
+					Return(svc.NewErrDeviceAliasDuplicated("web01", nil)).Once()
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			title: "success when try to update the device aliases",
+			role:  guard.RoleOwner,
+			updatePayload: requests.DeviceUpdateAliases{
+				DeviceParam: requests.DeviceParam{UID: "123"},
+				Aliases:     []string{"web01", "web02"},
+			},
+			requiredMocks: func(req requests.DeviceUpdateAliases) {
+				mock.On("UpdateDeviceAliases", gomock.Anything, "tenant-id", models.UID("123"), req.Aliases).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks(tc.updatePayload)
+
+			jsonData, err := json.Marshal(tc.updatePayload)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/devices/%s/aliases", tc.updatePayload.UID), strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant-id")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,122 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	CreateJobURL = "/jobs"
+	ListJobsURL  = "/jobs"
+	GetJobURL    = "/jobs/:id"
+	CancelJobURL = "/jobs/:id/cancel"
+)
+
+func (h *Handler) CreateJob(c gateway.Context) error {
+	var req requests.JobCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var username string
+	if c.Username() != nil {
+		username = c.Username().ID
+	}
+
+	var job *models.Job
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Job.Create, func() error {
+		var err error
+		job, err = h.service.CreateJob(c.Ctx(), tenant, username, &req)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+func (h *Handler) ListJobs(c gateway.Context) error {
+	paginator := query.Paginator{}
+	if err := c.Bind(&paginator); err != nil {
+		return err
+	}
+
+	paginator.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	jobs, count, err := h.service.ListJobs(c.Ctx(), tenant, paginator)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, jobs)
+}
+
+func (h *Handler) GetJob(c gateway.Context) error {
+	var req requests.JobGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	job, err := h.service.GetJob(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+func (h *Handler) CancelJob(c gateway.Context) error {
+	var req requests.JobCancel
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Job.Cancel, func() error {
+		return h.service.CancelJob(c.Ctx(), tenant, req.ID)
+	}); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
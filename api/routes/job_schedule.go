@@ -0,0 +1,179 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	CreateJobScheduleURL   = "/jobs/schedules"
+	ListJobSchedulesURL    = "/jobs/schedules"
+	GetJobScheduleURL      = "/jobs/schedules/:id"
+	UpdateJobScheduleURL   = "/jobs/schedules/:id"
+	DeleteJobScheduleURL   = "/jobs/schedules/:id"
+	ListJobScheduleRunsURL = "/jobs/schedules/:id/runs"
+)
+
+func (h *Handler) CreateJobSchedule(c gateway.Context) error {
+	var req requests.JobScheduleCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var username string
+	if c.Username() != nil {
+		username = c.Username().ID
+	}
+
+	var schedule *models.JobSchedule
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Job.Create, func() error {
+		var err error
+		schedule, err = h.service.CreateJobSchedule(c.Ctx(), tenant, username, &req)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, schedule)
+}
+
+func (h *Handler) ListJobSchedules(c gateway.Context) error {
+	paginator := query.Paginator{}
+	if err := c.Bind(&paginator); err != nil {
+		return err
+	}
+
+	paginator.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	schedules, count, err := h.service.ListJobSchedules(c.Ctx(), tenant, paginator)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, schedules)
+}
+
+func (h *Handler) GetJobSchedule(c gateway.Context) error {
+	var req requests.JobScheduleGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	schedule, err := h.service.GetJobSchedule(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, schedule)
+}
+
+func (h *Handler) UpdateJobSchedule(c gateway.Context) error {
+	var req requests.JobScheduleUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var schedule *models.JobSchedule
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Job.Create, func() error {
+		var err error
+		schedule, err = h.service.UpdateJobSchedule(c.Ctx(), tenant, &req)
+
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, schedule)
+}
+
+func (h *Handler) DeleteJobSchedule(c gateway.Context) error {
+	var req requests.JobScheduleDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Job.Create, func() error {
+		return h.service.DeleteJobSchedule(c.Ctx(), tenant, req.ID)
+	}); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ListJobScheduleRuns(c gateway.Context) error {
+	var req requests.JobScheduleRuns
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Paginator.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	jobs, count, err := h.service.ListJobScheduleRuns(c.Ctx(), tenant, req.ID, req.Paginator)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, jobs)
+}
@@ -0,0 +1,229 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateJobSchedule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	type Expected struct {
+		schedule *models.JobSchedule
+		status   int
+	}
+
+	cases := []struct {
+		description   string
+		body          string
+		role          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the cron expression is missing",
+			body:          `{"name":"cleanup","command":"rm -rf /tmp/cache","selector":{"tags":["edge"]},"user":"root","fingerprint":"fingerprint","signature":"c2lnbmF0dXJl","expires_at":1700000000}`,
+			role:          guard.RoleOwner,
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description:   "fails when the role cannot create jobs",
+			body:          `{"name":"cleanup","cron":"0 3 * * *","command":"rm -rf /tmp/cache","selector":{"tags":["edge"]},"user":"root","fingerprint":"fingerprint","signature":"c2lnbmF0dXJl","expires_at":1700000000}`,
+			role:          guard.RoleOperator,
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusForbidden},
+		},
+		{
+			description: "fails when the cron expression is invalid",
+			body:        `{"name":"cleanup","cron":"every night","command":"rm -rf /tmp/cache","selector":{"tags":["edge"]},"user":"root","fingerprint":"fingerprint","signature":"c2lnbmF0dXJl","expires_at":1700000000}`,
+			role:        guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("CreateJobSchedule", gomock.Anything, "00000000-0000-4000-0000-000000000000", "john", &requests.JobScheduleCreate{
+					Name: "cleanup",
+					Cron: "every night",
+					JobCreate: requests.JobCreate{
+						Command:     "rm -rf /tmp/cache",
+						Selector:    models.JobSelector{Tags: []string{"edge"}},
+						User:        "root",
+						Fingerprint: "fingerprint",
+						Signature:   "c2lnbmF0dXJl",
+						ExpiresAt:   1700000000,
+					},
+				}).Return(nil, svc.NewErrJobScheduleCron("every night", nil)).Once()
+			},
+			expected: Expected{status: http.StatusBadRequest},
+		},
+		{
+			description: "succeeds",
+			body:        `{"name":"cleanup","cron":"0 3 * * *","run_on_reconnect":true,"command":"rm -rf /tmp/cache","selector":{"tags":["edge"]},"user":"root","fingerprint":"fingerprint","signature":"c2lnbmF0dXJl","expires_at":1700000000}`,
+			role:        guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("CreateJobSchedule", gomock.Anything, "00000000-0000-4000-0000-000000000000", "john", &requests.JobScheduleCreate{
+					Name:           "cleanup",
+					Cron:           "0 3 * * *",
+					RunOnReconnect: true,
+					JobCreate: requests.JobCreate{
+						Command:     "rm -rf /tmp/cache",
+						Selector:    models.JobSelector{Tags: []string{"edge"}},
+						User:        "root",
+						Fingerprint: "fingerprint",
+						Signature:   "c2lnbmF0dXJl",
+						ExpiresAt:   1700000000,
+					},
+				}).Return(&models.JobSchedule{ID: "schedule", Name: "cleanup", Cron: "0 3 * * *", Enabled: true}, nil).Once()
+			},
+			expected: Expected{
+				schedule: &models.JobSchedule{ID: "schedule", Name: "cleanup", Cron: "0 3 * * *", Enabled: true},
+				status:   http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs/schedules", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Username", "john")
+			req.Header.Set("X-Role", tc.role)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+
+			if tc.expected.schedule != nil {
+				var schedule models.JobSchedule
+				assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&schedule))
+				assert.Equal(t, tc.expected.schedule, &schedule)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListJobSchedules(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.On("ListJobSchedules", gomock.Anything, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: 1, PerPage: 10}).
+		Return([]models.JobSchedule{{ID: "schedule"}}, 1, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/schedules?page=1&per_page=10", nil)
+	req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+	req.Header.Set("X-Role", guard.RoleObserver)
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	assert.Equal(t, "1", rec.Result().Header.Get("X-Total-Count"))
+
+	var schedules []models.JobSchedule
+	assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&schedules))
+	assert.Equal(t, []models.JobSchedule{{ID: "schedule"}}, schedules)
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateJobSchedule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	disabled := false
+
+	cases := []struct {
+		description   string
+		role          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the role cannot create jobs",
+			role:          guard.RoleOperator,
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description: "fails when the job schedule isn't found",
+			role:        guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("UpdateJobSchedule", gomock.Anything, "00000000-0000-4000-0000-000000000000", &requests.JobScheduleUpdate{
+					JobParam: requests.JobParam{ID: "schedule"},
+					Enabled:  &disabled,
+				}).Return(nil, svc.NewErrJobScheduleNotFound("schedule", nil)).Once()
+			},
+			expected: http.StatusNotFound,
+		},
+		{
+			description: "succeeds",
+			role:        guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("UpdateJobSchedule", gomock.Anything, "00000000-0000-4000-0000-000000000000", &requests.JobScheduleUpdate{
+					JobParam: requests.JobParam{ID: "schedule"},
+					Enabled:  &disabled,
+				}).Return(&models.JobSchedule{ID: "schedule"}, nil).Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPatch, "/api/jobs/schedules/schedule", strings.NewReader(`{"enabled":false}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListJobScheduleRuns(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.On("ListJobScheduleRuns", gomock.Anything, "00000000-0000-4000-0000-000000000000", "schedule", query.Paginator{Page: 1, PerPage: 10}).
+		Return([]models.Job{{ID: "job", ScheduleID: "schedule"}}, 1, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/schedules/schedule/runs?page=1&per_page=10", nil)
+	req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+	req.Header.Set("X-Role", guard.RoleObserver)
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	assert.Equal(t, "1", rec.Result().Header.Get("X-Total-Count"))
+
+	var jobs []models.Job
+	assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&jobs))
+	assert.Equal(t, []models.Job{{ID: "job", ScheduleID: "schedule"}}, jobs)
+
+	mock.AssertExpectations(t)
+}
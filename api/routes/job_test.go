@@ -0,0 +1,269 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateJob(t *testing.T) {
+	mock := new(mocks.Service)
+
+	type Expected struct {
+		job    *models.Job
+		status int
+	}
+
+	cases := []struct {
+		description   string
+		body          string
+		role          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the command is missing",
+			body:          `{"selector":{"tags":["production"]},"user":"root","fingerprint":"fingerprint","signature":"c2lnbmF0dXJl","expires_at":1700000000}`,
+			role:          guard.RoleOwner,
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description:   "fails when the concurrency is out of range",
+			body:          `{"command":"uptime","selector":{"tags":["production"]},"user":"root","fingerprint":"fingerprint","signature":"c2lnbmF0dXJl","expires_at":1700000000,"concurrency":1000}`,
+			role:          guard.RoleOwner,
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description:   "fails when the signature is missing",
+			body:          `{"command":"uptime","selector":{"tags":["production"]},"user":"root","fingerprint":"fingerprint"}`,
+			role:          guard.RoleOwner,
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusBadRequest},
+		},
+		{
+			description:   "fails when the role cannot create jobs",
+			body:          `{"command":"uptime","selector":{"tags":["production"]},"user":"root","fingerprint":"fingerprint","signature":"c2lnbmF0dXJl","expires_at":1700000000}`,
+			role:          guard.RoleOperator,
+			requiredMocks: func() {},
+			expected:      Expected{status: http.StatusForbidden},
+		},
+		{
+			description: "fails when no device is selected",
+			body:        `{"command":"uptime","selector":{"tags":["staging"]},"user":"root","fingerprint":"fingerprint","signature":"c2lnbmF0dXJl","expires_at":1700000000}`,
+			role:        guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("CreateJob", gomock.Anything, "00000000-0000-4000-0000-000000000000", "john", &requests.JobCreate{
+					Command:     "uptime",
+					Selector:    models.JobSelector{Tags: []string{"staging"}},
+					User:        "root",
+					Fingerprint: "fingerprint",
+					Signature:   "c2lnbmF0dXJl",
+					ExpiresAt:   1700000000,
+				}).Return(nil, svc.NewErrJobNoDevices(nil)).Once()
+			},
+			expected: Expected{status: http.StatusBadRequest},
+		},
+		{
+			description: "succeeds",
+			body:        `{"command":"uptime","selector":{"tags":["production"]},"user":"root","fingerprint":"fingerprint","signature":"c2lnbmF0dXJl","expires_at":1700000000}`,
+			role:        guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("CreateJob", gomock.Anything, "00000000-0000-4000-0000-000000000000", "john", &requests.JobCreate{
+					Command:     "uptime",
+					Selector:    models.JobSelector{Tags: []string{"production"}},
+					User:        "root",
+					Fingerprint: "fingerprint",
+					Signature:   "c2lnbmF0dXJl",
+					ExpiresAt:   1700000000,
+				}).Return(&models.Job{ID: "job", Command: "uptime", Status: models.JobStatusPending}, nil).Once()
+			},
+			expected: Expected{
+				job:    &models.Job{ID: "job", Command: "uptime", Status: models.JobStatusPending},
+				status: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Username", "john")
+			req.Header.Set("X-Role", tc.role)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+
+			if tc.expected.job != nil {
+				var job models.Job
+				assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&job))
+				assert.Equal(t, tc.expected.job, &job)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListJobs(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.On("ListJobs", gomock.Anything, "00000000-0000-4000-0000-000000000000", query.Paginator{Page: 1, PerPage: 10}).
+		Return([]models.Job{{ID: "job"}}, 1, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs?page=1&per_page=10", nil)
+	req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+	req.Header.Set("X-Role", guard.RoleObserver)
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	assert.Equal(t, "1", rec.Result().Header.Get("X-Total-Count"))
+
+	var jobs []models.Job
+	assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&jobs))
+	assert.Equal(t, []models.Job{{ID: "job"}}, jobs)
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetJob(t *testing.T) {
+	mock := new(mocks.Service)
+
+	type Expected struct {
+		job    *responses.Job
+		status int
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the job isn't found",
+			requiredMocks: func() {
+				mock.On("GetJob", gomock.Anything, "00000000-0000-4000-0000-000000000000", "job").
+					Return(nil, svc.NewErrJobNotFound("job", nil)).Once()
+			},
+			expected: Expected{status: http.StatusNotFound},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("GetJob", gomock.Anything, "00000000-0000-4000-0000-000000000000", "job").
+					Return(&responses.Job{
+						Job:     models.Job{ID: "job"},
+						Results: []models.JobResult{{JobID: "job", UID: "device", Status: models.JobResultStatusPending}},
+					}, nil).Once()
+			},
+			expected: Expected{
+				job: &responses.Job{
+					Job:     models.Job{ID: "job"},
+					Results: []models.JobResult{{JobID: "job", UID: "device", Status: models.JobResultStatusPending}},
+				},
+				status: http.StatusOK,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs/job", nil)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", guard.RoleObserver)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected.status, rec.Result().StatusCode)
+
+			if tc.expected.job != nil {
+				var job responses.Job
+				assert.NoError(t, json.NewDecoder(rec.Result().Body).Decode(&job))
+				assert.Equal(t, tc.expected.job, &job)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCancelJob(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		description   string
+		role          string
+		requiredMocks func()
+		expected      int
+	}{
+		{
+			description:   "fails when the role cannot cancel jobs",
+			role:          guard.RoleOperator,
+			requiredMocks: func() {},
+			expected:      http.StatusForbidden,
+		},
+		{
+			description: "fails when the job is finished",
+			role:        guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("CancelJob", gomock.Anything, "00000000-0000-4000-0000-000000000000", "job").
+					Return(svc.NewErrJobFinished("job", nil)).Once()
+			},
+			expected: http.StatusBadRequest,
+		},
+		{
+			description: "succeeds",
+			role:        guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("CancelJob", gomock.Anything, "00000000-0000-4000-0000-000000000000", "job").
+					Return(nil).Once()
+			},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs/job/cancel", nil)
+			req.Header.Set("X-Tenant-ID", "00000000-0000-4000-0000-000000000000")
+			req.Header.Set("X-Role", tc.role)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expected, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
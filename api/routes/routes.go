@@ -29,6 +29,7 @@ func NewRouter(service services.Service) *echo.Echo {
 
 	internalAPI.GET(AuthRequestURL, gateway.Handler(handler.AuthRequest), gateway.Middleware(AuthMiddleware))
 	internalAPI.GET(AuthUserTokenInternalURL, gateway.Handler(handler.AuthGetToken))
+	internalAPI.POST(AuthMFACodeInternalURL, gateway.Handler(handler.AuthMFACode))
 
 	internalAPI.GET(GetDeviceByPublicURLAddress, gateway.Handler(handler.GetDeviceByPublicURLAddress))
 	internalAPI.POST(OfflineDeviceURL, gateway.Handler(handler.OfflineDevice))
@@ -40,6 +41,7 @@ func NewRouter(service services.Service) *echo.Echo {
 	internalAPI.POST(FinishSessionURL, gateway.Handler(handler.FinishSession))
 	internalAPI.POST(KeepAliveSessionURL, gateway.Handler(handler.KeepAliveSession))
 	internalAPI.POST(RecordSessionURL, gateway.Handler(handler.RecordSession))
+	internalAPI.POST(AddSessionEventURL, gateway.Handler(handler.AddSessionEvent))
 
 	internalAPI.GET(GetPublicKeyURL, gateway.Handler(handler.GetPublicKey))
 	internalAPI.POST(CreatePrivateKeyURL, gateway.Handler(handler.CreatePrivateKey))
@@ -63,6 +65,7 @@ func NewRouter(service services.Service) *echo.Echo {
 
 	publicAPI.PATCH(UpdateUserDataURL, gateway.Handler(handler.UpdateUserData))
 	publicAPI.PATCH(UpdateUserPasswordURL, gateway.Handler(handler.UpdateUserPassword))
+	publicAPI.PATCH(UpdateUserPreferencesURL, gateway.Handler(handler.UpdateUserPreferences))
 	publicAPI.PUT(EditSessionRecordStatusURL, gateway.Handler(handler.EditSessionRecordStatus))
 	publicAPI.GET(GetSessionRecordURL, gateway.Handler(handler.GetSessionRecord))
 
@@ -73,9 +76,28 @@ func NewRouter(service services.Service) *echo.Echo {
 	publicAPI.PATCH(RenameDeviceURL, gateway.Handler(handler.RenameDevice))
 	publicAPI.PATCH(UpdateDeviceStatusURL, gateway.Handler(handler.UpdateDeviceStatus))
 
+	publicAPI.POST(DeviceFileChallengesURL, gateway.Handler(handler.CreateDeviceFileChallenge))
+	publicAPI.GET(ListDeviceFilesURL, gateway.Handler(handler.ListDeviceFiles))
+	publicAPI.GET(DeviceFilesURL, gateway.Handler(handler.DownloadDeviceFile))
+	publicAPI.PUT(DeviceFilesURL, gateway.Handler(handler.UploadDeviceFile))
+	publicAPI.DELETE(DeviceFilesURL, gateway.Handler(handler.RemoveDeviceFile))
+
+	publicAPI.POST(CreateJobURL, gateway.Handler(handler.CreateJob))
+	publicAPI.GET(ListJobsURL, gateway.Handler(handler.ListJobs))
+	publicAPI.GET(GetJobURL, gateway.Handler(handler.GetJob))
+	publicAPI.POST(CancelJobURL, gateway.Handler(handler.CancelJob))
+
+	publicAPI.POST(CreateJobScheduleURL, gateway.Handler(handler.CreateJobSchedule))
+	publicAPI.GET(ListJobSchedulesURL, gateway.Handler(handler.ListJobSchedules))
+	publicAPI.GET(GetJobScheduleURL, gateway.Handler(handler.GetJobSchedule))
+	publicAPI.PATCH(UpdateJobScheduleURL, gateway.Handler(handler.UpdateJobSchedule))
+	publicAPI.DELETE(DeleteJobScheduleURL, gateway.Handler(handler.DeleteJobSchedule))
+	publicAPI.GET(ListJobScheduleRunsURL, gateway.Handler(handler.ListJobScheduleRuns))
+
 	publicAPI.POST(CreateTagURL, gateway.Handler(handler.CreateDeviceTag))
 	publicAPI.DELETE(RemoveTagURL, gateway.Handler(handler.RemoveDeviceTag))
 	publicAPI.PUT(UpdateTagURL, gateway.Handler(handler.UpdateDeviceTag))
+	publicAPI.PUT(UpdateDeviceAliasesURL, gateway.Handler(handler.UpdateDeviceAliases))
 
 	publicAPI.GET(GetTagsURL, gateway.Handler(handler.GetTags))
 	publicAPI.PUT(RenameTagURL, gateway.Handler(handler.RenameTag))
@@ -84,6 +106,7 @@ func NewRouter(service services.Service) *echo.Echo {
 	publicAPI.GET(GetSessionsURL, apiMiddleware.Authorize(gateway.Handler(handler.GetSessionList)))
 	publicAPI.GET(GetSessionURL, apiMiddleware.Authorize(gateway.Handler(handler.GetSession)))
 	publicAPI.GET(PlaySessionURL, gateway.Handler(handler.PlaySession))
+	publicAPI.DELETE(CloseActiveSessionURL, gateway.Handler(handler.CloseActiveSession))
 	publicAPI.DELETE(RecordSessionURL, gateway.Handler(handler.DeleteRecordedSession))
 
 	publicAPI.GET(GetStatsURL, apiMiddleware.Authorize(gateway.Handler(handler.GetStats)))
@@ -107,6 +130,7 @@ func NewRouter(service services.Service) *echo.Echo {
 	publicAPI.POST(AddNamespaceUserURL, gateway.Handler(handler.AddNamespaceUser))
 	publicAPI.DELETE(RemoveNamespaceUserURL, gateway.Handler(handler.RemoveNamespaceUser))
 	publicAPI.PATCH(EditNamespaceUserURL, gateway.Handler(handler.EditNamespaceUser))
+	publicAPI.POST(CreateSSHCertificateURL, gateway.Handler(handler.CreateSSHCertificate))
 	publicAPI.GET(HealthCheckURL, gateway.Handler(handler.EvaluateHealth))
 
 	return e
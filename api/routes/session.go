@@ -5,6 +5,7 @@ import (
 	"strconv"
 
 	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -19,6 +20,8 @@ const (
 	KeepAliveSessionURL        = "/sessions/:uid/keepalive"
 	RecordSessionURL           = "/sessions/:uid/record"
 	PlaySessionURL             = "/sessions/:uid/play"
+	CloseActiveSessionURL      = "/sessions/:uid/active"
+	AddSessionEventURL         = "/sessions/:uid/events"
 )
 
 const (
@@ -26,15 +29,27 @@ const (
 )
 
 func (h *Handler) GetSessionList(c gateway.Context) error {
-	paginator := query.NewPaginator()
-	if err := c.Bind(paginator); err != nil {
+	type Query struct {
+		query.Paginator
+		query.Sorter
+		query.Filters
+	}
+
+	query := Query{}
+
+	if err := c.Bind(&query); err != nil {
 		return err
 	}
 
 	// TODO: normalize is not required when request is privileged
-	paginator.Normalize()
+	query.Paginator.Normalize()
+	query.Sorter.Normalize()
+
+	if err := query.Filters.Unmarshal(); err != nil {
+		return err
+	}
 
-	sessions, count, err := h.service.ListSessions(c.Ctx(), *paginator)
+	sessions, count, err := h.service.ListSessions(c.Ctx(), query.Paginator, query.Filters, query.Sorter)
 	if err != nil {
 		return err
 	}
@@ -103,6 +118,12 @@ func (h *Handler) FinishSession(c gateway.Context) error {
 		return err
 	}
 
+	if req.Reason != "" {
+		if err := h.service.SetSessionDisconnectReason(c.Ctx(), models.UID(req.UID), req.Reason); err != nil {
+			return err
+		}
+	}
+
 	return h.service.DeactivateSession(c.Ctx(), models.UID(req.UID))
 }
 
@@ -119,6 +140,38 @@ func (h *Handler) KeepAliveSession(c gateway.Context) error {
 	return h.service.KeepAliveSession(c.Ctx(), models.UID(req.UID))
 }
 
+func (h *Handler) AddSessionEvent(c gateway.Context) error {
+	var req requests.SessionEvent
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	return h.service.AddSessionEvent(c.Ctx(), models.UID(req.UID), &req)
+}
+
+func (h *Handler) CloseActiveSession(c gateway.Context) error {
+	var req requests.SessionClose
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := guard.EvaluatePermission(c.Role(), guard.Actions.Session.Close, func() error {
+		return h.service.CloseSession(c.Ctx(), models.UID(req.UID))
+	}); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) RecordSession(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
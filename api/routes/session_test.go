@@ -32,7 +32,9 @@ func TestGetSessionList(t *testing.T) {
 	cases := []struct {
 		description   string
 		paginator     query.Paginator
-		requiredMocks func(paginator query.Paginator)
+		sorter        query.Sorter
+		filters       query.Filters
+		requiredMocks func(paginator query.Paginator, filters query.Filters, sorter query.Sorter)
 		expected      Expected
 	}{
 		{
@@ -41,23 +43,55 @@ func TestGetSessionList(t *testing.T) {
 				Page:    1,
 				PerPage: 10,
 			},
-			requiredMocks: func(paginator query.Paginator) {
-				mock.On("ListSessions", gomock.Anything, paginator).Return(nil, 0, svc.ErrNotFound).Once()
+			sorter:  query.Sorter{By: "started_at", Order: query.OrderDesc},
+			filters: query.Filters{},
+			requiredMocks: func(paginator query.Paginator, filters query.Filters, sorter query.Sorter) {
+				mock.On("ListSessions", gomock.Anything, paginator, filters, sorter).Return(nil, 0, svc.ErrNotFound).Once()
 			},
 			expected: Expected{
 				expectedSession: nil,
 				expectedStatus:  http.StatusNotFound,
 			},
 		},
+		{
+			description: "fails when sorting by an attribute not allowed",
+			paginator: query.Paginator{
+				Page:    1,
+				PerPage: 10,
+			},
+			sorter:  query.Sorter{By: "recorded", Order: query.OrderAsc},
+			filters: query.Filters{},
+			requiredMocks: func(paginator query.Paginator, filters query.Filters, sorter query.Sorter) {
+				mock.On("ListSessions", gomock.Anything, paginator, filters, sorter).Return(nil, 0, svc.ErrSessionSortInvalid).Once()
+			},
+			expected: Expected{
+				expectedSession: nil,
+				expectedStatus:  http.StatusBadRequest,
+			},
+		},
 		{
 			description: "success when try to searching a session list of a existing session",
 			paginator: query.Paginator{
 				Page:    1,
 				PerPage: 10,
 			},
-			requiredMocks: func(paginator query.Paginator) {
+			sorter: query.Sorter{By: "last_seen", Order: query.OrderAsc},
+			filters: query.Filters{
+				Raw: "W3sidHlwZSI6InByb3BlcnR5IiwicGFyYW1zIjp7Im5hbWUiOiJhY3RpdmUiLCJvcGVyYXRvciI6ImJvb2wiLCJ2YWx1ZSI6InRydWUifX1d",
+				Data: []query.Filter{
+					{
+						Type: "property",
+						Params: &query.FilterProperty{
+							Name:     "active",
+							Operator: "bool",
+							Value:    "true",
+						},
+					},
+				},
+			},
+			requiredMocks: func(paginator query.Paginator, filters query.Filters, sorter query.Sorter) {
 				ss := []models.Session{}
-				mock.On("ListSessions", gomock.Anything, paginator).Return(ss, 1, nil).Once()
+				mock.On("ListSessions", gomock.Anything, paginator, filters, sorter).Return(ss, 1, nil).Once()
 			},
 			expected: Expected{
 				expectedSession: []models.Session{},
@@ -68,9 +102,21 @@ func TestGetSessionList(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.description, func(t *testing.T) {
-			tc.requiredMocks(tc.paginator)
+			tc.requiredMocks(tc.paginator, tc.filters, tc.sorter)
 
-			jsonData, err := json.Marshal(tc.paginator)
+			type Query struct {
+				query.Paginator
+				query.Sorter
+				query.Filters
+			}
+
+			b := Query{
+				Paginator: tc.paginator,
+				Sorter:    tc.sorter,
+				Filters:   tc.filters,
+			}
+
+			jsonData, err := json.Marshal(b)
 			if err != nil {
 				assert.NoError(t, err)
 			}
@@ -267,6 +313,7 @@ func TestFinishSession(t *testing.T) {
 	cases := []struct {
 		title          string
 		uid            string
+		body           string
 		requiredMocks  func()
 		expectedStatus int
 	}{
@@ -292,13 +339,30 @@ func TestFinishSession(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			title:          "fails when the disconnect reason is invalid",
+			uid:            "123",
+			body:           `{"reason": "invalid"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "success when try to finishing an existing session with a disconnect reason",
+			uid:   "12345",
+			body:  `{"reason": "idle_timeout"}`,
+			requiredMocks: func() {
+				mock.On("SetSessionDisconnectReason", gomock.Anything, models.UID("12345"), models.SessionDisconnectReasonIdleTimeout).Return(nil).Once()
+				mock.On("DeactivateSession", gomock.Anything, models.UID("12345")).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.title, func(t *testing.T) {
 			tc.requiredMocks()
 
-			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/internal/sessions/%s/finish", tc.uid), nil)
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/internal/sessions/%s/finish", tc.uid), strings.NewReader(tc.body))
 			req.Header.Set("Content-Type", "application/json")
 			req.Header.Set("X-Role", guard.RoleOwner)
 			rec := httptest.NewRecorder()
@@ -312,3 +376,131 @@ func TestFinishSession(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestAddSessionEvent(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the event type is missing",
+			uid:            "123",
+			body:           `{"data": {"host": "localhost"}}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when try to add an event to a non-existing session",
+			uid:   "1234",
+			body:  `{"type": "port_forwarding_denied", "data": {"host": "localhost", "port": "80"}}`,
+			requiredMocks: func() {
+				mock.On("AddSessionEvent", gomock.Anything, models.UID("1234"), &requests.SessionEvent{
+					SessionIDParam: requests.SessionIDParam{UID: "1234"},
+					Type:           models.SessionEventTypePortForwardingDenied,
+					Data:           map[string]string{"host": "localhost", "port": "80"},
+				}).Return(svc.ErrSessionNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when try to add an event to an existing session",
+			uid:   "123",
+			body:  `{"type": "port_forwarding_denied", "data": {"host": "localhost", "port": "80"}}`,
+			requiredMocks: func() {
+				mock.On("AddSessionEvent", gomock.Anything, models.UID("123"), &requests.SessionEvent{
+					SessionIDParam: requests.SessionIDParam{UID: "123"},
+					Type:           models.SessionEventTypePortForwardingDenied,
+					Data:           map[string]string{"host": "localhost", "port": "80"},
+				}).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/internal/sessions/%s/events", tc.uid), strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCloseActiveSession(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when role does not have the permission to close the session",
+			uid:            "123",
+			role:           guard.RoleOperator,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when try to close a non-existing session",
+			uid:   "1234",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CloseSession", gomock.Anything, models.UID("1234")).Return(svc.ErrSessionNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "fails when try to close a session that is not active",
+			uid:   "1234",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CloseSession", gomock.Anything, models.UID("1234")).Return(svc.ErrSessionNotActive).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "success when try to close an active session",
+			uid:   "123",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CloseSession", gomock.Anything, models.UID("123")).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/sessions/%s/active", tc.uid), nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -89,6 +89,10 @@ func (h *Handler) CreatePublicKey(c gateway.Context) error {
 		req.TenantID = tenant
 	}
 
+	if c.ID() != nil {
+		req.CreatedBy = c.ID().ID
+	}
+
 	var res *responses.PublicKeyCreate
 	err := guard.EvaluatePermission(c.Role(), guard.Actions.PublicKey.Create, func() error {
 		var err error
@@ -13,6 +13,8 @@ import (
 const (
 	UpdateUserDataURL     = "/users/:id/data"
 	UpdateUserPasswordURL = "/users/:id/password" //nolint:gosec
+	// UpdateUserPreferencesURL updates the user's preferences, like the default namespace.
+	UpdateUserPreferencesURL = "/users/:id/preferences"
 )
 
 const (
@@ -72,3 +74,22 @@ func (h *Handler) UpdateUserPassword(c gateway.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+func (h *Handler) UpdateUserPreferences(c gateway.Context) error {
+	var req requests.UserPreferencesUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.UpdateUserPreferences(c.Ctx(), req.ID, models.UserPreferences{
+		DefaultNamespace: req.DefaultNamespace,
+	}); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -241,3 +241,75 @@ func TestUpdateUserPassword(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestUpdateUserPreferences(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title             string
+		uid               string
+		updatePayloadMock requests.UserPreferencesUpdate
+		requiredMocks     func(updatePayloadMock requests.UserPreferencesUpdate)
+		expectedStatus    int
+	}{
+		{
+			title: "fails when the default namespace isn't a tenant ID",
+			uid:   "123",
+			updatePayloadMock: requests.UserPreferencesUpdate{
+				UserParam:        requests.UserParam{ID: "123"},
+				DefaultNamespace: "namespace",
+			},
+			requiredMocks:  func(updatePayloadMock requests.UserPreferencesUpdate) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the user isn't a member of the default namespace",
+			uid:   "123",
+			updatePayloadMock: requests.UserPreferencesUpdate{
+				UserParam:        requests.UserParam{ID: "123"},
+				DefaultNamespace: "00000000-0000-4000-0000-000000000000",
+			},
+			requiredMocks: func(updatePayloadMock requests.UserPreferencesUpdate) {
+				mock.On("UpdateUserPreferences", gomock.Anything, "123", models.UserPreferences{DefaultNamespace: updatePayloadMock.DefaultNamespace}).
+					Return(svc.NewErrNamespaceMemberNotFound("123", nil)).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when updating the default namespace",
+			uid:   "123",
+			updatePayloadMock: requests.UserPreferencesUpdate{
+				UserParam:        requests.UserParam{ID: "123"},
+				DefaultNamespace: "00000000-0000-4000-0000-000000000000",
+			},
+			requiredMocks: func(updatePayloadMock requests.UserPreferencesUpdate) {
+				mock.On("UpdateUserPreferences", gomock.Anything, "123", models.UserPreferences{DefaultNamespace: updatePayloadMock.DefaultNamespace}).
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks(tc.updatePayloadMock)
+
+			jsonData, err := json.Marshal(tc.updatePayloadMock)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/users/%s/preferences", tc.uid), strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
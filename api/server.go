@@ -56,7 +56,10 @@ var serverCmd = &cobra.Command{
 
 		log.Info("Connected to MongoDB")
 
-		worker, err := workers.New(store)
+		// NOTICE: The internal client enqueues the jobs created by the service, executed by the workers through it.
+		requestClient := requests.NewClientWithAsynq(cfg.RedisURI)
+
+		worker, err := workers.New(store, requestClient)
 		if err != nil {
 			log.WithError(err).Warn("Failed to create workers.")
 		}
@@ -73,7 +76,7 @@ var serverCmd = &cobra.Command{
 			cancel()
 		}()
 
-		return startServer(ctx, cfg, store, cache)
+		return startServer(ctx, cfg, store, cache, requestClient)
 	},
 }
 
@@ -131,7 +134,7 @@ func startSentry(dsn string) (*sentry.Client, error) {
 	return nil, errors.New("sentry DSN not provided")
 }
 
-func startServer(ctx context.Context, cfg *config, store store.Store, cache storecache.Cache) error {
+func startServer(ctx context.Context, cfg *config, store store.Store, cache storecache.Cache, requestClient requests.Client) error {
 	log.Info("Starting Sentry client")
 
 	reporter, err := startSentry(cfg.SentryDSN)
@@ -143,8 +146,6 @@ func startServer(ctx context.Context, cfg *config, store store.Store, cache stor
 
 	log.Info("Starting API server")
 
-	requestClient := requests.NewClient()
-
 	var locator geoip.Locator
 	if cfg.GeoIP {
 		log.Info("GeoIP feature is enable")
@@ -10,11 +10,15 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/pem"
+	"errors"
 	"strings"
 	"time"
 
 	"github.com/cnf/structhash"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/jwttoken"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/clock"
@@ -33,6 +37,7 @@ type AuthService interface {
 	AuthSwapToken(ctx context.Context, ID, tenant string) (*models.UserAuthResponse, error)
 	AuthUserInfo(ctx context.Context, username, tenant, token string) (*models.UserAuthResponse, error)
 	AuthMFA(ctx context.Context, id string) (bool, error)
+	AuthMFACode(ctx context.Context, id, code string) error
 	PublicKey() *rsa.PublicKey
 }
 
@@ -125,6 +130,34 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 	if err != nil {
 		return nil, NewErrDeviceNotFound(models.UID(device.UID), err)
 	}
+
+	// NOTICE: The agent's host key is pinned on the first authentication that reports it, so the SSH server can detect
+	// when another key is used to impersonate the device. Agents older than this feature don't report it.
+	if req.HostKey != "" {
+		mismatch := false
+
+		switch dev.HostKey {
+		case "":
+			// NOTICE: The host key is only pinned when the device still has none, so when another authentication pins
+			// one first, the reported key is handled as a mismatch.
+			err := s.store.DeviceSetHostKey(ctx, models.UID(device.UID), req.HostKey)
+			switch {
+			case err == store.ErrNoDocuments:
+				mismatch = true
+			case err != nil:
+				return nil, NewErrDeviceNotFound(models.UID(device.UID), err)
+			}
+		case req.HostKey:
+		default:
+			mismatch = true
+		}
+
+		if mismatch {
+			log.WithFields(log.Fields{"uid": device.UID, "tenant_id": device.TenantID}).
+				Warn("device reported a host key different from the pinned one")
+		}
+	}
+
 	if err := s.cache.Set(ctx, strings.Join([]string{"auth_device", key}, "/"), &Device{Name: dev.Name, Namespace: namespace.Name}, time.Second*30); err != nil {
 		return nil, err
 	}
@@ -445,3 +478,74 @@ func (s *service) AuthUncacheToken(ctx context.Context, tenant, id string) error
 func (s *service) AuthMFA(ctx context.Context, id string) (bool, error) {
 	return s.store.GetStatusMFA(ctx, id)
 }
+
+const (
+	// MFACodeMaxAttempts is how many invalid TOTP codes a user can send to the SSH server before being blocked for
+	// [MFACodeAttemptsWindow] since the last one.
+	MFACodeMaxAttempts = 5
+	// MFACodeAttemptsWindow is how long the TOTP codes sent by a user are counted.
+	MFACodeAttemptsWindow = 5 * time.Minute
+	// mfaCodeValidity is how long a TOTP code is valid, considering the clock skew tolerated, so how long it's kept as
+	// used to not be accepted again.
+	mfaCodeValidity = 90 * time.Second
+)
+
+// AuthMFACode validates a TOTP code against the MFA's secret of the user, what is used by the SSH server when the
+// device's namespace requires MFA to connect. A code is accepted only once, and the user is blocked after
+// [MFACodeMaxAttempts] invalid codes.
+//
+// It returns an unauthorized error when the user doesn't have MFA enabled or the code is invalid.
+func (s *service) AuthMFACode(ctx context.Context, id, code string) error {
+	attemptsKey := strings.Join([]string{"mfa_code_attempts", id}, "/")
+
+	// NOTICE: Every attempt is counted, atomically, before its code is checked, so concurrent attempts cannot exceed
+	// the limit. A valid code resets the counter.
+	attempts, err := s.cache.Increment(ctx, attemptsKey, MFACodeAttemptsWindow)
+	if err != nil {
+		return err
+	}
+
+	if attempts > MFACodeMaxAttempts {
+		return NewErrAuthUnathorized(errors.New("too many invalid MFA codes"))
+	}
+
+	status, err := s.store.GetStatusMFA(ctx, id)
+	if err != nil {
+		return NewErrUserNotFound(id, err)
+	}
+
+	if !status {
+		return NewErrAuthUnathorized(errors.New("the user doesn't have MFA enabled"))
+	}
+
+	secret, err := s.store.GetSecret(ctx, id)
+	if err != nil {
+		return NewErrUserNotFound(id, err)
+	}
+
+	valid, err := totp.ValidateCustom(code, secret, clock.Now(), totp.ValidateOpts{
+		Period:    30,
+		Skew:      1,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	})
+	if err != nil || !valid {
+		return NewErrAuthUnathorized(errors.New("the MFA code is invalid"))
+	}
+
+	// NOTICE: The code's uses are counted atomically too, so only the first of concurrent uses of a code is accepted.
+	uses, err := s.cache.Increment(ctx, strings.Join([]string{"mfa_code_used", id, code}, "/"), mfaCodeValidity)
+	if err != nil {
+		return err
+	}
+
+	if uses > 1 {
+		return NewErrAuthUnathorized(errors.New("the MFA code is invalid"))
+	}
+
+	if err := s.cache.Delete(ctx, attemptsKey); err != nil {
+		log.WithError(err).WithField("id", id).Warn("failed to reset the invalid MFA codes")
+	}
+
+	return nil
+}
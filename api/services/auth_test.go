@@ -6,10 +6,16 @@ import (
 	"crypto/rsa"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	goerrors "errors"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/cnf/structhash"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
@@ -88,6 +94,100 @@ func TestAuthDevice(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestAuthDeviceHostKey(t *testing.T) {
+	ctx := context.TODO()
+
+	const hostKey = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGq0nJpNoV8dIh3zzN9ahLiv0UMsrc6T6BxSaVCrNu3B"
+
+	authReq := requests.DeviceAuth{
+		TenantID: "tenant",
+		Identity: &requests.DeviceIdentity{
+			MAC: "mac",
+		},
+		HostKey: hostKey,
+	}
+
+	auth := models.DeviceAuth{
+		Identity: &models.DeviceIdentity{
+			MAC: authReq.Identity.MAC,
+		},
+		TenantID: authReq.TenantID,
+		HostKey:  hostKey,
+	}
+	uid := sha256.Sum256(structhash.Dump(auth, 1))
+	device := models.Device{
+		UID: hex.EncodeToString(uid[:]),
+		Identity: &models.DeviceIdentity{
+			MAC: authReq.Identity.MAC,
+		},
+		TenantID:   authReq.TenantID,
+		LastSeen:   now,
+		RemoteAddr: "0.0.0.0",
+	}
+
+	namespace := &models.Namespace{Name: "group1", Owner: "hash1", TenantID: "tenant"}
+
+	cases := []struct {
+		description   string
+		pinned        string
+		requiredMocks func(mock *mocks.Store)
+	}{
+		{
+			description: "pins the host key when the device has none",
+			pinned:      "",
+			requiredMocks: func(mock *mocks.Store) {
+				mock.On("DeviceSetHostKey", ctx, models.UID(device.UID), hostKey).
+					Return(nil).Once()
+			},
+		},
+		{
+			description: "keeps the host key pinned by another authentication",
+			pinned:      "",
+			requiredMocks: func(mock *mocks.Store) {
+				mock.On("DeviceSetHostKey", ctx, models.UID(device.UID), hostKey).
+					Return(store.ErrNoDocuments).Once()
+			},
+		},
+		{
+			description:   "keeps the host key when it is already pinned",
+			pinned:        hostKey,
+			requiredMocks: func(_ *mocks.Store) {},
+		},
+		{
+			description:   "keeps the pinned host key when the device reports another one",
+			pinned:        "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl",
+			requiredMocks: func(_ *mocks.Store) {},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			mock := new(mocks.Store)
+
+			stored := device
+			stored.HostKey = tc.pinned
+
+			clockMock.On("Now").Return(now)
+
+			mock.On("NamespaceGet", ctx, namespace.TenantID).
+				Return(namespace, nil).Once()
+			mock.On("DeviceCreate", ctx, device, "").
+				Return(nil).Once()
+			mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+				Return(&stored, nil).Once()
+			tc.requiredMocks(mock)
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			authRes, err := service.AuthDevice(ctx, authReq, "0.0.0.0")
+			assert.NoError(t, err)
+			assert.Equal(t, device.UID, authRes.UID)
+
+			mock.AssertExpectations(t)
+		})
+	}
+}
+
 func TestAuthUser(t *testing.T) {
 	mock := new(mocks.Store)
 
@@ -763,3 +863,207 @@ func TestAuthGetToken(t *testing.T) {
 		})
 	}
 }
+
+// memoryCache is a [storecache.Cache] kept in memory, used to test services that depend on state stored in the cache.
+// The TTL is ignored.
+type memoryCache struct {
+	mu     sync.Mutex
+	values sync.Map
+}
+
+func (c *memoryCache) Get(_ context.Context, key string, value interface{}) error {
+	data, ok := c.values.Load(key)
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal(data.([]byte), value)
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	c.values.Store(key, data)
+
+	return nil
+}
+
+func (c *memoryCache) Delete(_ context.Context, key string) error {
+	c.values.Delete(key)
+
+	return nil
+}
+
+func (c *memoryCache) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var count int64
+	if err := c.Get(ctx, key, &count); err != nil {
+		return 0, err
+	}
+
+	count++
+
+	return count, c.Set(ctx, key, count, ttl)
+}
+
+func TestAuthMFACode(t *testing.T) {
+	ctx := context.TODO()
+
+	const secret = "JBSWY3DPEHPK3PXP"
+
+	code, err := totp.GenerateCodeCustom(secret, now, totp.ValidateOpts{
+		Period:    30,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	})
+	assert.NoError(t, err)
+
+	cases := []struct {
+		description   string
+		code          string
+		cached        func(cache storecache.Cache)
+		requiredMocks func(mock *mocks.Store)
+		expected      error
+	}{
+		{
+			description: "fails when the user has sent too many invalid codes",
+			code:        code,
+			cached: func(cache storecache.Cache) {
+				assert.NoError(t, cache.Set(ctx, "mfa_code_attempts/id", MFACodeMaxAttempts, MFACodeAttemptsWindow))
+			},
+			requiredMocks: func(mock *mocks.Store) {},
+			expected:      NewErrAuthUnathorized(goerrors.New("too many invalid MFA codes")),
+		},
+		{
+			description: "fails when the user is not found",
+			code:        code,
+			requiredMocks: func(mock *mocks.Store) {
+				mock.On("GetStatusMFA", ctx, "id").Return(false, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrUserNotFound("id", store.ErrNoDocuments),
+		},
+		{
+			description: "fails when the user doesn't have MFA enabled",
+			code:        code,
+			requiredMocks: func(mock *mocks.Store) {
+				mock.On("GetStatusMFA", ctx, "id").Return(false, nil).Once()
+			},
+			expected: NewErrAuthUnathorized(goerrors.New("the user doesn't have MFA enabled")),
+		},
+		{
+			description: "fails when the code is invalid",
+			code:        "000000",
+			requiredMocks: func(mock *mocks.Store) {
+				mock.On("GetStatusMFA", ctx, "id").Return(true, nil).Once()
+				mock.On("GetSecret", ctx, "id").Return(secret, nil).Once()
+			},
+			expected: NewErrAuthUnathorized(goerrors.New("the MFA code is invalid")),
+		},
+		{
+			description: "fails when the code was already used",
+			code:        code,
+			cached: func(cache storecache.Cache) {
+				_, err := cache.Increment(ctx, "mfa_code_used/id/"+code, mfaCodeValidity)
+				assert.NoError(t, err)
+			},
+			requiredMocks: func(mock *mocks.Store) {
+				mock.On("GetStatusMFA", ctx, "id").Return(true, nil).Once()
+				mock.On("GetSecret", ctx, "id").Return(secret, nil).Once()
+			},
+			expected: NewErrAuthUnathorized(goerrors.New("the MFA code is invalid")),
+		},
+		{
+			description: "succeeds when the code is valid",
+			code:        code,
+			cached: func(cache storecache.Cache) {
+				assert.NoError(t, cache.Set(ctx, "mfa_code_attempts/id", MFACodeMaxAttempts-1, MFACodeAttemptsWindow))
+			},
+			requiredMocks: func(mock *mocks.Store) {
+				mock.On("GetStatusMFA", ctx, "id").Return(true, nil).Once()
+				mock.On("GetSecret", ctx, "id").Return(secret, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			mock := new(mocks.Store)
+			tc.requiredMocks(mock)
+
+			clockMock.On("Now").Return(now)
+
+			cache := new(memoryCache)
+			if tc.cached != nil {
+				tc.cached(cache)
+			}
+
+			service := NewService(store.Store(mock), privateKey, publicKey, cache, clientMock, nil)
+
+			err := service.AuthMFACode(ctx, "id", tc.code)
+			assert.Equal(t, tc.expected, err)
+
+			mock.AssertExpectations(t)
+		})
+	}
+
+	t.Run("counts the invalid codes and rejects a code used twice", func(t *testing.T) {
+		mock := new(mocks.Store)
+		mock.On("GetStatusMFA", ctx, "id").Return(true, nil)
+		mock.On("GetSecret", ctx, "id").Return(secret, nil)
+
+		clockMock.On("Now").Return(now)
+
+		cache := new(memoryCache)
+		service := NewService(store.Store(mock), privateKey, publicKey, cache, clientMock, nil)
+
+		assert.Error(t, service.AuthMFACode(ctx, "id", "000000"))
+
+		var attempts int
+		assert.NoError(t, cache.Get(ctx, "mfa_code_attempts/id", &attempts))
+		assert.Equal(t, 1, attempts)
+
+		assert.NoError(t, service.AuthMFACode(ctx, "id", code))
+
+		attempts = 0
+		assert.NoError(t, cache.Get(ctx, "mfa_code_attempts/id", &attempts))
+		assert.Equal(t, 0, attempts)
+
+		assert.Equal(t, NewErrAuthUnathorized(goerrors.New("the MFA code is invalid")), service.AuthMFACode(ctx, "id", code))
+	})
+
+	t.Run("accepts only one of concurrent uses of a code", func(t *testing.T) {
+		mock := new(mocks.Store)
+		mock.On("GetStatusMFA", ctx, "id").Return(true, nil)
+		mock.On("GetSecret", ctx, "id").Return(secret, nil)
+
+		clockMock.On("Now").Return(now)
+
+		cache := new(memoryCache)
+		service := NewService(store.Store(mock), privateKey, publicKey, cache, clientMock, nil)
+
+		var accepted atomic.Int32
+
+		wg := new(sync.WaitGroup)
+		for i := 0; i < MFACodeMaxAttempts; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				if service.AuthMFACode(ctx, "id", code) == nil {
+					accepted.Add(1)
+				}
+			}()
+		}
+
+		wg.Wait()
+
+		assert.Equal(t, int32(1), accepted.Load())
+	})
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"golang.org/x/crypto/ssh"
+)
+
+type CertificateService interface {
+	// CreateSSHCertificate issues a short-lived OpenSSH user certificate to a namespace's member, signed by the
+	// namespace's certificate authority. The certificate's principals are the usernames the namespace maps to the
+	// member's role.
+	CreateSSHCertificate(ctx context.Context, req *requests.SSHCertificateCreate, userID string) (*responses.SSHCertificateCreate, error)
+}
+
+const (
+	// SSHCertificateDefaultDuration is how long a certificate is valid when the request doesn't set it.
+	SSHCertificateDefaultDuration = time.Hour
+	// SSHCertificateClockSkew is how much a certificate is backdated to tolerate clocks behind the API's one.
+	SSHCertificateClockSkew = time.Minute
+)
+
+// sshCertificateExtensions are the extensions set by OpenSSH on user certificates by default.
+var sshCertificateExtensions = map[string]string{
+	"permit-X11-forwarding":   "",
+	"permit-agent-forwarding": "",
+	"permit-port-forwarding":  "",
+	"permit-pty":              "",
+	"permit-user-rc":          "",
+}
+
+func (s *service) CreateSSHCertificate(ctx context.Context, req *requests.SSHCertificateCreate, userID string) (*responses.SSHCertificateCreate, error) {
+	namespace, err := s.store.NamespaceGet(ctx, req.Tenant)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(req.Tenant, err)
+	}
+
+	member, ok := namespace.FindMember(userID)
+	if !ok {
+		return nil, NewErrNamespaceMemberNotFound(userID, nil)
+	}
+
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(req.PublicKey)) //nolint:dogsled
+	if err != nil {
+		return nil, NewErrSSHCertificateKeyInvalid(req.PublicKey, err)
+	}
+
+	var principals []string
+	if namespace.Settings != nil && namespace.Settings.UserCA != nil {
+		principals = namespace.Settings.UserCA.RolePrincipals[member.Role]
+	}
+
+	if len(principals) == 0 {
+		return nil, NewErrSSHCertificatePrincipals(nil)
+	}
+
+	signer, err := s.namespaceCA(ctx, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	serial := make([]byte, 8)
+	if _, err := rand.Read(serial); err != nil {
+		return nil, err
+	}
+
+	duration := SSHCertificateDefaultDuration
+	if req.Duration > 0 {
+		duration = time.Duration(req.Duration) * time.Minute
+	}
+
+	now := clock.Now()
+	validAfter := now.Add(-SSHCertificateClockSkew)
+	validBefore := now.Add(duration)
+
+	extensions := maps.Clone(sshCertificateExtensions)
+	extensions[models.CertificateExtensionTenant] = namespace.TenantID
+
+	cert := &ssh.Certificate{
+		Key:             key,
+		Serial:          binary.BigEndian.Uint64(serial),
+		CertType:        ssh.UserCert,
+		KeyId:           userID,
+		ValidPrincipals: principals,
+		ValidAfter:      uint64(validAfter.Unix()),
+		ValidBefore:     uint64(validBefore.Unix()),
+		Permissions: ssh.Permissions{
+			Extensions: extensions,
+		},
+	}
+
+	if err := cert.SignCert(rand.Reader, signer); err != nil {
+		return nil, err
+	}
+
+	return &responses.SSHCertificateCreate{
+		Certificate: strings.TrimSpace(string(ssh.MarshalAuthorizedKey(cert))),
+		Serial:      cert.Serial,
+		Principals:  principals,
+		ValidAfter:  time.Unix(int64(cert.ValidAfter), 0),
+		ValidBefore: time.Unix(int64(cert.ValidBefore), 0),
+	}, nil
+}
+
+// namespaceCA returns the signer of the namespace's certificate authority, creating it when the namespace has none.
+func (s *service) namespaceCA(ctx context.Context, namespace *models.Namespace) (ssh.Signer, error) {
+	if namespace.CA == nil {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+
+		block, err := ssh.MarshalPrivateKey(key, "")
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey, err := ssh.NewPublicKey(key.Public())
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey, err := s.sealCAKey(pem.EncodeToMemory(block))
+		if err != nil {
+			return nil, err
+		}
+
+		ca := &models.NamespaceCA{
+			PublicKey:  strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+			PrivateKey: privateKey,
+		}
+
+		// NOTICE: Concurrent requests may create a certificate authority at the same time, but only the first one is
+		// kept, so the namespace is read again to sign with it.
+		if err := s.store.NamespaceSetCA(ctx, namespace.TenantID, ca); err != nil {
+			return nil, err
+		}
+
+		tenant := namespace.TenantID
+
+		namespace, err = s.store.NamespaceGet(ctx, tenant)
+		if err != nil {
+			return nil, NewErrNamespaceNotFound(tenant, err)
+		}
+
+		if namespace.CA == nil {
+			return nil, NewErrNamespaceNotFound(tenant, nil)
+		}
+	}
+
+	privateKey, err := s.openCAKey(namespace.CA.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssh.ParsePrivateKey(privateKey)
+}
+
+// caKeyCipher returns the cipher used to encrypt the private keys of the namespaces' certificate authorities, keyed by
+// a hash of the API's private key.
+func (s *service) caKeyCipher() (cipher.AEAD, error) {
+	sum := sha256.Sum256(x509.MarshalPKCS1PrivateKey(s.privKey))
+
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// sealCAKey encrypts the private key of a namespace's certificate authority, prefixing it with the nonce used.
+func (s *service) sealCAKey(key []byte) ([]byte, error) {
+	aead, err := s.caKeyCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return aead.Seal(nonce, nonce, key, nil), nil
+}
+
+// openCAKey decrypts the private key of a namespace's certificate authority encrypted by [service.sealCAKey].
+func (s *service) openCAKey(sealed []byte) ([]byte, error) {
+	aead, err := s.caKeyCipher()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrSSHCertificateAuthority
+	}
+
+	key, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrSSHCertificateAuthority
+	}
+
+	return key, nil
+}
@@ -0,0 +1,270 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestCreateSSHCertificate(t *testing.T) {
+	storeMock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	block, err := ssh.MarshalPrivateKey(caKey, "")
+	require.NoError(t, err)
+
+	caSigner, err := ssh.NewSignerFromKey(caKey)
+	require.NoError(t, err)
+
+	sealed, err := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil).
+		sealCAKey(pem.EncodeToMemory(block))
+	require.NoError(t, err)
+
+	ca := &models.NamespaceCA{
+		PublicKey:  strings.TrimSpace(string(ssh.MarshalAuthorizedKey(caSigner.PublicKey()))),
+		PrivateKey: sealed,
+	}
+
+	userKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	userPublicKey, err := ssh.NewPublicKey(userKey)
+	require.NoError(t, err)
+
+	user := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(userPublicKey)))
+
+	settings := &models.NamespaceSettings{
+		UserCA: &models.UserCA{
+			RolePrincipals: map[string][]string{
+				guard.RoleOperator: {"deploy", "www-data"},
+			},
+		},
+	}
+
+	type Expected struct {
+		principals []string
+		duration   time.Duration
+		authority  ssh.PublicKey
+		err        error
+	}
+
+	cases := []struct {
+		description   string
+		req           *requests.SSHCertificateCreate
+		userID        string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when namespace is not found",
+			req:         &requests.SSHCertificateCreate{TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"}, PublicKey: user},
+			userID:      "member",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{err: NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when user is not a member of the namespace",
+			req:         &requests.SSHCertificateCreate{TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"}, PublicKey: user},
+			userID:      "other",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Members:  []models.Member{{ID: "member", Role: guard.RoleOperator}},
+						Settings: settings,
+						CA:       ca,
+					}, nil).Once()
+			},
+			expected: Expected{err: NewErrNamespaceMemberNotFound("other", nil)},
+		},
+		{
+			description: "fails when public key is invalid",
+			req:         &requests.SSHCertificateCreate{TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"}, PublicKey: "invalid"},
+			userID:      "member",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Members:  []models.Member{{ID: "member", Role: guard.RoleOperator}},
+						Settings: settings,
+						CA:       ca,
+					}, nil).Once()
+			},
+			expected: Expected{err: NewErrSSHCertificateKeyInvalid("invalid", errors.New("ssh: no key found"))},
+		},
+		{
+			description: "fails when namespace maps no usernames to the member's role",
+			req:         &requests.SSHCertificateCreate{TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"}, PublicKey: user},
+			userID:      "member",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Members:  []models.Member{{ID: "member", Role: guard.RoleObserver}},
+						Settings: settings,
+						CA:       ca,
+					}, nil).Once()
+			},
+			expected: Expected{err: NewErrSSHCertificatePrincipals(nil)},
+		},
+		{
+			description: "fails when the namespace's certificate authority cannot be saved",
+			req:         &requests.SSHCertificateCreate{TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"}, PublicKey: user},
+			userID:      "member",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Members:  []models.Member{{ID: "member", Role: guard.RoleOperator}},
+						Settings: settings,
+					}, nil).Once()
+
+				storeMock.On("NamespaceSetCA", ctx, "00000000-0000-4000-0000-000000000000", mock.AnythingOfType("*models.NamespaceCA")).
+					Return(errors.New("error")).Once()
+			},
+			expected: Expected{err: errors.New("error")},
+		},
+		{
+			description: "succeeds creating the namespace's certificate authority",
+			req:         &requests.SSHCertificateCreate{TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"}, PublicKey: user},
+			userID:      "member",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Members:  []models.Member{{ID: "member", Role: guard.RoleOperator}},
+						Settings: settings,
+					}, nil).Once()
+
+				var created *models.NamespaceCA
+				storeMock.On("NamespaceSetCA", ctx, "00000000-0000-4000-0000-000000000000", mock.MatchedBy(func(ca *models.NamespaceCA) bool {
+					created = ca
+
+					return ca.PublicKey != "" && !strings.Contains(string(ca.PrivateKey), "PRIVATE KEY")
+				})).Return(nil).Once()
+
+				storeMock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(func(context.Context, string) *models.Namespace {
+						return &models.Namespace{
+							TenantID: "00000000-0000-4000-0000-000000000000",
+							Members:  []models.Member{{ID: "member", Role: guard.RoleOperator}},
+							Settings: settings,
+							CA:       created,
+						}
+					}, nil).Once()
+
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{principals: []string{"deploy", "www-data"}, duration: time.Hour},
+		},
+		{
+			description: "succeeds signing with the certificate authority created concurrently",
+			req:         &requests.SSHCertificateCreate{TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"}, PublicKey: user},
+			userID:      "member",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Members:  []models.Member{{ID: "member", Role: guard.RoleOperator}},
+						Settings: settings,
+					}, nil).Once()
+
+				storeMock.On("NamespaceSetCA", ctx, "00000000-0000-4000-0000-000000000000", mock.AnythingOfType("*models.NamespaceCA")).
+					Return(nil).Once()
+
+				storeMock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Members:  []models.Member{{ID: "member", Role: guard.RoleOperator}},
+						Settings: settings,
+						CA:       ca,
+					}, nil).Once()
+
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{principals: []string{"deploy", "www-data"}, duration: time.Hour, authority: caSigner.PublicKey()},
+		},
+		{
+			description: "succeeds with the request's duration",
+			req:         &requests.SSHCertificateCreate{TenantParam: requests.TenantParam{Tenant: "00000000-0000-4000-0000-000000000000"}, PublicKey: user, Duration: 15},
+			userID:      "member",
+			requiredMocks: func() {
+				storeMock.On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Members:  []models.Member{{ID: "member", Role: guard.RoleOperator}},
+						Settings: settings,
+						CA:       ca,
+					}, nil).Once()
+
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{principals: []string{"deploy", "www-data"}, duration: 15 * time.Minute, authority: caSigner.PublicKey()},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(storeMock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			res, err := service.CreateSSHCertificate(ctx, tc.req, tc.userID)
+			if tc.expected.err != nil {
+				assert.Nil(t, res)
+				assert.Equal(t, tc.expected.err, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(res.Certificate)) //nolint:dogsled
+			require.NoError(t, err)
+
+			cert, ok := key.(*ssh.Certificate)
+			require.True(t, ok)
+
+			assert.Equal(t, uint32(ssh.UserCert), cert.CertType)
+			assert.Equal(t, userPublicKey.Marshal(), cert.Key.Marshal())
+			assert.Equal(t, tc.expected.principals, cert.ValidPrincipals)
+			assert.Equal(t, tc.expected.principals, res.Principals)
+			assert.Equal(t, res.Serial, cert.Serial)
+			assert.Equal(t, "00000000-0000-4000-0000-000000000000", cert.Extensions[models.CertificateExtensionTenant])
+			assert.Equal(t, now.Add(-SSHCertificateClockSkew).Unix(), int64(cert.ValidAfter))
+			assert.Equal(t, now.Add(tc.expected.duration).Unix(), int64(cert.ValidBefore))
+
+			if tc.expected.authority != nil {
+				assert.Equal(t, tc.expected.authority.Marshal(), cert.SignatureKey.Marshal())
+			}
+
+			checker := &ssh.CertChecker{Clock: func() time.Time { return now }}
+			assert.NoError(t, checker.CheckCert(tc.expected.principals[0], cert))
+		})
+	}
+
+	storeMock.AssertExpectations(t)
+}
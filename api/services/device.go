@@ -26,6 +26,7 @@ type DeviceService interface {
 	DeleteDevice(ctx context.Context, uid models.UID, tenant string) error
 	RenameDevice(ctx context.Context, uid models.UID, name, tenant string) error
 	LookupDevice(ctx context.Context, namespace, name string) (*models.Device, error)
+	LookupDeviceByUser(ctx context.Context, userID, name string) (*models.Device, error)
 	OffineDevice(ctx context.Context, uid models.UID, online bool) error
 	UpdateDeviceStatus(ctx context.Context, tenant string, uid models.UID, status models.DeviceStatus) error
 	SetDevicePosition(ctx context.Context, uid models.UID, ip string) error
@@ -164,6 +165,15 @@ func (s *service) RenameDevice(ctx context.Context, uid models.UID, name, tenant
 		return NewErrDeviceDuplicated(otherDevice.Name, err)
 	}
 
+	aliasedDevice, err := s.store.DeviceGetByAlias(ctx, updatedDevice.Name, tenant)
+	if err != nil && err != store.ErrNoDocuments {
+		return NewErrDeviceNotFound(models.UID(updatedDevice.UID), err)
+	}
+
+	if aliasedDevice != nil && aliasedDevice.UID != updatedDevice.UID {
+		return NewErrDeviceDuplicated(updatedDevice.Name, nil)
+	}
+
 	return s.store.DeviceRename(ctx, uid, name)
 }
 
@@ -180,6 +190,31 @@ func (s *service) LookupDevice(ctx context.Context, namespace, name string) (*mo
 	return device, nil
 }
 
+// LookupDeviceByUser looks for a device in the user's default namespace.
+//
+// It's used to resolve devices referenced only by their names, or aliases, when the user is known.
+func (s *service) LookupDeviceByUser(ctx context.Context, userID, name string) (*models.Device, error) {
+	user, _, err := s.store.UserGetByID(ctx, userID, false)
+	if err != nil || user == nil {
+		return nil, NewErrUserNotFound(userID, err)
+	}
+
+	if user.Preferences.DefaultNamespace == "" {
+		return nil, NewErrDeviceLookupNotFound("", name, nil)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, user.Preferences.DefaultNamespace)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(user.Preferences.DefaultNamespace, err)
+	}
+
+	if _, ok := namespace.FindMember(userID); !ok {
+		return nil, NewErrNamespaceMemberNotFound(userID, nil)
+	}
+
+	return s.LookupDevice(ctx, namespace.Name, name)
+}
+
 func (s *service) OffineDevice(ctx context.Context, uid models.UID, online bool) error {
 	err := s.store.DeviceSetOnline(ctx, uid, clock.Now(), online)
 	if err == store.ErrNoDocuments {
@@ -370,6 +405,15 @@ func (s *service) UpdateDevice(ctx context.Context, tenant string, uid models.UI
 		if otherDevice != nil {
 			return NewErrDeviceDuplicated(otherDevice.Name, err)
 		}
+
+		aliasedDevice, err := s.store.DeviceGetByAlias(ctx, *name, tenant)
+		if err != nil && err != store.ErrNoDocuments {
+			return NewErrDeviceNotFound(models.UID(*name), fmt.Errorf("failed to get device by alias: %w", err))
+		}
+
+		if aliasedDevice != nil && aliasedDevice.UID != device.UID {
+			return NewErrDeviceDuplicated(*name, nil)
+		}
 	}
 
 	if publicURL != nil {
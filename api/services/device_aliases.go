@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DeviceAliases contains the service's function to manage device aliases.
+type DeviceAliases interface {
+	UpdateDeviceAliases(ctx context.Context, tenant string, uid models.UID, aliases []string) error
+}
+
+// UpdateDeviceAliases updates a device's aliases, the other names the device can be reached by on its namespace.
+//
+// If the device does not exist, a NewErrDeviceNotFound error will be returned.
+// If an alias is the name or an alias of another device in the namespace, a NewErrDeviceAliasDuplicated error will be
+// returned.
+func (s *service) UpdateDeviceAliases(ctx context.Context, tenant string, uid models.UID, aliases []string) error {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	set := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		alias = strings.ToLower(alias)
+		if alias == device.Name || contains(set, alias) {
+			continue
+		}
+
+		conflict, err := s.deviceNameConflicts(ctx, tenant, uid, alias)
+		if err != nil {
+			return err
+		}
+
+		if conflict {
+			return NewErrDeviceAliasDuplicated(alias, nil)
+		}
+
+		set = append(set, alias)
+	}
+
+	if err := s.store.DeviceSetAliases(ctx, uid, set); err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	return nil
+}
+
+// deviceNameConflicts checks if the name is already used by a device other than the one with the UID, as its name or
+// one of its aliases, in the namespace.
+func (s *service) deviceNameConflicts(ctx context.Context, tenant string, uid models.UID, name string) (bool, error) {
+	other, err := s.store.DeviceGetByName(ctx, name, tenant, models.DeviceStatusAccepted)
+	if err != nil && err != store.ErrNoDocuments {
+		return false, err
+	}
+
+	if other != nil && other.UID != string(uid) {
+		return true, nil
+	}
+
+	other, err = s.store.DeviceGetByAlias(ctx, name, tenant)
+	if err != nil && err != store.ErrNoDocuments {
+		return false, err
+	}
+
+	return other != nil && other.UID != string(uid), nil
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/errors"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateDeviceAliases(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	device := &models.Device{UID: "uid", Name: "device", TenantID: "tenant"}
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		aliases       []string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("uid"),
+			aliases:     []string{"web01"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(nil, errors.New("error", "", 0)).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), errors.New("error", "", 0)),
+		},
+		{
+			description: "fails when the alias is the name of another device",
+			uid:         models.UID("uid"),
+			aliases:     []string{"web01"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				mock.On("DeviceGetByName", ctx, "web01", "tenant", models.DeviceStatusAccepted).
+					Return(&models.Device{UID: "other", Name: "web01"}, nil).Once()
+			},
+			expected: NewErrDeviceAliasDuplicated("web01", nil),
+		},
+		{
+			description: "fails when the alias is an alias of another device",
+			uid:         models.UID("uid"),
+			aliases:     []string{"web01"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				mock.On("DeviceGetByName", ctx, "web01", "tenant", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).Once()
+				mock.On("DeviceGetByAlias", ctx, "web01", "tenant").
+					Return(&models.Device{UID: "other", Name: "other", Aliases: []string{"web01"}}, nil).Once()
+			},
+			expected: NewErrDeviceAliasDuplicated("web01", nil),
+		},
+		{
+			description: "succeeds ignoring duplicated aliases and the device's name",
+			uid:         models.UID("uid"),
+			aliases:     []string{"WEB01", "web01", "device"},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				mock.On("DeviceGetByName", ctx, "web01", "tenant", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).Once()
+				mock.On("DeviceGetByAlias", ctx, "web01", "tenant").
+					Return(device, nil).Once()
+				mock.On("DeviceSetAliases", ctx, models.UID("uid"), []string{"web01"}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when removing all aliases",
+			uid:         models.UID("uid"),
+			aliases:     []string{},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				mock.On("DeviceSetAliases", ctx, models.UID("uid"), []string{}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+			err := service.UpdateDeviceAliases(ctx, "tenant", tc.uid, tc.aliases)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
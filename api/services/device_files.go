@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	req "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// DeviceFiles transfers files to and from the namespace's devices, through the SSH server, authenticated with the
+// credentials of a device's user.
+type DeviceFiles interface {
+	// CreateDeviceFileChallenge issues a challenge to be signed by a public key's private key to transfer files on the
+	// device with it. It's accepted only once, until it expires after [DeviceFileChallengeTTL].
+	CreateDeviceFileChallenge(ctx context.Context, tenant string, uid models.UID) (*models.FileChallenge, error)
+	// ListDeviceFiles lists the files of a directory on the device.
+	ListDeviceFiles(ctx context.Context, tenant string, req *requests.DeviceFile) ([]models.File, error)
+	// DownloadDeviceFile downloads a file from the device. The returned reader must be closed by the caller.
+	DownloadDeviceFile(ctx context.Context, tenant string, req *requests.DeviceFile) (io.ReadCloser, error)
+	// UploadDeviceFile uploads the content to a file on the device, replacing it when it already exists.
+	UploadDeviceFile(ctx context.Context, tenant string, req *requests.DeviceFile, content io.Reader) (*models.File, error)
+	// RemoveDeviceFile removes a file, or an empty directory, from the device.
+	RemoveDeviceFile(ctx context.Context, tenant string, req *requests.DeviceFile) error
+}
+
+// DeviceFileChallengeTTL is how long a challenge to transfer files can be used after it's issued.
+const DeviceFileChallengeTTL = time.Minute
+
+func (s *service) CreateDeviceFileChallenge(ctx context.Context, tenant string, uid models.UID) (*models.FileChallenge, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, uid, tenant); err != nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	challenge := &models.FileChallenge{
+		Challenge: uuid.Generate(),
+		ExpiresAt: clock.Now().Add(DeviceFileChallengeTTL),
+	}
+
+	if err := s.cache.Set(ctx, strings.Join([]string{"device_file_challenge", challenge.Challenge}, "/"), string(uid), DeviceFileChallengeTTL); err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
+
+// deviceFile checks if the device belongs to the namespace before its files are transferred and, when the user is
+// authenticated by a public key, consumes the challenge signed by it.
+func (s *service) deviceFile(ctx context.Context, tenant string, r *requests.DeviceFile) error {
+	if _, err := s.store.DeviceGetByUID(ctx, models.UID(r.UID), tenant); err != nil {
+		return NewErrDeviceNotFound(models.UID(r.UID), err)
+	}
+
+	if r.Fingerprint == "" {
+		return nil
+	}
+
+	key := strings.Join([]string{"device_file_challenge", r.Challenge}, "/")
+
+	var uid string
+	if err := s.cache.Get(ctx, key, &uid); err != nil {
+		return err
+	}
+
+	if uid == "" || uid != r.UID {
+		return NewErrDeviceFileChallenge(errors.New("the challenge wasn't issued to the device or expired"))
+	}
+
+	// NOTICE: The challenge's uses are counted atomically, so only the first of concurrent requests signed with it is
+	// accepted.
+	uses, err := s.cache.Increment(ctx, strings.Join([]string{"device_file_challenge_used", r.Challenge}, "/"), DeviceFileChallengeTTL)
+	if err != nil {
+		return err
+	}
+
+	if uses > 1 {
+		return NewErrDeviceFileChallenge(errors.New("the challenge was already used"))
+	}
+
+	if err := s.cache.Delete(ctx, key); err != nil {
+		log.WithError(err).WithField("uid", r.UID).Warn("failed to remove the used device file challenge")
+	}
+
+	return nil
+}
+
+// deviceFileError converts the SSH server's error to the service's one.
+func deviceFileError(r *requests.DeviceFile, err error) error {
+	switch {
+	case errors.Is(err, req.ErrNotFound):
+		return NewErrDeviceFileNotFound(r.Path, err)
+	case errors.Is(err, req.ErrFileForbidden):
+		return NewErrDeviceFileForbidden(err)
+	case errors.Is(err, req.ErrFileInvalid):
+		return NewErrDeviceFileInvalid(r.Path, err)
+	default:
+		return NewErrDeviceFileTransfer(r.Path, err)
+	}
+}
+
+func (s *service) ListDeviceFiles(ctx context.Context, tenant string, r *requests.DeviceFile) ([]models.File, error) {
+	if err := s.deviceFile(ctx, tenant, r); err != nil {
+		return nil, err
+	}
+
+	files, err := s.client.(req.Client).FileList(r)
+	if err != nil {
+		return nil, deviceFileError(r, err)
+	}
+
+	return files, nil
+}
+
+func (s *service) DownloadDeviceFile(ctx context.Context, tenant string, r *requests.DeviceFile) (io.ReadCloser, error) {
+	if err := s.deviceFile(ctx, tenant, r); err != nil {
+		return nil, err
+	}
+
+	content, err := s.client.(req.Client).FileDownload(r)
+	if err != nil {
+		return nil, deviceFileError(r, err)
+	}
+
+	return content, nil
+}
+
+func (s *service) UploadDeviceFile(ctx context.Context, tenant string, r *requests.DeviceFile, content io.Reader) (*models.File, error) {
+	if err := s.deviceFile(ctx, tenant, r); err != nil {
+		return nil, err
+	}
+
+	file, err := s.client.(req.Client).FileUpload(r, content)
+	if err != nil {
+		return nil, deviceFileError(r, err)
+	}
+
+	return file, nil
+}
+
+func (s *service) RemoveDeviceFile(ctx context.Context, tenant string, r *requests.DeviceFile) error {
+	if err := s.deviceFile(ctx, tenant, r); err != nil {
+		return err
+	}
+
+	if err := s.client.(req.Client).FileRemove(r); err != nil {
+		return deviceFileError(r, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	goerrors "errors"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListDeviceFiles(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	req := &requests.DeviceFile{
+		DeviceParam: requests.DeviceParam{UID: "device"},
+		Path:        "/etc",
+		User:        "root",
+		Fingerprint: "fingerprint",
+		Challenge:   "challenge",
+	}
+
+	type Expected struct {
+		files []models.File
+		err   error
+	}
+
+	cases := []struct {
+		name          string
+		challenge     string
+		used          bool
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name: "fails when the device isn't on the namespace",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("device"), "tenant").
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: Expected{err: NewErrDeviceNotFound("device", goerrors.New("error"))},
+		},
+		{
+			name: "fails when the challenge wasn't issued",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("device"), "tenant").
+					Return(&models.Device{UID: "device"}, nil).Once()
+			},
+			expected: Expected{err: NewErrDeviceFileChallenge(goerrors.New("the challenge wasn't issued to the device or expired"))},
+		},
+		{
+			name:      "fails when the challenge was issued to other device",
+			challenge: "other",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("device"), "tenant").
+					Return(&models.Device{UID: "device"}, nil).Once()
+			},
+			expected: Expected{err: NewErrDeviceFileChallenge(goerrors.New("the challenge wasn't issued to the device or expired"))},
+		},
+		{
+			name:      "fails when the challenge was already used",
+			challenge: "device",
+			used:      true,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("device"), "tenant").
+					Return(&models.Device{UID: "device"}, nil).Once()
+			},
+			expected: Expected{err: NewErrDeviceFileChallenge(goerrors.New("the challenge was already used"))},
+		},
+		{
+			name:      "fails when the directory isn't found on the device",
+			challenge: "device",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("device"), "tenant").
+					Return(&models.Device{UID: "device"}, nil).Once()
+				clientMock.On("FileList", req).
+					Return(nil, internalclient.ErrNotFound).Once()
+			},
+			expected: Expected{err: NewErrDeviceFileNotFound("/etc", internalclient.ErrNotFound)},
+		},
+		{
+			name:      "fails when the user cannot be authenticated on the device",
+			challenge: "device",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("device"), "tenant").
+					Return(&models.Device{UID: "device"}, nil).Once()
+				clientMock.On("FileList", req).
+					Return(nil, internalclient.ErrFileForbidden).Once()
+			},
+			expected: Expected{err: NewErrDeviceFileForbidden(internalclient.ErrFileForbidden)},
+		},
+		{
+			name:      "succeeds",
+			challenge: "device",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("device"), "tenant").
+					Return(&models.Device{UID: "device"}, nil).Once()
+				clientMock.On("FileList", req).
+					Return([]models.File{{Name: "hosts", Size: 10}}, nil).Once()
+			},
+			expected: Expected{files: []models.File{{Name: "hosts", Size: 10}}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			cache := new(memoryCache)
+			if tc.challenge != "" {
+				assert.NoError(t, cache.Set(ctx, "device_file_challenge/challenge", tc.challenge, DeviceFileChallengeTTL))
+			}
+
+			if tc.used {
+				_, err := cache.Increment(ctx, "device_file_challenge_used/challenge", DeviceFileChallengeTTL)
+				assert.NoError(t, err)
+			}
+
+			service := NewService(store.Store(mock), privateKey, publicKey, cache, clientMock, nil)
+			files, err := service.ListDeviceFiles(ctx, "tenant", req)
+			assert.Equal(t, tc.expected, Expected{files, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceFilesChallengeIsSingleUse(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	req := &requests.DeviceFile{
+		DeviceParam: requests.DeviceParam{UID: "device"},
+		Path:        "/etc",
+		User:        "root",
+		Fingerprint: "fingerprint",
+		Challenge:   "challenge",
+	}
+
+	mock.On("DeviceGetByUID", ctx, models.UID("device"), "tenant").
+		Return(&models.Device{UID: "device"}, nil).Twice()
+	clientMock.On("FileList", req).
+		Return([]models.File{{Name: "hosts", Size: 10}}, nil).Once()
+
+	cache := new(memoryCache)
+	assert.NoError(t, cache.Set(ctx, "device_file_challenge/challenge", "device", DeviceFileChallengeTTL))
+
+	service := NewService(store.Store(mock), privateKey, publicKey, cache, clientMock, nil)
+
+	_, err := service.ListDeviceFiles(ctx, "tenant", req)
+	assert.NoError(t, err)
+
+	_, err = service.ListDeviceFiles(ctx, "tenant", req)
+	assert.Error(t, err)
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateDeviceFileChallenge(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		challenge *models.FileChallenge
+		err       error
+	}
+
+	cases := []struct {
+		name          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name: "fails when the device isn't on the namespace",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("device"), "tenant").
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: Expected{err: NewErrDeviceNotFound("device", goerrors.New("error"))},
+		},
+		{
+			name: "succeeds",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("device"), "tenant").
+					Return(&models.Device{UID: "device"}, nil).Once()
+				uuidMock.On("Generate").Return("challenge").Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{
+				challenge: &models.FileChallenge{Challenge: "challenge", ExpiresAt: now.Add(DeviceFileChallengeTTL)},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			cache := new(memoryCache)
+
+			service := NewService(store.Store(mock), privateKey, publicKey, cache, clientMock, nil)
+			challenge, err := service.CreateDeviceFileChallenge(ctx, "tenant", "device")
+			assert.Equal(t, tc.expected, Expected{challenge, err})
+
+			if challenge != nil {
+				var uid string
+				assert.NoError(t, cache.Get(ctx, "device_file_challenge/challenge", &uid))
+				assert.Equal(t, "device", uid)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+	uuidMock.AssertExpectations(t)
+}
+
+func TestUploadDeviceFile(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	req := &requests.DeviceFile{
+		DeviceParam: requests.DeviceParam{UID: "device"},
+		Path:        "app.conf",
+		User:        "root",
+		Password:    "secret",
+	}
+
+	content := strings.NewReader("debug = true")
+
+	type Expected struct {
+		file *models.File
+		err  error
+	}
+
+	cases := []struct {
+		name          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name: "fails when the SSH server cannot transfer the file",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("device"), "tenant").
+					Return(&models.Device{UID: "device"}, nil).Once()
+				clientMock.On("FileUpload", req, io.Reader(content)).
+					Return(nil, internalclient.ErrFileTransfer).Once()
+			},
+			expected: Expected{err: NewErrDeviceFileTransfer("app.conf", internalclient.ErrFileTransfer)},
+		},
+		{
+			name: "succeeds",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("device"), "tenant").
+					Return(&models.Device{UID: "device"}, nil).Once()
+				clientMock.On("FileUpload", req, io.Reader(content)).
+					Return(&models.File{Name: "app.conf", Size: 12}, nil).Once()
+			},
+			expected: Expected{file: &models.File{Name: "app.conf", Size: 12}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			file, err := service.UploadDeviceFile(ctx, "tenant", req, content)
+			assert.Equal(t, tc.expected, Expected{file, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
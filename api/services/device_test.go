@@ -1505,6 +1505,26 @@ func TestRenameDevice(t *testing.T) {
 			},
 			expected: NewErrDeviceDuplicated("newname", nil),
 		},
+		{
+			description:   "fails when the name is an alias of another device",
+			tenant:        "tenant",
+			deviceNewName: "anewname",
+			uid:           models.UID("uid"),
+			device:        &models.Device{UID: "uid", Name: "name", TenantID: "tenant", Identity: &models.DeviceIdentity{MAC: "00:00:00:00:00:00"}, Status: "accepted"},
+			requiredMocks: func(device *models.Device) {
+				device2 := &models.Device{
+					UID:      "uid2",
+					Name:     "othername",
+					TenantID: "tenant",
+					Aliases:  []string{"anewname"},
+				}
+
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
+				mock.On("DeviceGetByName", ctx, "anewname", "tenant", models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
+				mock.On("DeviceGetByAlias", ctx, "anewname", "tenant").Return(device2, nil).Once()
+			},
+			expected: NewErrDeviceDuplicated("anewname", nil),
+		},
 		{
 			description:   "fails when the store device rename fails",
 			tenant:        "tenant",
@@ -1514,6 +1534,7 @@ func TestRenameDevice(t *testing.T) {
 			requiredMocks: func(device *models.Device) {
 				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
 				mock.On("DeviceGetByName", ctx, "anewname", "tenant", models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
+				mock.On("DeviceGetByAlias", ctx, "anewname", "tenant").Return(nil, store.ErrNoDocuments).Once()
 				mock.On("DeviceRename", ctx, models.UID("uid"), "anewname").Return(errors.New("error", "", 0)).Once()
 			},
 			expected: errors.New("error", "", 0),
@@ -1527,6 +1548,7 @@ func TestRenameDevice(t *testing.T) {
 			requiredMocks: func(device *models.Device) {
 				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").Return(device, nil).Once()
 				mock.On("DeviceGetByName", ctx, "anewname", "tenant", models.DeviceStatusAccepted).Return(nil, store.ErrNoDocuments).Once()
+				mock.On("DeviceGetByAlias", ctx, "anewname", "tenant").Return(nil, store.ErrNoDocuments).Once()
 				mock.On("DeviceRename", ctx, models.UID("uid"), "anewname").Return(nil).Once()
 			},
 			expected: nil,
@@ -1615,6 +1637,81 @@ func TestLookupDevice(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestLookupDeviceByUser(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		device *models.Device
+		err    error
+	}
+
+	device := &models.Device{UID: "uid", Name: "name", TenantID: "tenant", Status: "accepted"}
+	namespace := &models.Namespace{
+		Name:     "namespace",
+		TenantID: "tenant",
+		Members:  []models.Member{{ID: "507f1f77bcf86cd799439011", Role: "owner"}},
+	}
+
+	cases := []struct {
+		description   string
+		user          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the user is not found",
+			user:        "507f1f77bcf86cd799439011",
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "507f1f77bcf86cd799439011", false).Return(nil, 0, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrUserNotFound("507f1f77bcf86cd799439011", store.ErrNoDocuments)},
+		},
+		{
+			description: "fails when the user has no default namespace",
+			user:        "507f1f77bcf86cd799439011",
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "507f1f77bcf86cd799439011", false).
+					Return(&models.User{ID: "507f1f77bcf86cd799439011"}, 0, nil).Once()
+			},
+			expected: Expected{nil, NewErrDeviceLookupNotFound("", "name", nil)},
+		},
+		{
+			description: "fails when the user isn't a member of the default namespace anymore",
+			user:        "507f1f77bcf86cd799439012",
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "507f1f77bcf86cd799439012", false).
+					Return(&models.User{ID: "507f1f77bcf86cd799439012", Preferences: models.UserPreferences{DefaultNamespace: "tenant"}}, 0, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+			},
+			expected: Expected{nil, NewErrNamespaceMemberNotFound("507f1f77bcf86cd799439012", nil)},
+		},
+		{
+			description: "succeeds",
+			user:        "507f1f77bcf86cd799439011",
+			requiredMocks: func() {
+				mock.On("UserGetByID", ctx, "507f1f77bcf86cd799439011", false).
+					Return(&models.User{ID: "507f1f77bcf86cd799439011", Preferences: models.UserPreferences{DefaultNamespace: "tenant"}}, 0, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(namespace, nil).Once()
+				mock.On("DeviceLookup", ctx, "namespace", "name").Return(device, nil).Once()
+			},
+			expected: Expected{device, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			returnedDevice, err := service.LookupDeviceByUser(ctx, tc.user, "name")
+			assert.Equal(t, tc.expected, Expected{returnedDevice, err})
+		})
+	}
+	mock.AssertExpectations(t)
+}
+
 func TestOffineDevice(t *testing.T) {
 	mock := new(mocks.Store)
 
@@ -3406,6 +3503,31 @@ func TestDeviceUpdate(t *testing.T) {
 			},
 			expected: NewErrDeviceDuplicated("same", nil),
 		},
+		{
+			description: "fails when already exists a device with the name as alias",
+			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+			tenant:      "00000000-0000-0000-0000-000000000000",
+			name:        toPointer("aliased"),
+			publicKey:   nil,
+			requiredMocks: func(ctx context.Context) {
+				mock.On("DeviceGetByUID", ctx, models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), "00000000-0000-0000-0000-000000000000").
+					Return(&models.Device{
+						UID:  "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
+						Name: "name",
+					}, nil).Once()
+
+				mock.On("DeviceGetByName", ctx, "aliased", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).Once()
+
+				mock.On("DeviceGetByAlias", ctx, "aliased", "00000000-0000-0000-0000-000000000000").
+					Return(&models.Device{
+						UID:     "fb2de504e98d3ccab342b53d83395cd7fda297c71e8da550c31478bae0dbb8c5",
+						Name:    "same",
+						Aliases: []string{"aliased"},
+					}, nil).Once()
+			},
+			expected: NewErrDeviceDuplicated("aliased", nil),
+		},
 		{
 			description: "success when udpate device for a different name",
 			uid:         "d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e",
@@ -3422,6 +3544,9 @@ func TestDeviceUpdate(t *testing.T) {
 				mock.On("DeviceGetByName", ctx, "other", "00000000-0000-0000-0000-000000000000", models.DeviceStatusAccepted).
 					Return(nil, store.ErrNoDocuments).Once()
 
+				mock.On("DeviceGetByAlias", ctx, "other", "00000000-0000-0000-0000-000000000000").
+					Return(nil, store.ErrNoDocuments).Once()
+
 				mock.On("DeviceUpdate", ctx, "00000000-0000-0000-0000-000000000000", models.UID("d6c6a5e97217bbe4467eae46ab004695a766c5c43f70b95efd4b6a4d32b33c6e"), other, new(bool)).
 					Return(nil).Once()
 			},
@@ -78,6 +78,7 @@ var (
 	ErrUserUpdate                   = errors.New("user update", ErrLayer, ErrCodeStore)
 	ErrNamespaceNotFound            = errors.New("namespace not found", ErrLayer, ErrCodeNotFound)
 	ErrNamespaceInvalid             = errors.New("namespace invalid", ErrLayer, ErrCodeInvalid)
+	ErrNamespaceUserCAInvalid       = errors.New("namespace user ca invalid", ErrLayer, ErrCodeInvalid)
 	ErrNamespaceList                = errors.New("namespace member list", ErrLayer, ErrCodeNotFound)
 	ErrNamespaceDuplicated          = errors.New("namespace duplicated", ErrLayer, ErrCodeDuplicated)
 	ErrNamespaceMemberNotFound      = errors.New("member not found", ErrLayer, ErrCodeNotFound)
@@ -96,6 +97,7 @@ var (
 	ErrDeviceInvalid                = errors.New("device invalid", ErrLayer, ErrCodeInvalid)
 	ErrDeviceDuplicated             = errors.New("device duplicated", ErrLayer, ErrCodeDuplicated)
 	ErrDeviceLookupNotFound         = errors.New("device lookup not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceAliasDuplicated        = errors.New("device alias duplicated", ErrLayer, ErrCodeDuplicated)
 	ErrDeviceLimit                  = errors.New("device limit reached", ErrLayer, ErrCodePayment)
 	ErrDeviceStatusInvalid          = errors.New("device status invalid", ErrLayer, ErrCodeInvalid)
 	ErrDeviceStatusAccepted         = errors.New("device status accepted", ErrLayer, ErrCodeInvalid)
@@ -112,6 +114,26 @@ var (
 	ErrTokenSigned                  = errors.New("token signed", ErrLayer, ErrCodeInvalid)
 	ErrTypeAssertion                = errors.New("type assertion failed", ErrLayer, ErrCodeInvalid)
 	ErrSessionNotFound              = errors.New("session not found", ErrLayer, ErrCodeNotFound)
+	ErrSessionNotActive             = errors.New("session not active", ErrLayer, ErrCodeInvalid)
+	ErrSessionClose                 = errors.New("session close", ErrLayer, ErrCodeInvalid)
+	ErrSessionSortInvalid           = errors.New("session sort invalid", ErrLayer, ErrCodeInvalid)
+	ErrSSHCertificateKeyInvalid     = errors.New("ssh certificate public key invalid", ErrLayer, ErrCodeInvalid)
+	ErrSSHCertificatePrincipals     = errors.New("ssh certificate has no principals for the member's role", ErrLayer, ErrCodeForbidden)
+	ErrSSHCertificateAuthority      = errors.New("ssh certificate authority key cannot be decrypted", ErrLayer, ErrCodeStore)
+	ErrDeviceFileNotFound           = errors.New("device file not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceFileForbidden          = errors.New("device file forbidden", ErrLayer, ErrCodeForbidden)
+	ErrDeviceFileInvalid            = errors.New("device file invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceFileTransfer           = errors.New("device file transfer", ErrLayer, ErrCodeInvalid)
+	ErrDeviceFileChallenge          = errors.New("device file challenge", ErrLayer, ErrCodeForbidden)
+	ErrJobNotFound                  = errors.New("job not found", ErrLayer, ErrCodeNotFound)
+	ErrJobInvalid                   = errors.New("job invalid", ErrLayer, ErrCodeInvalid)
+	ErrJobNoDevices                 = errors.New("job has no devices", ErrLayer, ErrCodeInvalid)
+	ErrJobFinished                  = errors.New("job finished", ErrLayer, ErrCodeInvalid)
+	ErrJobEnqueue                   = errors.New("job enqueue", ErrLayer, ErrCodeStore)
+	ErrJobSignature                 = errors.New("job signature invalid", ErrLayer, ErrCodeForbidden)
+	ErrJobScheduleNotFound          = errors.New("job schedule not found", ErrLayer, ErrCodeNotFound)
+	ErrJobScheduleCron              = errors.New("job schedule cron invalid", ErrLayer, ErrCodeInvalid)
+	ErrJobScheduleCreator           = errors.New("job schedule creator required", ErrLayer, ErrCodeForbidden)
 	ErrAuthInvalid                  = errors.New("auth invalid", ErrLayer, ErrCodeInvalid)
 	ErrAuthUnathorized              = errors.New("auth unauthorized", ErrLayer, ErrCodeUnauthorized)
 	ErrNamespaceLimitReached        = errors.New("namespace limit reached", ErrLayer, ErrCodeLimit)
@@ -302,11 +324,31 @@ func NewErrSessionNotFound(id models.UID, next error) error {
 	return NewErrNotFound(ErrSessionNotFound, string(id), next)
 }
 
+// NewErrSessionNotActive returns an error when the session is not active.
+func NewErrSessionNotActive(id models.UID) error {
+	return NewErrInvalid(ErrSessionNotActive, map[string]interface{}{"uid": string(id)}, nil)
+}
+
+// NewErrSessionClose returns an error when the SSH server fails to close the session.
+func NewErrSessionClose(id models.UID, next error) error {
+	return NewErrInvalid(ErrSessionClose, map[string]interface{}{"uid": string(id)}, next)
+}
+
+// NewErrSessionSortInvalid returns an error when the session's listing is sorted by an attribute not allowed.
+func NewErrSessionSortInvalid(by string) error {
+	return NewErrInvalid(ErrSessionSortInvalid, map[string]interface{}{"sort_by": by}, nil)
+}
+
 // NewErrNamespaceList return an error to be used when cannot list namespaces.
 func NewErrNamespaceList(next error) error {
 	return NewErrInvalid(ErrNamespaceList, nil, next)
 }
 
+// NewErrNamespaceUserCAInvalid returns an error when the namespace's user certificate authority key is invalid.
+func NewErrNamespaceUserCAInvalid(key string, next error) error {
+	return NewErrInvalid(ErrNamespaceUserCAInvalid, map[string]interface{}{"public_key": key}, next)
+}
+
 // NewErrNamespaceInvalid returns an error to be used when the namespace is invalid.
 func NewErrNamespaceInvalid(next error) error {
 	return NewErrInvalid(ErrNamespaceInvalid, nil, next)
@@ -358,6 +400,12 @@ func NewErrDeviceLookupNotFound(namespace, name string, next error) error {
 	return NewErrNotFound(ErrDeviceLookupNotFound, fmt.Sprintf("device %s on namespace %s", name, namespace), next)
 }
 
+// NewErrDeviceAliasDuplicated returns an error to be used when the alias is already a name or an alias of another
+// device in the namespace.
+func NewErrDeviceAliasDuplicated(alias string, next error) error {
+	return NewErrDuplicated(ErrDeviceAliasDuplicated, []string{alias}, next)
+}
+
 // NewErrDeviceLimit returns an error to be used when the device limit is reached.
 func NewErrDeviceLimit(limit int, next error) error {
 	return NewErrLimit(ErrDeviceLimit, limit, next)
@@ -455,3 +503,85 @@ func NewErrBillingEvaluate(next error) error {
 func NewErrDeviceMaxDevicesReached(count int) error {
 	return NewErrLimit(ErrMaxDeviceCountReached, count, nil)
 }
+
+// NewErrSSHCertificateKeyInvalid returns an error when the public key to be certified is invalid.
+func NewErrSSHCertificateKeyInvalid(key string, next error) error {
+	return NewErrInvalid(ErrSSHCertificateKeyInvalid, map[string]interface{}{"public_key": key}, next)
+}
+
+// NewErrSSHCertificatePrincipals returns an error when the namespace maps no usernames to the member's role.
+func NewErrSSHCertificatePrincipals(next error) error {
+	return NewErrForbidden(ErrSSHCertificatePrincipals, next)
+}
+
+// NewErrDeviceFileNotFound returns an error when the file isn't found on the device.
+func NewErrDeviceFileNotFound(path string, next error) error {
+	return NewErrNotFound(ErrDeviceFileNotFound, path, next)
+}
+
+// NewErrDeviceFileForbidden returns an error when the device's user cannot be authenticated to access its files.
+func NewErrDeviceFileForbidden(next error) error {
+	return NewErrForbidden(ErrDeviceFileForbidden, next)
+}
+
+// NewErrDeviceFileInvalid returns an error when the file on the path cannot be transferred, like a directory.
+func NewErrDeviceFileInvalid(path string, next error) error {
+	return NewErrInvalid(ErrDeviceFileInvalid, map[string]interface{}{"path": path}, next)
+}
+
+// NewErrDeviceFileChallenge returns an error when the challenge signed to transfer files wasn't issued to the device,
+// expired or was already used.
+func NewErrDeviceFileChallenge(next error) error {
+	return NewErrForbidden(ErrDeviceFileChallenge, next)
+}
+
+// NewErrDeviceFileTransfer returns an error when the SSH server fails to transfer the file.
+func NewErrDeviceFileTransfer(path string, next error) error {
+	return NewErrInvalid(ErrDeviceFileTransfer, map[string]interface{}{"path": path}, next)
+}
+
+// NewErrJobNotFound returns an error when the job isn't found on the namespace.
+func NewErrJobNotFound(id string, next error) error {
+	return NewErrNotFound(ErrJobNotFound, id, next)
+}
+
+// NewErrJobInvalid returns an error when the job's devices cannot be selected, like when the selector is empty.
+func NewErrJobInvalid(data map[string]interface{}, next error) error {
+	return NewErrInvalid(ErrJobInvalid, data, next)
+}
+
+// NewErrJobNoDevices returns an error when the job's selector doesn't match any device.
+func NewErrJobNoDevices(next error) error {
+	return NewErrInvalid(ErrJobNoDevices, nil, next)
+}
+
+// NewErrJobFinished returns an error when the job cannot be cancelled because it was already finished.
+func NewErrJobFinished(id string, next error) error {
+	return NewErrInvalid(ErrJobFinished, map[string]interface{}{"id": id}, next)
+}
+
+// NewErrJobEnqueue returns an error when the job cannot be enqueued to the workers.
+func NewErrJobEnqueue(next error) error {
+	return errors.Wrap(ErrJobEnqueue, next)
+}
+
+// NewErrJobSignature returns an error when the job's command isn't signed by the private key of its public key.
+func NewErrJobSignature(next error) error {
+	return NewErrForbidden(ErrJobSignature, next)
+}
+
+// NewErrJobScheduleNotFound returns an error when the job schedule isn't found on the namespace.
+func NewErrJobScheduleNotFound(id string, next error) error {
+	return NewErrNotFound(ErrJobScheduleNotFound, id, next)
+}
+
+// NewErrJobScheduleCron returns an error when the job schedule's cron expression cannot be parsed.
+func NewErrJobScheduleCron(cron string, next error) error {
+	return NewErrInvalid(ErrJobScheduleCron, map[string]interface{}{"cron": cron}, next)
+}
+
+// NewErrJobScheduleCreator returns an error when the job schedule is created without a user, like with an API key, as
+// the job schedule runs on behalf of its creator.
+func NewErrJobScheduleCreator(next error) error {
+	return NewErrForbidden(ErrJobScheduleCreator, next)
+}
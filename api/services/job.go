@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	req "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// DefaultJobConcurrency is the number of devices executing a job's command at the same time when it isn't informed.
+	DefaultJobConcurrency = 10
+	// DefaultJobTimeout is the number of seconds a job's command can run on each device when it isn't informed.
+	DefaultJobTimeout = 300
+	// MaxJobSignatureTTL is the maximum time, from its creation, a job's signature can be used to create the job.
+	MaxJobSignatureTTL = 10 * time.Minute
+)
+
+// JobService manages the jobs, what execute a command asynchronously on the namespace's devices.
+type JobService interface {
+	// CreateJob creates a job to execute the command on the devices selected, at this moment, by the request's
+	// selector, enqueueing it to the workers.
+	CreateJob(ctx context.Context, tenant string, username string, req *requests.JobCreate) (*models.Job, error)
+	// ListJobs lists the namespace's jobs, from the newest to the oldest.
+	ListJobs(ctx context.Context, tenant string, paginator query.Paginator) ([]models.Job, int, error)
+	// GetJob gets the namespace's job with the results of its devices.
+	GetJob(ctx context.Context, tenant string, id string) (*responses.Job, error)
+	// CancelJob cancels the namespace's job, killing the commands being executed and skipping the devices left.
+	CancelJob(ctx context.Context, tenant string, id string) error
+}
+
+// validateJobSelector checks that the selector is informed and that its filter can be parsed.
+func validateJobSelector(selector *models.JobSelector) error {
+	if len(selector.UIDs) == 0 && len(selector.Tags) == 0 && selector.Filter == "" {
+		return NewErrJobInvalid(map[string]interface{}{"selector": "empty"}, nil)
+	}
+
+	filters := query.Filters{Raw: selector.Filter}
+	if err := filters.Unmarshal(); err != nil {
+		return NewErrJobInvalid(map[string]interface{}{"filter": selector.Filter}, err)
+	}
+
+	return nil
+}
+
+// verifyJobSignature checks that the job's signed data, built from the request, is signed by the private key of the
+// namespace's public key, what proves the job's creator holds it, as the key authenticates the user on the devices
+// without any other credential. The signature is accepted once and only before it expires, so it cannot be replayed
+// to create other jobs. It returns the canonical form of the signed data.
+func (s *service) verifyJobSignature(ctx context.Context, tenant string, username string, r *requests.JobCreate, cron string) (string, error) {
+	key, err := s.store.PublicKeyGet(ctx, r.Fingerprint, tenant)
+	if err != nil {
+		return "", NewErrPublicKeyNotFound(r.Fingerprint, err)
+	}
+
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey(key.Data) //nolint:dogsled
+	if err != nil {
+		return "", NewErrPublicKeyInvalid(map[string]interface{}{"fingerprint": r.Fingerprint}, err)
+	}
+
+	expiresAt := time.Unix(r.ExpiresAt, 0)
+
+	now := clock.Now()
+	if !expiresAt.After(now) || expiresAt.After(now.Add(MaxJobSignatureTTL)) {
+		return "", NewErrJobSignature(errors.New("the signature is expired or expires too late"))
+	}
+
+	data := (&models.JobSignedData{
+		TenantID:    tenant,
+		CreatedBy:   username,
+		User:        r.User,
+		Fingerprint: r.Fingerprint,
+		Selector:    r.Selector,
+		Cron:        cron,
+		ExpiresAt:   r.ExpiresAt,
+		Command:     r.Command,
+	}).String()
+
+	digest, err := base64.StdEncoding.DecodeString(r.Signature)
+	if err != nil {
+		return "", NewErrJobSignature(err)
+	}
+
+	if err := pubKey.Verify([]byte(data), &ssh.Signature{Format: pubKey.Type(), Blob: digest}); err != nil {
+		return "", NewErrJobSignature(err)
+	}
+
+	// NOTICE: The uses are counted by the signed data, instead of by the signature, as some signatures can be
+	// changed without invalidating them.
+	sum := sha256.Sum256([]byte(data))
+
+	uses, err := s.cache.Increment(ctx, strings.Join([]string{"job_signature", hex.EncodeToString(sum[:])}, "/"), expiresAt.Sub(now))
+	if err != nil {
+		return "", err
+	}
+
+	if uses > 1 {
+		return "", NewErrJobSignature(errors.New("the signature was already used"))
+	}
+
+	return data, nil
+}
+
+func (s *service) CreateJob(ctx context.Context, tenant string, username string, r *requests.JobCreate) (*models.Job, error) {
+	if err := validateJobSelector(&r.Selector); err != nil {
+		return nil, err
+	}
+
+	data, err := s.verifyJobSignature(ctx, tenant, username, r, "")
+	if err != nil {
+		return nil, err
+	}
+
+	devices, err := s.store.DeviceListBySelector(ctx, tenant, &r.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(devices) == 0 {
+		return nil, NewErrJobNoDevices(nil)
+	}
+
+	job := &models.Job{
+		ID:          uuid.Generate(),
+		TenantID:    tenant,
+		Command:     r.Command,
+		Selector:    r.Selector,
+		User:        r.User,
+		Fingerprint: r.Fingerprint,
+		SignedData:  data,
+		Signature:   r.Signature,
+		Concurrency: r.Concurrency,
+		Timeout:     r.Timeout,
+		Devices:     make([]string, len(devices)),
+		Status:      models.JobStatusPending,
+		CreatedBy:   username,
+		CreatedAt:   clock.Now(),
+	}
+
+	if job.Concurrency == 0 {
+		job.Concurrency = DefaultJobConcurrency
+	}
+
+	if job.Timeout == 0 {
+		job.Timeout = DefaultJobTimeout
+	}
+
+	results := make([]models.JobResult, len(devices))
+	for i, device := range devices {
+		job.Devices[i] = device.UID
+		results[i] = models.JobResult{
+			JobID:  job.ID,
+			UID:    device.UID,
+			Name:   device.Name,
+			Status: models.JobResultStatusPending,
+		}
+	}
+
+	if err := s.store.JobCreate(ctx, job, results); err != nil {
+		return nil, err
+	}
+
+	if err := s.client.(req.Client).JobEnqueue(job.ID, job.Deadline()); err != nil {
+		// NOTICE: The job is cancelled, so it isn't listed as pending forever.
+		s.store.JobUpdateStatus(ctx, job.ID, models.JobStatusCancelled) //nolint:errcheck
+
+		return nil, NewErrJobEnqueue(err)
+	}
+
+	return job, nil
+}
+
+func (s *service) ListJobs(ctx context.Context, tenant string, paginator query.Paginator) ([]models.Job, int, error) {
+	return s.store.JobList(ctx, tenant, "", paginator)
+}
+
+// job gets the job, checking it belongs to the namespace.
+func (s *service) job(ctx context.Context, tenant string, id string) (*models.Job, error) {
+	job, err := s.store.JobGet(ctx, id)
+	if err != nil {
+		return nil, NewErrJobNotFound(id, err)
+	}
+
+	if job.TenantID != tenant {
+		return nil, NewErrJobNotFound(id, nil)
+	}
+
+	return job, nil
+}
+
+func (s *service) GetJob(ctx context.Context, tenant string, id string) (*responses.Job, error) {
+	job, err := s.job(ctx, tenant, id)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.store.JobResultList(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &responses.Job{Job: *job, Results: results}, nil
+}
+
+func (s *service) CancelJob(ctx context.Context, tenant string, id string) error {
+	if _, err := s.job(ctx, tenant, id); err != nil {
+		return err
+	}
+
+	// NOTICE: The workers watch the job's status, stopping its execution when it's cancelled.
+	if err := s.store.JobUpdateStatus(ctx, id, models.JobStatusCancelled, models.JobStatusPending, models.JobStatusRunning); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return NewErrJobFinished(id, err)
+		}
+
+		return err
+	}
+
+	return nil
+}
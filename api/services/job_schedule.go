@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+// JobScheduleService manages the job schedules, what execute a command periodically on the namespace's devices. The
+// workers pick the changes on the job schedules up within a minute.
+type JobScheduleService interface {
+	// CreateJobSchedule creates an enabled job schedule, what selects the devices at each scheduled time. It must be
+	// created by a user, as each run is authorized on behalf of its creator.
+	CreateJobSchedule(ctx context.Context, tenant string, username string, req *requests.JobScheduleCreate) (*models.JobSchedule, error)
+	// ListJobSchedules lists the namespace's job schedules, from the newest to the oldest.
+	ListJobSchedules(ctx context.Context, tenant string, paginator query.Paginator) ([]models.JobSchedule, int, error)
+	// GetJobSchedule gets the namespace's job schedule.
+	GetJobSchedule(ctx context.Context, tenant string, id string) (*models.JobSchedule, error)
+	// UpdateJobSchedule updates the namespace's job schedule, like enabling or disabling it.
+	UpdateJobSchedule(ctx context.Context, tenant string, req *requests.JobScheduleUpdate) (*models.JobSchedule, error)
+	// DeleteJobSchedule deletes the namespace's job schedule, keeping its runs.
+	DeleteJobSchedule(ctx context.Context, tenant string, id string) error
+	// ListJobScheduleRuns lists the jobs executed by the namespace's job schedule, from the newest to the oldest.
+	ListJobScheduleRuns(ctx context.Context, tenant string, id string, paginator query.Paginator) ([]models.Job, int, error)
+}
+
+// validateJobScheduleCron checks that the cron expression can be parsed by the workers' scheduler and that it isn't
+// more frequent than a minute, as the workers run a job schedule at most once per minute.
+func validateJobScheduleCron(expression string) error {
+	schedule, err := cron.ParseStandard(expression)
+	if err != nil {
+		return NewErrJobScheduleCron(expression, err)
+	}
+
+	if every, ok := schedule.(cron.ConstantDelaySchedule); ok && every.Delay < time.Minute {
+		return NewErrJobScheduleCron(expression, errors.New("the interval must be at least a minute"))
+	}
+
+	return nil
+}
+
+func (s *service) CreateJobSchedule(ctx context.Context, tenant string, username string, r *requests.JobScheduleCreate) (*models.JobSchedule, error) {
+	if username == "" {
+		return nil, NewErrJobScheduleCreator(nil)
+	}
+
+	if err := validateJobScheduleCron(r.Cron); err != nil {
+		return nil, err
+	}
+
+	if err := validateJobSelector(&r.Selector); err != nil {
+		return nil, err
+	}
+
+	data, err := s.verifyJobSignature(ctx, tenant, username, &r.JobCreate, r.Cron)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := &models.JobSchedule{
+		ID:             uuid.Generate(),
+		TenantID:       tenant,
+		Name:           r.Name,
+		Cron:           r.Cron,
+		Command:        r.Command,
+		Selector:       r.Selector,
+		User:           r.User,
+		Fingerprint:    r.Fingerprint,
+		SignedData:     data,
+		Signature:      r.Signature,
+		Concurrency:    r.Concurrency,
+		Timeout:        r.Timeout,
+		Enabled:        true,
+		RunOnReconnect: r.RunOnReconnect,
+		Missed:         []string{},
+		CreatedBy:      username,
+		CreatedAt:      clock.Now(),
+	}
+
+	if schedule.Concurrency == 0 {
+		schedule.Concurrency = DefaultJobConcurrency
+	}
+
+	if schedule.Timeout == 0 {
+		schedule.Timeout = DefaultJobTimeout
+	}
+
+	if err := s.store.JobScheduleCreate(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+func (s *service) ListJobSchedules(ctx context.Context, tenant string, paginator query.Paginator) ([]models.JobSchedule, int, error) {
+	return s.store.JobScheduleList(ctx, tenant, paginator)
+}
+
+func (s *service) GetJobSchedule(ctx context.Context, tenant string, id string) (*models.JobSchedule, error) {
+	schedule, err := s.store.JobScheduleGet(ctx, id)
+	if err != nil {
+		return nil, NewErrJobScheduleNotFound(id, err)
+	}
+
+	if schedule.TenantID != tenant {
+		return nil, NewErrJobScheduleNotFound(id, nil)
+	}
+
+	return schedule, nil
+}
+
+func (s *service) UpdateJobSchedule(ctx context.Context, tenant string, r *requests.JobScheduleUpdate) (*models.JobSchedule, error) {
+	if _, err := s.GetJobSchedule(ctx, tenant, r.ID); err != nil {
+		return nil, err
+	}
+
+	if r.Cron != nil {
+		if err := validateJobScheduleCron(*r.Cron); err != nil {
+			return nil, err
+		}
+	}
+
+	changes := &models.JobScheduleChanges{
+		Name:           r.Name,
+		Cron:           r.Cron,
+		Enabled:        r.Enabled,
+		RunOnReconnect: r.RunOnReconnect,
+	}
+
+	if err := s.store.JobScheduleUpdate(ctx, r.ID, changes); err != nil {
+		return nil, NewErrJobScheduleNotFound(r.ID, err)
+	}
+
+	return s.store.JobScheduleGet(ctx, r.ID)
+}
+
+func (s *service) DeleteJobSchedule(ctx context.Context, tenant string, id string) error {
+	if _, err := s.GetJobSchedule(ctx, tenant, id); err != nil {
+		return err
+	}
+
+	if err := s.store.JobScheduleDelete(ctx, id); err != nil {
+		return NewErrJobScheduleNotFound(id, err)
+	}
+
+	return nil
+}
+
+func (s *service) ListJobScheduleRuns(ctx context.Context, tenant string, id string, paginator query.Paginator) ([]models.Job, int, error) {
+	if _, err := s.GetJobSchedule(ctx, tenant, id); err != nil {
+		return nil, 0, err
+	}
+
+	return s.store.JobList(ctx, tenant, id, paginator)
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	goerrors "errors"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestCreateJobSchedule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromKey(private)
+	require.NoError(t, err)
+
+	expiresAt := now.Add(5 * time.Minute).Unix()
+
+	data := &models.JobSignedData{
+		TenantID:    "tenant",
+		CreatedBy:   "john",
+		User:        "root",
+		Fingerprint: "fingerprint",
+		Selector:    models.JobSelector{Tags: []string{"edge"}},
+		Cron:        "0 3 * * *",
+		ExpiresAt:   expiresAt,
+		Command:     "rm -rf /tmp/cache",
+	}
+
+	signature, err := signer.Sign(rand.Reader, []byte(data.String()))
+	require.NoError(t, err)
+
+	key := &models.PublicKey{Data: ssh.MarshalAuthorizedKey(signer.PublicKey()), Fingerprint: "fingerprint", TenantID: "tenant"}
+
+	job := requests.JobCreate{
+		Command:     "rm -rf /tmp/cache",
+		Selector:    models.JobSelector{Tags: []string{"edge"}},
+		User:        "root",
+		Fingerprint: "fingerprint",
+		Signature:   base64.StdEncoding.EncodeToString(signature.Blob),
+		ExpiresAt:   expiresAt,
+	}
+
+	type Expected struct {
+		schedule *models.JobSchedule
+		err      error
+	}
+
+	cases := []struct {
+		name          string
+		apiKey        bool
+		req           *requests.JobScheduleCreate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name:          "fails when it's created with an API key",
+			apiKey:        true,
+			req:           &requests.JobScheduleCreate{Name: "cleanup", Cron: "0 3 * * *", JobCreate: job},
+			requiredMocks: func() {},
+			expected:      Expected{err: NewErrJobScheduleCreator(nil)},
+		},
+		{
+			name:          "fails when the cron expression is invalid",
+			req:           &requests.JobScheduleCreate{Name: "cleanup", Cron: "every night", JobCreate: job},
+			requiredMocks: func() {},
+			expected: Expected{err: NewErrJobScheduleCron(
+				"every night",
+				goerrors.New("expected exactly 5 fields, found 2: [every night]"),
+			)},
+		},
+		{
+			name:          "fails when the cron expression is more frequent than a minute",
+			req:           &requests.JobScheduleCreate{Name: "cleanup", Cron: "@every 30s", JobCreate: job},
+			requiredMocks: func() {},
+			expected: Expected{err: NewErrJobScheduleCron(
+				"@every 30s",
+				goerrors.New("the interval must be at least a minute"),
+			)},
+		},
+		{
+			name: "fails when the selector is empty",
+			req: &requests.JobScheduleCreate{Name: "cleanup", Cron: "0 3 * * *", JobCreate: requests.JobCreate{
+				Command:     "rm -rf /tmp/cache",
+				User:        "root",
+				Fingerprint: "fingerprint",
+			}},
+			requiredMocks: func() {},
+			expected:      Expected{err: NewErrJobInvalid(map[string]interface{}{"selector": "empty"}, nil)},
+		},
+		{
+			name: "fails when the command isn't signed by the public key",
+			req: &requests.JobScheduleCreate{Name: "cleanup", Cron: "0 3 * * *", JobCreate: requests.JobCreate{
+				Command:     "reboot",
+				Selector:    job.Selector,
+				User:        "root",
+				Fingerprint: "fingerprint",
+				Signature:   job.Signature,
+				ExpiresAt:   expiresAt,
+			}},
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{err: NewErrJobSignature(goerrors.New("ssh: signature did not verify"))},
+		},
+		{
+			name: "fails when the cron expression isn't the signed one",
+			req:  &requests.JobScheduleCreate{Name: "cleanup", Cron: "* * * * *", JobCreate: job},
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{err: NewErrJobSignature(goerrors.New("ssh: signature did not verify"))},
+		},
+		{
+			name: "succeeds",
+			req:  &requests.JobScheduleCreate{Name: "cleanup", Cron: "0 3 * * *", RunOnReconnect: true, JobCreate: job},
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				uuidMock.On("Generate").Return("schedule").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("JobScheduleCreate", ctx, &models.JobSchedule{
+					ID:             "schedule",
+					TenantID:       "tenant",
+					Name:           "cleanup",
+					Cron:           "0 3 * * *",
+					Command:        "rm -rf /tmp/cache",
+					Selector:       models.JobSelector{Tags: []string{"edge"}},
+					User:           "root",
+					Fingerprint:    "fingerprint",
+					SignedData:     data.String(),
+					Signature:      job.Signature,
+					Concurrency:    DefaultJobConcurrency,
+					Timeout:        DefaultJobTimeout,
+					Enabled:        true,
+					RunOnReconnect: true,
+					Missed:         []string{},
+					CreatedBy:      "john",
+					CreatedAt:      now,
+				}).Return(nil).Once()
+			},
+			expected: Expected{schedule: &models.JobSchedule{
+				ID:             "schedule",
+				TenantID:       "tenant",
+				Name:           "cleanup",
+				Cron:           "0 3 * * *",
+				Command:        "rm -rf /tmp/cache",
+				Selector:       models.JobSelector{Tags: []string{"edge"}},
+				User:           "root",
+				Fingerprint:    "fingerprint",
+				SignedData:     data.String(),
+				Signature:      job.Signature,
+				Concurrency:    DefaultJobConcurrency,
+				Timeout:        DefaultJobTimeout,
+				Enabled:        true,
+				RunOnReconnect: true,
+				Missed:         []string{},
+				CreatedBy:      "john",
+				CreatedAt:      now,
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, new(memoryCache), clientMock, nil)
+			username := "john"
+			if tc.apiKey {
+				username = ""
+			}
+
+			schedule, err := service.CreateJobSchedule(ctx, "tenant", username, tc.req)
+			assert.Equal(t, tc.expected, Expected{schedule, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateJobSchedule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	disabled := false
+	invalid := "@sometimes"
+
+	type Expected struct {
+		schedule *models.JobSchedule
+		err      error
+	}
+
+	cases := []struct {
+		name          string
+		req           *requests.JobScheduleUpdate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name: "fails when the job schedule belongs to another namespace",
+			req:  &requests.JobScheduleUpdate{JobParam: requests.JobParam{ID: "schedule"}, Enabled: &disabled},
+			requiredMocks: func() {
+				mock.On("JobScheduleGet", ctx, "schedule").
+					Return(&models.JobSchedule{ID: "schedule", TenantID: "other"}, nil).Once()
+			},
+			expected: Expected{err: NewErrJobScheduleNotFound("schedule", nil)},
+		},
+		{
+			name: "fails when the cron expression is invalid",
+			req:  &requests.JobScheduleUpdate{JobParam: requests.JobParam{ID: "schedule"}, Cron: &invalid},
+			requiredMocks: func() {
+				mock.On("JobScheduleGet", ctx, "schedule").
+					Return(&models.JobSchedule{ID: "schedule", TenantID: "tenant"}, nil).Once()
+			},
+			expected: Expected{err: NewErrJobScheduleCron(
+				"@sometimes",
+				goerrors.New("unrecognized descriptor: @sometimes"),
+			)},
+		},
+		{
+			name: "succeeds to disable the job schedule",
+			req:  &requests.JobScheduleUpdate{JobParam: requests.JobParam{ID: "schedule"}, Enabled: &disabled},
+			requiredMocks: func() {
+				mock.On("JobScheduleGet", ctx, "schedule").
+					Return(&models.JobSchedule{ID: "schedule", TenantID: "tenant", Enabled: true}, nil).Once()
+				mock.On("JobScheduleUpdate", ctx, "schedule", &models.JobScheduleChanges{Enabled: &disabled}).
+					Return(nil).Once()
+				mock.On("JobScheduleGet", ctx, "schedule").
+					Return(&models.JobSchedule{ID: "schedule", TenantID: "tenant", Enabled: false}, nil).Once()
+			},
+			expected: Expected{schedule: &models.JobSchedule{ID: "schedule", TenantID: "tenant", Enabled: false}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			schedule, err := service.UpdateJobSchedule(ctx, "tenant", tc.req)
+			assert.Equal(t, tc.expected, Expected{schedule, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListJobScheduleRuns(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	paginator := query.Paginator{Page: 1, PerPage: 10}
+
+	mock.On("JobScheduleGet", ctx, "schedule").
+		Return(&models.JobSchedule{ID: "schedule", TenantID: "tenant"}, nil).Once()
+	mock.On("JobList", ctx, "tenant", "schedule", paginator).
+		Return([]models.Job{{ID: "job", ScheduleID: "schedule"}}, 1, nil).Once()
+
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+	jobs, count, err := service.ListJobScheduleRuns(ctx, "tenant", "schedule", paginator)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, []models.Job{{ID: "job", ScheduleID: "schedule"}}, jobs)
+
+	mock.AssertExpectations(t)
+}
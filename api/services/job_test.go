@@ -0,0 +1,349 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	goerrors "errors"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/api/responses"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestCreateJob(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromKey(private)
+	require.NoError(t, err)
+
+	expiresAt := now.Add(5 * time.Minute).Unix()
+
+	data := &models.JobSignedData{
+		TenantID:    "tenant",
+		CreatedBy:   "john",
+		User:        "root",
+		Fingerprint: "fingerprint",
+		Selector:    models.JobSelector{Tags: []string{"production"}},
+		ExpiresAt:   expiresAt,
+		Command:     "uptime",
+	}
+
+	signature, err := signer.Sign(rand.Reader, []byte(data.String()))
+	require.NoError(t, err)
+
+	key := &models.PublicKey{Data: ssh.MarshalAuthorizedKey(signer.PublicKey()), Fingerprint: "fingerprint", TenantID: "tenant"}
+
+	req := &requests.JobCreate{
+		Command:     "uptime",
+		Selector:    models.JobSelector{Tags: []string{"production"}},
+		User:        "root",
+		Fingerprint: "fingerprint",
+		Signature:   base64.StdEncoding.EncodeToString(signature.Blob),
+		ExpiresAt:   expiresAt,
+	}
+
+	job := &models.Job{
+		ID:          "job",
+		TenantID:    "tenant",
+		Command:     "uptime",
+		Selector:    models.JobSelector{Tags: []string{"production"}},
+		User:        "root",
+		Fingerprint: "fingerprint",
+		SignedData:  data.String(),
+		Signature:   req.Signature,
+		Concurrency: DefaultJobConcurrency,
+		Timeout:     DefaultJobTimeout,
+		Devices:     []string{"device"},
+		Status:      models.JobStatusPending,
+		CreatedBy:   "john",
+		CreatedAt:   now,
+	}
+
+	results := []models.JobResult{
+		{JobID: "job", UID: "device", Name: "device-1", Status: models.JobResultStatusPending},
+	}
+
+	type Expected struct {
+		job *models.Job
+		err error
+	}
+
+	cases := []struct {
+		name          string
+		req           *requests.JobCreate
+		used          bool
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name:          "fails when the selector is empty",
+			req:           &requests.JobCreate{Command: "uptime", User: "root", Fingerprint: "fingerprint"},
+			requiredMocks: func() {},
+			expected:      Expected{err: NewErrJobInvalid(map[string]interface{}{"selector": "empty"}, nil)},
+		},
+		{
+			name: "fails when the public key isn't found",
+			req:  req,
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{err: NewErrPublicKeyNotFound("fingerprint", store.ErrNoDocuments)},
+		},
+		{
+			name: "fails when the signature is expired",
+			req: &requests.JobCreate{
+				Command:     "uptime",
+				Selector:    req.Selector,
+				User:        "root",
+				Fingerprint: "fingerprint",
+				Signature:   req.Signature,
+				ExpiresAt:   now.Add(-time.Minute).Unix(),
+			},
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{err: NewErrJobSignature(goerrors.New("the signature is expired or expires too late"))},
+		},
+		{
+			name: "fails when the signature expires too late",
+			req: &requests.JobCreate{
+				Command:     "uptime",
+				Selector:    req.Selector,
+				User:        "root",
+				Fingerprint: "fingerprint",
+				Signature:   req.Signature,
+				ExpiresAt:   now.Add(MaxJobSignatureTTL + time.Minute).Unix(),
+			},
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{err: NewErrJobSignature(goerrors.New("the signature is expired or expires too late"))},
+		},
+		{
+			name: "fails when the command isn't signed by the public key",
+			req: &requests.JobCreate{
+				Command:     "reboot",
+				Selector:    req.Selector,
+				User:        "root",
+				Fingerprint: "fingerprint",
+				Signature:   req.Signature,
+				ExpiresAt:   expiresAt,
+			},
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{err: NewErrJobSignature(goerrors.New("ssh: signature did not verify"))},
+		},
+		{
+			name: "fails when the selector isn't the signed one",
+			req: &requests.JobCreate{
+				Command:     "uptime",
+				Selector:    models.JobSelector{Tags: []string{"staging"}},
+				User:        "root",
+				Fingerprint: "fingerprint",
+				Signature:   req.Signature,
+				ExpiresAt:   expiresAt,
+			},
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{err: NewErrJobSignature(goerrors.New("ssh: signature did not verify"))},
+		},
+		{
+			name: "fails when the signature was already used",
+			req:  req,
+			used: true,
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{err: NewErrJobSignature(goerrors.New("the signature was already used"))},
+		},
+		{
+			name: "fails when no device is selected",
+			req:  req,
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceListBySelector", ctx, "tenant", &req.Selector).
+					Return([]models.Device{}, nil).Once()
+			},
+			expected: Expected{err: NewErrJobNoDevices(nil)},
+		},
+		{
+			name: "fails when the job cannot be enqueued",
+			req:  req,
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceListBySelector", ctx, "tenant", &req.Selector).
+					Return([]models.Device{{UID: "device", Name: "device-1"}}, nil).Once()
+				uuidMock.On("Generate").Return("job").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("JobCreate", ctx, job, results).Return(nil).Once()
+				clientMock.On("JobEnqueue", "job", 360*time.Second).Return(goerrors.New("error")).Once()
+				mock.On("JobUpdateStatus", ctx, "job", models.JobStatusCancelled).Return(nil).Once()
+			},
+			expected: Expected{err: NewErrJobEnqueue(goerrors.New("error"))},
+		},
+		{
+			name: "succeeds",
+			req:  req,
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(key, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceListBySelector", ctx, "tenant", &req.Selector).
+					Return([]models.Device{{UID: "device", Name: "device-1"}}, nil).Once()
+				uuidMock.On("Generate").Return("job").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("JobCreate", ctx, job, results).Return(nil).Once()
+				clientMock.On("JobEnqueue", "job", 360*time.Second).Return(nil).Once()
+			},
+			expected: Expected{job: job},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			cache := new(memoryCache)
+			if tc.used {
+				sum := sha256.Sum256([]byte(data.String()))
+				_, err := cache.Increment(ctx, "job_signature/"+hex.EncodeToString(sum[:]), time.Minute)
+				require.NoError(t, err)
+			}
+
+			service := NewService(store.Store(mock), privateKey, publicKey, cache, clientMock, nil)
+			job, err := service.CreateJob(ctx, "tenant", "john", tc.req)
+			assert.Equal(t, tc.expected, Expected{job, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetJob(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		job *responses.Job
+		err error
+	}
+
+	cases := []struct {
+		name          string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			name: "fails when the job isn't found",
+			requiredMocks: func() {
+				mock.On("JobGet", ctx, "job").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{err: NewErrJobNotFound("job", store.ErrNoDocuments)},
+		},
+		{
+			name: "fails when the job belongs to another namespace",
+			requiredMocks: func() {
+				mock.On("JobGet", ctx, "job").Return(&models.Job{ID: "job", TenantID: "other"}, nil).Once()
+			},
+			expected: Expected{err: NewErrJobNotFound("job", nil)},
+		},
+		{
+			name: "succeeds",
+			requiredMocks: func() {
+				mock.On("JobGet", ctx, "job").Return(&models.Job{ID: "job", TenantID: "tenant"}, nil).Once()
+				mock.On("JobResultList", ctx, "job").
+					Return([]models.JobResult{{JobID: "job", UID: "device"}}, nil).Once()
+			},
+			expected: Expected{job: &responses.Job{
+				Job:     models.Job{ID: "job", TenantID: "tenant"},
+				Results: []models.JobResult{{JobID: "job", UID: "device"}},
+			}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			job, err := service.GetJob(ctx, "tenant", "job")
+			assert.Equal(t, tc.expected, Expected{job, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCancelJob(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		name          string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "fails when the job is finished",
+			requiredMocks: func() {
+				mock.On("JobGet", ctx, "job").Return(&models.Job{ID: "job", TenantID: "tenant"}, nil).Once()
+				mock.On("JobUpdateStatus", ctx, "job", models.JobStatusCancelled, models.JobStatusPending, models.JobStatusRunning).
+					Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrJobFinished("job", store.ErrNoDocuments),
+		},
+		{
+			name: "succeeds",
+			requiredMocks: func() {
+				mock.On("JobGet", ctx, "job").Return(&models.Job{ID: "job", TenantID: "tenant"}, nil).Once()
+				mock.On("JobUpdateStatus", ctx, "job", models.JobStatusCancelled, models.JobStatusPending, models.JobStatusRunning).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.CancelJob(ctx, "tenant", "job"))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
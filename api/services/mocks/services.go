@@ -4,8 +4,10 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 
 	internalclient "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/shellhub-io/shellhub/pkg/models"
@@ -74,6 +76,24 @@ func (_m *Service) AddPublicKeyTag(ctx context.Context, tenant string, fingerpri
 	return r0
 }
 
+// AddSessionEvent provides a mock function with given fields: ctx, uid, req
+func (_m *Service) AddSessionEvent(ctx context.Context, uid models.UID, req *requests.SessionEvent) error {
+	ret := _m.Called(ctx, uid, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddSessionEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *requests.SessionEvent) error); ok {
+		r0 = rf(ctx, uid, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AuthCacheToken provides a mock function with given fields: ctx, tenant, id, token
 func (_m *Service) AuthCacheToken(ctx context.Context, tenant string, id string, token string) error {
 	ret := _m.Called(ctx, tenant, id, token)
@@ -208,6 +228,24 @@ func (_m *Service) AuthMFA(ctx context.Context, id string) (bool, error) {
 	return r0, r1
 }
 
+// AuthMFACode provides a mock function with given fields: ctx, id, code
+func (_m *Service) AuthMFACode(ctx context.Context, id string, code string) error {
+	ret := _m.Called(ctx, id, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthMFACode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AuthPublicKey provides a mock function with given fields: ctx, req
 func (_m *Service) AuthPublicKey(ctx context.Context, req requests.PublicKeyAuth) (*models.PublicKeyAuthResponse, error) {
 	ret := _m.Called(ctx, req)
@@ -286,9 +324,9 @@ func (_m *Service) AuthUncacheToken(ctx context.Context, tenant string, id strin
 	return r0
 }
 
-// AuthUser provides a mock function with given fields: ctx, model
-func (_m *Service) AuthUser(ctx context.Context, model *requests.UserAuth) (*models.UserAuthResponse, error) {
-	ret := _m.Called(ctx, model)
+// AuthUser provides a mock function with given fields: ctx, req
+func (_m *Service) AuthUser(ctx context.Context, req *requests.UserAuth) (*models.UserAuthResponse, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AuthUser")
@@ -297,10 +335,10 @@ func (_m *Service) AuthUser(ctx context.Context, model *requests.UserAuth) (*mod
 	var r0 *models.UserAuthResponse
 	var r1 error
 	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserAuth) (*models.UserAuthResponse, error)); ok {
-		return rf(ctx, model)
+		return rf(ctx, req)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, *requests.UserAuth) *models.UserAuthResponse); ok {
-		r0 = rf(ctx, model)
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.UserAuthResponse)
@@ -308,7 +346,7 @@ func (_m *Service) AuthUser(ctx context.Context, model *requests.UserAuth) (*mod
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, *requests.UserAuth) error); ok {
-		r1 = rf(ctx, model)
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -392,6 +430,42 @@ func (_m *Service) BillingReport(_a0 internalclient.Client, _a1 string, _a2 stri
 	return r0
 }
 
+// CancelJob provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) CancelJob(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CloseSession provides a mock function with given fields: ctx, uid
+func (_m *Service) CloseSession(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateAPIKey provides a mock function with given fields: ctx, userID, tenant, key, req
 func (_m *Service) CreateAPIKey(ctx context.Context, userID string, tenant string, key string, req *requests.CreateAPIKey) (string, error) {
 	ret := _m.Called(ctx, userID, tenant, key, req)
@@ -420,6 +494,36 @@ func (_m *Service) CreateAPIKey(ctx context.Context, userID string, tenant strin
 	return r0, r1
 }
 
+// CreateDeviceFileChallenge provides a mock function with given fields: ctx, tenant, uid
+func (_m *Service) CreateDeviceFileChallenge(ctx context.Context, tenant string, uid models.UID) (*models.FileChallenge, error) {
+	ret := _m.Called(ctx, tenant, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDeviceFileChallenge")
+	}
+
+	var r0 *models.FileChallenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) (*models.FileChallenge, error)); ok {
+		return rf(ctx, tenant, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID) *models.FileChallenge); ok {
+		r0 = rf(ctx, tenant, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FileChallenge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.UID) error); ok {
+		r1 = rf(ctx, tenant, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateDeviceTag provides a mock function with given fields: ctx, uid, tag
 func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, tag string) error {
 	ret := _m.Called(ctx, uid, tag)
@@ -438,6 +542,66 @@ func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, tag stri
 	return r0
 }
 
+// CreateJob provides a mock function with given fields: ctx, tenant, username, req
+func (_m *Service) CreateJob(ctx context.Context, tenant string, username string, req *requests.JobCreate) (*models.Job, error) {
+	ret := _m.Called(ctx, tenant, username, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateJob")
+	}
+
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *requests.JobCreate) (*models.Job, error)); ok {
+		return rf(ctx, tenant, username, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *requests.JobCreate) *models.Job); ok {
+		r0 = rf(ctx, tenant, username, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *requests.JobCreate) error); ok {
+		r1 = rf(ctx, tenant, username, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateJobSchedule provides a mock function with given fields: ctx, tenant, username, req
+func (_m *Service) CreateJobSchedule(ctx context.Context, tenant string, username string, req *requests.JobScheduleCreate) (*models.JobSchedule, error) {
+	ret := _m.Called(ctx, tenant, username, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateJobSchedule")
+	}
+
+	var r0 *models.JobSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *requests.JobScheduleCreate) (*models.JobSchedule, error)); ok {
+		return rf(ctx, tenant, username, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *requests.JobScheduleCreate) *models.JobSchedule); ok {
+		r0 = rf(ctx, tenant, username, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *requests.JobScheduleCreate) error); ok {
+		r1 = rf(ctx, tenant, username, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateNamespace provides a mock function with given fields: ctx, namespace, userID
 func (_m *Service) CreateNamespace(ctx context.Context, namespace requests.NamespaceCreate, userID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, namespace, userID)
@@ -528,6 +692,36 @@ func (_m *Service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCr
 	return r0, r1
 }
 
+// CreateSSHCertificate provides a mock function with given fields: ctx, req, userID
+func (_m *Service) CreateSSHCertificate(ctx context.Context, req *requests.SSHCertificateCreate, userID string) (*responses.SSHCertificateCreate, error) {
+	ret := _m.Called(ctx, req, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSSHCertificate")
+	}
+
+	var r0 *responses.SSHCertificateCreate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SSHCertificateCreate, string) (*responses.SSHCertificateCreate, error)); ok {
+		return rf(ctx, req, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.SSHCertificateCreate, string) *responses.SSHCertificateCreate); ok {
+		r0 = rf(ctx, req, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*responses.SSHCertificateCreate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.SSHCertificateCreate, string) error); ok {
+		r1 = rf(ctx, req, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateSession provides a mock function with given fields: ctx, session
 func (_m *Service) CreateSession(ctx context.Context, session requests.SessionCreate) (*models.Session, error) {
 	ret := _m.Called(ctx, session)
@@ -612,6 +806,24 @@ func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant stri
 	return r0
 }
 
+// DeleteJobSchedule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteJobSchedule(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteJobSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteNamespace provides a mock function with given fields: ctx, tenantID
 func (_m *Service) DeleteNamespace(ctx context.Context, tenantID string) error {
 	ret := _m.Called(ctx, tenantID)
@@ -684,6 +896,36 @@ func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
 	return r0
 }
 
+// DownloadDeviceFile provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) DownloadDeviceFile(ctx context.Context, tenant string, req *requests.DeviceFile) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DownloadDeviceFile")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *requests.DeviceFile) (io.ReadCloser, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *requests.DeviceFile) io.ReadCloser); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *requests.DeviceFile) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // EditAPIKey provides a mock function with given fields: ctx, changes
 func (_m *Service) EditAPIKey(ctx context.Context, changes *requests.APIKeyChanges) (*models.APIKey, error) {
 	ret := _m.Called(ctx, changes)
@@ -926,6 +1168,66 @@ func (_m *Service) GetDeviceByPublicURLAddress(ctx context.Context, address stri
 	return r0, r1
 }
 
+// GetJob provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetJob(ctx context.Context, tenant string, id string) (*responses.Job, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJob")
+	}
+
+	var r0 *responses.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*responses.Job, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *responses.Job); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*responses.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobSchedule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetJobSchedule(ctx context.Context, tenant string, id string) (*models.JobSchedule, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobSchedule")
+	}
+
+	var r0 *models.JobSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.JobSchedule, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.JobSchedule); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNamespace provides a mock function with given fields: ctx, tenantID
 func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, tenantID)
@@ -1166,6 +1468,36 @@ func (_m *Service) ListAPIKeys(ctx context.Context, req *requests.APIKeyList) ([
 	return r0, r1, r2
 }
 
+// ListDeviceFiles provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) ListDeviceFiles(ctx context.Context, tenant string, req *requests.DeviceFile) ([]models.File, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceFiles")
+	}
+
+	var r0 []models.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *requests.DeviceFile) ([]models.File, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *requests.DeviceFile) []models.File); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *requests.DeviceFile) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListDevices provides a mock function with given fields: ctx, tenant, status, paginator, filter, sorter
 func (_m *Service) ListDevices(ctx context.Context, tenant string, status models.DeviceStatus, paginator query.Paginator, filter query.Filters, sorter query.Sorter) ([]models.Device, int, error) {
 	ret := _m.Called(ctx, tenant, status, paginator, filter, sorter)
@@ -1203,6 +1535,117 @@ func (_m *Service) ListDevices(ctx context.Context, tenant string, status models
 	return r0, r1, r2
 }
 
+// ListJobScheduleRuns provides a mock function with given fields: ctx, tenant, id, paginator
+func (_m *Service) ListJobScheduleRuns(ctx context.Context, tenant string, id string, paginator query.Paginator) ([]models.Job, int, error) {
+	ret := _m.Called(ctx, tenant, id, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobScheduleRuns")
+	}
+
+	var r0 []models.Job
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, query.Paginator) ([]models.Job, int, error)); ok {
+		return rf(ctx, tenant, id, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, query.Paginator) []models.Job); ok {
+		r0 = rf(ctx, tenant, id, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, id, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, id, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListJobSchedules provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Service) ListJobSchedules(ctx context.Context, tenant string, paginator query.Paginator) ([]models.JobSchedule, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobSchedules")
+	}
+
+	var r0 []models.JobSchedule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.JobSchedule, int, error)); ok {
+		return rf(ctx, tenant, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.JobSchedule); ok {
+		r0 = rf(ctx, tenant, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListJobs provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Service) ListJobs(ctx context.Context, tenant string, paginator query.Paginator) ([]models.Job, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListJobs")
+	}
+
+	var r0 []models.Job
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.Job, int, error)); ok {
+		return rf(ctx, tenant, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.Job); ok {
+		r0 = rf(ctx, tenant, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ListNamespaces provides a mock function with given fields: ctx, paginator, filters, export
 func (_m *Service) ListNamespaces(ctx context.Context, paginator query.Paginator, filters query.Filters, export bool) ([]models.Namespace, int, error) {
 	ret := _m.Called(ctx, paginator, filters, export)
@@ -1277,9 +1720,9 @@ func (_m *Service) ListPublicKeys(ctx context.Context, paginator query.Paginator
 	return r0, r1, r2
 }
 
-// ListSessions provides a mock function with given fields: ctx, paginator
-func (_m *Service) ListSessions(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
-	ret := _m.Called(ctx, paginator)
+// ListSessions provides a mock function with given fields: ctx, paginator, filters, sorter
+func (_m *Service) ListSessions(ctx context.Context, paginator query.Paginator, filters query.Filters, sorter query.Sorter) ([]models.Session, int, error) {
+	ret := _m.Called(ctx, paginator, filters, sorter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ListSessions")
@@ -1288,25 +1731,25 @@ func (_m *Service) ListSessions(ctx context.Context, paginator query.Paginator)
 	var r0 []models.Session
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.Session, int, error)); ok {
-		return rf(ctx, paginator)
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator, query.Filters, query.Sorter) ([]models.Session, int, error)); ok {
+		return rf(ctx, paginator, filters, sorter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.Session); ok {
-		r0 = rf(ctx, paginator)
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator, query.Filters, query.Sorter) []models.Session); ok {
+		r0 = rf(ctx, paginator, filters, sorter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Session)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
-		r1 = rf(ctx, paginator)
+	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator, query.Filters, query.Sorter) int); ok {
+		r1 = rf(ctx, paginator, filters, sorter)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
-		r2 = rf(ctx, paginator)
+	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator, query.Filters, query.Sorter) error); ok {
+		r2 = rf(ctx, paginator, filters, sorter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1344,6 +1787,36 @@ func (_m *Service) LookupDevice(ctx context.Context, namespace string, name stri
 	return r0, r1
 }
 
+// LookupDeviceByUser provides a mock function with given fields: ctx, userID, name
+func (_m *Service) LookupDeviceByUser(ctx context.Context, userID string, name string) (*models.Device, error) {
+	ret := _m.Called(ctx, userID, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupDeviceByUser")
+	}
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Device, error)); ok {
+		return rf(ctx, userID, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Device); ok {
+		r0 = rf(ctx, userID, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // OffineDevice provides a mock function with given fields: ctx, uid, online
 func (_m *Service) OffineDevice(ctx context.Context, uid models.UID, online bool) error {
 	ret := _m.Called(ctx, uid, online)
@@ -1382,6 +1855,24 @@ func (_m *Service) PublicKey() *rsa.PublicKey {
 	return r0
 }
 
+// RemoveDeviceFile provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) RemoveDeviceFile(ctx context.Context, tenant string, req *requests.DeviceFile) error {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveDeviceFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *requests.DeviceFile) error); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveDeviceTag provides a mock function with given fields: ctx, uid, tag
 func (_m *Service) RemoveDeviceTag(ctx context.Context, uid models.UID, tag string) error {
 	ret := _m.Called(ctx, uid, tag)
@@ -1520,6 +2011,24 @@ func (_m *Service) SetSessionAuthenticated(ctx context.Context, uid models.UID,
 	return r0
 }
 
+// SetSessionDisconnectReason provides a mock function with given fields: ctx, uid, reason
+func (_m *Service) SetSessionDisconnectReason(ctx context.Context, uid models.UID, reason string) error {
+	ret := _m.Called(ctx, uid, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSessionDisconnectReason")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Setup provides a mock function with given fields: ctx, req
 func (_m *Service) Setup(ctx context.Context, req requests.Setup) error {
 	ret := _m.Called(ctx, req)
@@ -1655,6 +2164,24 @@ func (_m *Service) UpdateDevice(ctx context.Context, tenant string, uid models.U
 	return r0
 }
 
+// UpdateDeviceAliases provides a mock function with given fields: ctx, tenant, uid, aliases
+func (_m *Service) UpdateDeviceAliases(ctx context.Context, tenant string, uid models.UID, aliases []string) error {
+	ret := _m.Called(ctx, tenant, uid, aliases)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeviceAliases")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UID, []string) error); ok {
+		r0 = rf(ctx, tenant, uid, aliases)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateDeviceStatus provides a mock function with given fields: ctx, tenant, uid, status
 func (_m *Service) UpdateDeviceStatus(ctx context.Context, tenant string, uid models.UID, status models.DeviceStatus) error {
 	ret := _m.Called(ctx, tenant, uid, status)
@@ -1691,6 +2218,36 @@ func (_m *Service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []s
 	return r0
 }
 
+// UpdateJobSchedule provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) UpdateJobSchedule(ctx context.Context, tenant string, req *requests.JobScheduleUpdate) (*models.JobSchedule, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateJobSchedule")
+	}
+
+	var r0 *models.JobSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *requests.JobScheduleUpdate) (*models.JobSchedule, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *requests.JobScheduleUpdate) *models.JobSchedule); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *requests.JobScheduleUpdate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdatePasswordUser provides a mock function with given fields: ctx, id, currentPassword, newPassword
 func (_m *Service) UpdatePasswordUser(ctx context.Context, id string, currentPassword string, newPassword string) error {
 	ret := _m.Called(ctx, id, currentPassword, newPassword)
@@ -1757,6 +2314,54 @@ func (_m *Service) UpdatePublicKeyTags(ctx context.Context, tenant string, finge
 	return r0
 }
 
+// UpdateUserPreferences provides a mock function with given fields: ctx, id, preferences
+func (_m *Service) UpdateUserPreferences(ctx context.Context, id string, preferences models.UserPreferences) error {
+	ret := _m.Called(ctx, id, preferences)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserPreferences")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UserPreferences) error); ok {
+		r0 = rf(ctx, id, preferences)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UploadDeviceFile provides a mock function with given fields: ctx, tenant, req, content
+func (_m *Service) UploadDeviceFile(ctx context.Context, tenant string, req *requests.DeviceFile, content io.Reader) (*models.File, error) {
+	ret := _m.Called(ctx, tenant, req, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UploadDeviceFile")
+	}
+
+	var r0 *models.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *requests.DeviceFile, io.Reader) (*models.File, error)); ok {
+		return rf(ctx, tenant, req, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *requests.DeviceFile, io.Reader) *models.File); ok {
+		r0 = rf(ctx, tenant, req, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *requests.DeviceFile, io.Reader) error); ok {
+		r1 = rf(ctx, tenant, req, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
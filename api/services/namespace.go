@@ -13,6 +13,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"golang.org/x/crypto/ssh"
 )
 
 type NamespaceService interface {
@@ -82,6 +83,7 @@ func (s *service) CreateNamespace(ctx context.Context, namespace requests.Namesp
 		Settings: &models.NamespaceSettings{
 			SessionRecord:          true,
 			ConnectionAnnouncement: "",
+			AgentForwarding:        true,
 		},
 		TenantID: namespace.TenantID,
 	}
@@ -189,10 +191,25 @@ func (s *service) fillMembersData(ctx context.Context, members []models.Member)
 }
 
 func (s *service) EditNamespace(ctx context.Context, req *requests.NamespaceEdit) (*models.Namespace, error) {
+	if req.Settings.UserCA != nil && req.Settings.UserCA.PublicKey != "" {
+		if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(req.Settings.UserCA.PublicKey)); err != nil { //nolint:dogsled
+			return nil, NewErrNamespaceUserCAInvalid(req.Settings.UserCA.PublicKey, err)
+		}
+	}
+
 	changes := &models.NamespaceChanges{
 		Name:                   strings.ToLower(req.Name),
 		SessionRecord:          req.Settings.SessionRecord,
 		ConnectionAnnouncement: req.Settings.ConnectionAnnouncement,
+		SessionIdleTimeout:     req.Settings.SessionIdleTimeout,
+		SessionMaxDuration:     req.Settings.SessionMaxDuration,
+		ReversePortForwarding:  req.Settings.ReversePortForwarding,
+		PortForwarding:         req.Settings.PortForwarding,
+		AgentForwarding:        req.Settings.AgentForwarding,
+		X11Forwarding:          req.Settings.X11Forwarding,
+		UserCA:                 req.Settings.UserCA,
+		MFARequired:            req.Settings.MFARequired,
+		JobRetention:           req.Settings.JobRetention,
 	}
 
 	if err := s.store.NamespaceEdit(ctx, req.Tenant, changes); err != nil {
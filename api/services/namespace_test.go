@@ -522,7 +522,8 @@ func TestCreateNamespace(t *testing.T) {
 						{ID: "hash1", Role: guard.RoleOwner},
 					},
 					Settings: &models.NamespaceSettings{
-						SessionRecord: true,
+						SessionRecord:   true,
+						AgentForwarding: true,
 					},
 					TenantID: "xxxxx",
 				}
@@ -560,7 +561,8 @@ func TestCreateNamespace(t *testing.T) {
 						{ID: "hash1", Role: guard.RoleOwner},
 					},
 					Settings: &models.NamespaceSettings{
-						SessionRecord: true,
+						SessionRecord:   true,
+						AgentForwarding: true,
 					},
 					TenantID: "xxxxx",
 				}
@@ -598,7 +600,7 @@ func TestCreateNamespace(t *testing.T) {
 					Members: []models.Member{
 						{ID: "hash1", Role: guard.RoleOwner},
 					},
-					Settings:   &models.NamespaceSettings{SessionRecord: true},
+					Settings:   &models.NamespaceSettings{SessionRecord: true, AgentForwarding: true},
 					TenantID:   "xxxxx",
 					MaxDevices: -1,
 				}
@@ -633,7 +635,7 @@ func TestCreateNamespace(t *testing.T) {
 					Members: []models.Member{
 						{ID: "hash1", Role: guard.RoleOwner},
 					},
-					Settings:   &models.NamespaceSettings{SessionRecord: true},
+					Settings:   &models.NamespaceSettings{SessionRecord: true, AgentForwarding: true},
 					TenantID:   "random_uuid",
 					MaxDevices: -1,
 				}
@@ -650,7 +652,7 @@ func TestCreateNamespace(t *testing.T) {
 					Members: []models.Member{
 						{ID: "hash1", Role: guard.RoleOwner},
 					},
-					Settings:   &models.NamespaceSettings{SessionRecord: true},
+					Settings:   &models.NamespaceSettings{SessionRecord: true, AgentForwarding: true},
 					TenantID:   "random_uuid",
 					MaxDevices: -1,
 				}, nil,
@@ -679,7 +681,7 @@ func TestCreateNamespace(t *testing.T) {
 					Members: []models.Member{
 						{ID: "hash1", Role: guard.RoleOwner},
 					},
-					Settings:   &models.NamespaceSettings{SessionRecord: true},
+					Settings:   &models.NamespaceSettings{SessionRecord: true, AgentForwarding: true},
 					TenantID:   "xxxxx",
 					MaxDevices: -1,
 				}
@@ -695,7 +697,7 @@ func TestCreateNamespace(t *testing.T) {
 					Members: []models.Member{
 						{ID: "hash1", Role: guard.RoleOwner},
 					},
-					Settings:   &models.NamespaceSettings{SessionRecord: true},
+					Settings:   &models.NamespaceSettings{SessionRecord: true, AgentForwarding: true},
 					TenantID:   "xxxxx",
 					MaxDevices: -1,
 				}, nil,
@@ -724,7 +726,7 @@ func TestCreateNamespace(t *testing.T) {
 					Members: []models.Member{
 						{ID: "hash1", Role: guard.RoleOwner},
 					},
-					Settings:   &models.NamespaceSettings{SessionRecord: true},
+					Settings:   &models.NamespaceSettings{SessionRecord: true, AgentForwarding: true},
 					TenantID:   "xxxxx",
 					MaxDevices: 3,
 				}
@@ -740,7 +742,7 @@ func TestCreateNamespace(t *testing.T) {
 					Members: []models.Member{
 						{ID: "hash1", Role: guard.RoleOwner},
 					},
-					Settings:   &models.NamespaceSettings{SessionRecord: true},
+					Settings:   &models.NamespaceSettings{SessionRecord: true, AgentForwarding: true},
 					TenantID:   "xxxxx",
 					MaxDevices: 3,
 				}, nil,
@@ -775,8 +777,20 @@ func TestEditNamespace(t *testing.T) {
 		requiredMocks func()
 		tenantID      string
 		namespaceName string
+		userCA        *models.UserCA
 		expected      Expected
 	}{
+		{
+			description:   "fails when the user CA public key is invalid",
+			tenantID:      "xxxxx",
+			namespaceName: "newname",
+			userCA:        &models.UserCA{PublicKey: "invalid"},
+			requiredMocks: func() {},
+			expected: Expected{
+				nil,
+				NewErrNamespaceUserCAInvalid("invalid", errors.New("ssh: no key found")),
+			},
+		},
 		{
 			description:   "fails when namespace does not exist",
 			tenantID:      "xxxxx",
@@ -831,6 +845,42 @@ func TestEditNamespace(t *testing.T) {
 				nil,
 			},
 		},
+		{
+			description:   "succeeds setting the user CA",
+			namespaceName: "newname",
+			tenantID:      "xxxxx",
+			userCA:        &models.UserCA{PublicKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPlN00vtv1eFaaa2bwZixnKiZIlIoBQNDBjS7AlTm9GQ"},
+			requiredMocks: func() {
+				mock.On("NamespaceEdit", ctx, "xxxxx", &models.NamespaceChanges{
+					Name:   "newname",
+					UserCA: &models.UserCA{PublicKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPlN00vtv1eFaaa2bwZixnKiZIlIoBQNDBjS7AlTm9GQ"},
+				}).
+					Return(nil).
+					Once()
+
+				namespace := &models.Namespace{
+					TenantID: "xxxxx",
+					Name:     "newname",
+					Settings: &models.NamespaceSettings{
+						UserCA: &models.UserCA{PublicKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPlN00vtv1eFaaa2bwZixnKiZIlIoBQNDBjS7AlTm9GQ"},
+					},
+				}
+
+				mock.On("NamespaceGet", ctx, "xxxxx").
+					Return(namespace, nil).
+					Once()
+			},
+			expected: Expected{
+				&models.Namespace{
+					TenantID: "xxxxx",
+					Name:     "newname",
+					Settings: &models.NamespaceSettings{
+						UserCA: &models.UserCA{PublicKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPlN00vtv1eFaaa2bwZixnKiZIlIoBQNDBjS7AlTm9GQ"},
+					},
+				},
+				nil,
+			},
+		},
 		{
 			description:   "succeeds",
 			namespaceName: "newname",
@@ -868,6 +918,8 @@ func TestEditNamespace(t *testing.T) {
 				TenantParam: requests.TenantParam{Tenant: tc.tenantID},
 				Name:        tc.namespaceName,
 			}
+			req.Settings.UserCA = tc.userCA
+
 			namespace, err := service.EditNamespace(ctx, req)
 
 			assert.Equal(t, tc.expected, Expected{namespace, err})
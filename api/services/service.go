@@ -31,6 +31,8 @@ type Service interface {
 	TagsService
 	DeviceService
 	DeviceTags
+	DeviceAliases
+	DeviceFiles
 	UserService
 	SSHKeysService
 	SSHKeysTagsService
@@ -41,6 +43,9 @@ type Service interface {
 	SetupService
 	SystemService
 	APIKeyService
+	CertificateService
+	JobService
+	JobScheduleService
 }
 
 func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, c interface{}, l geoip.Locator) *APIService {
@@ -3,24 +3,40 @@ package services
 import (
 	"context"
 	"net"
+	"slices"
 
 	"github.com/shellhub-io/shellhub/api/store"
+	req "github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
 )
 
 type SessionService interface {
-	ListSessions(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error)
+	ListSessions(ctx context.Context, paginator query.Paginator, filters query.Filters, sorter query.Sorter) ([]models.Session, int, error)
 	GetSession(ctx context.Context, uid models.UID) (*models.Session, error)
 	CreateSession(ctx context.Context, session requests.SessionCreate) (*models.Session, error)
 	DeactivateSession(ctx context.Context, uid models.UID) error
 	KeepAliveSession(ctx context.Context, uid models.UID) error
 	SetSessionAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error
+	// SetSessionDisconnectReason stores the reason why ShellHub disconnected the session.
+	SetSessionDisconnectReason(ctx context.Context, uid models.UID, reason string) error
+	// AddSessionEvent registers an event what happened during the session.
+	AddSessionEvent(ctx context.Context, uid models.UID, req *requests.SessionEvent) error
+	// CloseSession terminates an active session, closing it on the device and on the client, and marks it as finished.
+	CloseSession(ctx context.Context, uid models.UID) error
 }
 
-func (s *service) ListSessions(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
-	return s.store.SessionList(ctx, paginator)
+// SessionSortFields are the session's attributes the session's listing can be sorted by.
+var SessionSortFields = []string{"started_at", "last_seen"}
+
+func (s *service) ListSessions(ctx context.Context, paginator query.Paginator, filters query.Filters, sorter query.Sorter) ([]models.Session, int, error) {
+	if sorter.By != "" && !slices.Contains(SessionSortFields, sorter.By) {
+		return nil, 0, NewErrSessionSortInvalid(sorter.By)
+	}
+
+	return s.store.SessionList(ctx, paginator, filters, sorter)
 }
 
 func (s *service) GetSession(ctx context.Context, uid models.UID) (*models.Session, error) {
@@ -65,3 +81,48 @@ func (s *service) KeepAliveSession(ctx context.Context, uid models.UID) error {
 func (s *service) SetSessionAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error {
 	return s.store.SessionSetAuthenticated(ctx, uid, authenticated)
 }
+
+func (s *service) SetSessionDisconnectReason(ctx context.Context, uid models.UID, reason string) error {
+	err := s.store.SessionSetDisconnectReason(ctx, uid, reason)
+	if err == store.ErrNoDocuments {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	return err
+}
+
+func (s *service) AddSessionEvent(ctx context.Context, uid models.UID, req *requests.SessionEvent) error {
+	event := &models.SessionEvent{
+		Type:      req.Type,
+		Timestamp: clock.Now(),
+		Data:      req.Data,
+	}
+
+	err := s.store.SessionAddEvent(ctx, uid, event)
+	if err == store.ErrNoDocuments {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	return err
+}
+
+func (s *service) CloseSession(ctx context.Context, uid models.UID) error {
+	session, err := s.store.SessionGet(ctx, uid)
+	if err != nil {
+		return NewErrSessionNotFound(uid, err)
+	}
+
+	if !session.Active {
+		return NewErrSessionNotActive(uid)
+	}
+
+	if err := s.client.(req.Client).SessionClose(session.UID, string(session.DeviceUID)); err != nil {
+		return NewErrSessionClose(uid, err)
+	}
+
+	if err := s.SetSessionDisconnectReason(ctx, uid, models.SessionDisconnectReasonClosed); err != nil {
+		return err
+	}
+
+	return s.DeactivateSession(ctx, uid)
+}
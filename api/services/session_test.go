@@ -32,14 +32,30 @@ func TestListSessions(t *testing.T) {
 	cases := []struct {
 		description   string
 		paginator     query.Paginator
-		requiredMocks func(paginator query.Paginator)
+		filters       query.Filters
+		sorter        query.Sorter
+		requiredMocks func(paginator query.Paginator, filters query.Filters, sorter query.Sorter)
 		expected      Expected
 	}{
+		{
+			description:   "fails when sorting by an attribute not allowed",
+			paginator:     query.Paginator{Page: 1, PerPage: 10},
+			filters:       query.Filters{},
+			sorter:        query.Sorter{By: "recorded", Order: query.OrderAsc},
+			requiredMocks: func(_ query.Paginator, _ query.Filters, _ query.Sorter) {},
+			expected: Expected{
+				sessions: nil,
+				count:    0,
+				err:      NewErrSessionSortInvalid("recorded"),
+			},
+		},
 		{
 			description: "fails",
 			paginator:   query.Paginator{Page: 1, PerPage: 10},
-			requiredMocks: func(paginator query.Paginator) {
-				mock.On("SessionList", ctx, paginator).
+			filters:     query.Filters{},
+			sorter:      query.Sorter{By: "started_at", Order: query.OrderDesc},
+			requiredMocks: func(paginator query.Paginator, filters query.Filters, sorter query.Sorter) {
+				mock.On("SessionList", ctx, paginator, filters, sorter).
 					Return(nil, 0, goerrors.New("error")).Once()
 			},
 			expected: Expected{
@@ -51,13 +67,22 @@ func TestListSessions(t *testing.T) {
 		{
 			description: "succeeds",
 			paginator:   query.Paginator{Page: 1, PerPage: 10},
-			requiredMocks: func(paginator query.Paginator) {
+			filters: query.Filters{
+				Data: []query.Filter{
+					{
+						Type:   query.FilterTypeProperty,
+						Params: &query.FilterProperty{Name: "active", Operator: "bool", Value: "true"},
+					},
+				},
+			},
+			sorter: query.Sorter{By: "last_seen", Order: query.OrderAsc},
+			requiredMocks: func(paginator query.Paginator, filters query.Filters, sorter query.Sorter) {
 				sessions := []models.Session{
 					{UID: "uid1"},
 					{UID: "uid2"},
 					{UID: "uid3"},
 				}
-				mock.On("SessionList", ctx, paginator).
+				mock.On("SessionList", ctx, paginator, filters, sorter).
 					Return(sessions, len(sessions), nil).Once()
 			},
 			expected: Expected{
@@ -78,10 +103,10 @@ func TestListSessions(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.description, func(t *testing.T) {
-			tc.requiredMocks(tc.paginator)
+			tc.requiredMocks(tc.paginator, tc.filters, tc.sorter)
 
 			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
-			returnedSessions, count, err := service.ListSessions(ctx, tc.paginator)
+			returnedSessions, count, err := service.ListSessions(ctx, tc.paginator, tc.filters, tc.sorter)
 			assert.Equal(t, tc.expected, Expected{returnedSessions, count, err})
 		})
 	}
@@ -312,3 +337,236 @@ func TestSetSessionAuthenticated(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestSetSessionDisconnectReason(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		name          string
+		uid           models.UID
+		reason        string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name:   "fails when session is not found",
+			uid:    models.UID("_uid"),
+			reason: models.SessionDisconnectReasonIdleTimeout,
+			requiredMocks: func() {
+				mock.On("SessionSetDisconnectReason", ctx, models.UID("_uid"), models.SessionDisconnectReasonIdleTimeout).
+					Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrSessionNotFound("_uid", store.ErrNoDocuments),
+		},
+		{
+			name:   "fails",
+			uid:    models.UID("_uid"),
+			reason: models.SessionDisconnectReasonIdleTimeout,
+			requiredMocks: func() {
+				mock.On("SessionSetDisconnectReason", ctx, models.UID("_uid"), models.SessionDisconnectReasonIdleTimeout).
+					Return(goerrors.New("error")).Once()
+			},
+			expected: goerrors.New("error"),
+		},
+		{
+			name:   "succeeds",
+			uid:    models.UID("uid"),
+			reason: models.SessionDisconnectReasonMaxDuration,
+			requiredMocks: func() {
+				mock.On("SessionSetDisconnectReason", ctx, models.UID("uid"), models.SessionDisconnectReasonMaxDuration).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.SetSessionDisconnectReason(ctx, tc.uid, tc.reason)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestAddSessionEvent(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		name          string
+		uid           models.UID
+		req           *requests.SessionEvent
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "fails when session is not found",
+			uid:  models.UID("_uid"),
+			req: &requests.SessionEvent{
+				Type: models.SessionEventTypePortForwardingDenied,
+				Data: map[string]string{"host": "localhost", "port": "80"},
+			},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("SessionAddEvent", ctx, models.UID("_uid"), &models.SessionEvent{
+					Type:      models.SessionEventTypePortForwardingDenied,
+					Timestamp: now,
+					Data:      map[string]string{"host": "localhost", "port": "80"},
+				}).Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrSessionNotFound("_uid", store.ErrNoDocuments),
+		},
+		{
+			name: "fails",
+			uid:  models.UID("_uid"),
+			req: &requests.SessionEvent{
+				Type: models.SessionEventTypePortForwardingDenied,
+				Data: map[string]string{"host": "localhost", "port": "80"},
+			},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("SessionAddEvent", ctx, models.UID("_uid"), &models.SessionEvent{
+					Type:      models.SessionEventTypePortForwardingDenied,
+					Timestamp: now,
+					Data:      map[string]string{"host": "localhost", "port": "80"},
+				}).Return(goerrors.New("error")).Once()
+			},
+			expected: goerrors.New("error"),
+		},
+		{
+			name: "succeeds",
+			uid:  models.UID("uid"),
+			req: &requests.SessionEvent{
+				Type: models.SessionEventTypePortForwardingDenied,
+				Data: map[string]string{"host": "localhost", "port": "80"},
+			},
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("SessionAddEvent", ctx, models.UID("uid"), &models.SessionEvent{
+					Type:      models.SessionEventTypePortForwardingDenied,
+					Timestamp: now,
+					Data:      map[string]string{"host": "localhost", "port": "80"},
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.AddSessionEvent(ctx, tc.uid, tc.req)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCloseSession(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		name          string
+		uid           models.UID
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			name: "fails when session is not found",
+			uid:  models.UID("_uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("_uid")).
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: NewErrSessionNotFound("_uid", goerrors.New("error")),
+		},
+		{
+			name: "fails when session is not active",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", DeviceUID: "device", Active: false}, nil).Once()
+			},
+			expected: NewErrSessionNotActive("uid"),
+		},
+		{
+			name: "fails when the SSH server cannot close the session",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", DeviceUID: "device", Active: true}, nil).Once()
+				clientMock.On("SessionClose", "uid", "device").
+					Return(goerrors.New("error")).Once()
+			},
+			expected: NewErrSessionClose("uid", goerrors.New("error")),
+		},
+		{
+			name: "fails when the disconnect reason cannot be set",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", DeviceUID: "device", Active: true}, nil).Once()
+				clientMock.On("SessionClose", "uid", "device").
+					Return(nil).Once()
+				mock.On("SessionSetDisconnectReason", ctx, models.UID("uid"), models.SessionDisconnectReasonClosed).
+					Return(goerrors.New("error")).Once()
+			},
+			expected: goerrors.New("error"),
+		},
+		{
+			name: "fails when session cannot be deactivated",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", DeviceUID: "device", Active: true}, nil).Once()
+				clientMock.On("SessionClose", "uid", "device").
+					Return(nil).Once()
+				mock.On("SessionSetDisconnectReason", ctx, models.UID("uid"), models.SessionDisconnectReasonClosed).
+					Return(nil).Once()
+				mock.On("SessionDeleteActives", ctx, models.UID("uid")).
+					Return(goerrors.New("error")).Once()
+			},
+			expected: goerrors.New("error"),
+		},
+		{
+			name: "succeeds",
+			uid:  models.UID("uid"),
+			requiredMocks: func() {
+				mock.On("SessionGet", ctx, models.UID("uid")).
+					Return(&models.Session{UID: "uid", DeviceUID: "device", Active: true}, nil).Once()
+				clientMock.On("SessionClose", "uid", "device").
+					Return(nil).Once()
+				mock.On("SessionSetDisconnectReason", ctx, models.UID("uid"), models.SessionDisconnectReasonClosed).
+					Return(nil).Once()
+				mock.On("SessionDeleteActives", ctx, models.UID("uid")).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.CloseSession(ctx, tc.uid)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -59,6 +59,7 @@ func (s *service) Setup(ctx context.Context, req requests.Setup) error {
 		Settings: &models.NamespaceSettings{
 			SessionRecord:          false,
 			ConnectionAnnouncement: "",
+			AgentForwarding:        true,
 		},
 	}
 
@@ -123,6 +123,7 @@ func TestSetup(t *testing.T) {
 					Settings: &models.NamespaceSettings{
 						SessionRecord:          false,
 						ConnectionAnnouncement: "",
+						AgentForwarding:        true,
 					},
 					CreatedAt: now,
 				}
@@ -176,6 +177,7 @@ func TestSetup(t *testing.T) {
 					Settings: &models.NamespaceSettings{
 						SessionRecord:          false,
 						ConnectionAnnouncement: "",
+						AgentForwarding:        true,
 					},
 					CreatedAt: now,
 				}
@@ -112,6 +112,7 @@ func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCre
 		Fingerprint: req.Fingerprint,
 		CreatedAt:   clock.Now(),
 		TenantID:    req.TenantID,
+		CreatedBy:   req.CreatedBy,
 		PublicKeyFields: models.PublicKeyFields{
 			Name:     req.Name,
 			Username: req.Username,
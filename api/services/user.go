@@ -9,6 +9,7 @@ import (
 type UserService interface {
 	UpdateDataUser(ctx context.Context, id string, userData models.UserData) ([]string, error)
 	UpdatePasswordUser(ctx context.Context, id string, currentPassword, newPassword string) error
+	UpdateUserPreferences(ctx context.Context, id string, preferences models.UserPreferences) error
 }
 
 // UpdateDataUser update user data.
@@ -71,3 +72,30 @@ func (s *service) UpdatePasswordUser(ctx context.Context, id, currentPassword, n
 
 	return nil
 }
+
+// UpdateUserPreferences updates the user's preferences.
+//
+// The default namespace, when set, must be a namespace the user is a member of.
+func (s *service) UpdateUserPreferences(ctx context.Context, id string, preferences models.UserPreferences) error {
+	user, _, err := s.store.UserGetByID(ctx, id, false)
+	if user == nil {
+		return NewErrUserNotFound(id, err)
+	}
+
+	if preferences.DefaultNamespace != "" {
+		namespace, err := s.store.NamespaceGet(ctx, preferences.DefaultNamespace)
+		if err != nil {
+			return NewErrNamespaceNotFound(preferences.DefaultNamespace, err)
+		}
+
+		if _, ok := namespace.FindMember(id); !ok {
+			return NewErrNamespaceMemberNotFound(id, nil)
+		}
+	}
+
+	if err := s.store.UserUpdatePreferences(ctx, id, preferences); err != nil {
+		return NewErrUserUpdate(user, err)
+	}
+
+	return nil
+}
@@ -398,3 +398,117 @@ func TestUpdatePasswordUser(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestUpdateUserPreferences(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.Background()
+
+	user := &models.User{ID: "65fde3a72c4c7507c7f53c43"}
+	namespace := &models.Namespace{
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		Members:  []models.Member{{ID: "65fde3a72c4c7507c7f53c43", Role: "operator"}},
+	}
+
+	cases := []struct {
+		description   string
+		id            string
+		preferences   models.UserPreferences
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when user is not found",
+			id:          "65fde3a72c4c7507c7f53c43",
+			preferences: models.UserPreferences{DefaultNamespace: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func() {
+				mock.
+					On("UserGetByID", ctx, "65fde3a72c4c7507c7f53c43", false).
+					Return(nil, 0, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: NewErrUserNotFound("65fde3a72c4c7507c7f53c43", errors.New("error", "", 0)),
+		},
+		{
+			description: "fails when the default namespace is not found",
+			id:          "65fde3a72c4c7507c7f53c43",
+			preferences: models.UserPreferences{DefaultNamespace: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func() {
+				mock.
+					On("UserGetByID", ctx, "65fde3a72c4c7507c7f53c43", false).
+					Return(user, 1, nil).
+					Once()
+				mock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(nil, errors.New("error", "", 0)).
+					Once()
+			},
+			expected: NewErrNamespaceNotFound("00000000-0000-4000-0000-000000000000", errors.New("error", "", 0)),
+		},
+		{
+			description: "fails when the user isn't a member of the default namespace",
+			id:          "65fde3a72c4c7507c7f53c44",
+			preferences: models.UserPreferences{DefaultNamespace: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func() {
+				mock.
+					On("UserGetByID", ctx, "65fde3a72c4c7507c7f53c44", false).
+					Return(&models.User{ID: "65fde3a72c4c7507c7f53c44"}, 1, nil).
+					Once()
+				mock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).
+					Once()
+			},
+			expected: NewErrNamespaceMemberNotFound("65fde3a72c4c7507c7f53c44", nil),
+		},
+		{
+			description: "succeeds when the user is a member of the default namespace",
+			id:          "65fde3a72c4c7507c7f53c43",
+			preferences: models.UserPreferences{DefaultNamespace: "00000000-0000-4000-0000-000000000000"},
+			requiredMocks: func() {
+				mock.
+					On("UserGetByID", ctx, "65fde3a72c4c7507c7f53c43", false).
+					Return(user, 1, nil).
+					Once()
+				mock.
+					On("NamespaceGet", ctx, "00000000-0000-4000-0000-000000000000").
+					Return(namespace, nil).
+					Once()
+				mock.
+					On("UserUpdatePreferences", ctx, "65fde3a72c4c7507c7f53c43", models.UserPreferences{DefaultNamespace: "00000000-0000-4000-0000-000000000000"}).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when unsetting the default namespace",
+			id:          "65fde3a72c4c7507c7f53c43",
+			preferences: models.UserPreferences{DefaultNamespace: ""},
+			requiredMocks: func() {
+				mock.
+					On("UserGetByID", ctx, "65fde3a72c4c7507c7f53c43", false).
+					Return(user, 1, nil).
+					Once()
+				mock.
+					On("UserUpdatePreferences", ctx, "65fde3a72c4c7507c7f53c43", models.UserPreferences{DefaultNamespace: ""}).
+					Return(nil).
+					Once()
+			},
+			expected: nil,
+		},
+	}
+
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			err := service.UpdateUserPreferences(ctx, tc.id, tc.preferences)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
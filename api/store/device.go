@@ -36,7 +36,16 @@ type DeviceStore interface {
 	DeviceGetByName(ctx context.Context, name string, tenantID string, status models.DeviceStatus) (*models.Device, error)
 	DeviceGetByUID(ctx context.Context, uid models.UID, tenantID string) (*models.Device, error)
 	DeviceSetPosition(ctx context.Context, uid models.UID, position models.DevicePosition) error
+	// DeviceSetHostKey sets the agent's SSH host key of the device with the specified UID, only when it has none. It
+	// returns an error, if any, or store.ErrNoDocuments if the device does not exist or already has a host key.
+	DeviceSetHostKey(ctx context.Context, uid models.UID, hostKey string) error
+	// DeviceSetAliases sets the aliases of the device with the specified UID.
+	DeviceSetAliases(ctx context.Context, uid models.UID, aliases []string) error
+	// DeviceGetByAlias gets the accepted device of the specified tenant that has the alias.
+	DeviceGetByAlias(ctx context.Context, alias string, tenantID string) (*models.Device, error)
 	DeviceListByUsage(ctx context.Context, tenantID string) ([]models.UID, error)
+	// DeviceListBySelector lists the tenant's accepted devices selected by the job's selector, sorted by name.
+	DeviceListBySelector(ctx context.Context, tenantID string, selector *models.JobSelector) ([]models.Device, error)
 	DeviceChooser(ctx context.Context, tenantID string, chosen []string) error
 	DeviceRemovedCount(ctx context.Context, tenant string) (int64, error)
 	DeviceRemovedGet(ctx context.Context, tenant string, uid models.UID) (*models.DeviceRemoved, error)
@@ -0,0 +1,31 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type JobStore interface {
+	// JobCreate creates the job with the results of its devices.
+	JobCreate(ctx context.Context, job *models.Job, results []models.JobResult) error
+	// JobList lists the namespace's jobs, from the newest to the oldest. When the schedule is informed, only its runs
+	// are listed.
+	JobList(ctx context.Context, tenant string, schedule string, paginator query.Paginator) ([]models.Job, int, error)
+	// JobGet gets the job with the ID.
+	JobGet(ctx context.Context, id string) (*models.Job, error)
+	// JobUpdateStatus updates the job's status, recording when the job started and finished, only when its current
+	// status is one of the expected. It returns [ErrNoDocuments] when the job isn't found with any of them.
+	JobUpdateStatus(ctx context.Context, id string, status models.JobStatus, expected ...models.JobStatus) error
+	// JobListRunning lists the jobs being executed, from every namespace.
+	JobListRunning(ctx context.Context) ([]models.Job, error)
+	// JobResultList lists the results of the job's devices.
+	JobResultList(ctx context.Context, id string) ([]models.JobResult, error)
+	// JobResultUpdate replaces the result of the job's device.
+	JobResultUpdate(ctx context.Context, result *models.JobResult) error
+	// JobDeleteExpired deletes the jobs, and their results, finished before the retention of their namespaces, counted
+	// back from the time. Jobs of namespaces without a retention are kept.
+	JobDeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
@@ -0,0 +1,31 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type JobScheduleStore interface {
+	// JobScheduleCreate creates the job schedule.
+	JobScheduleCreate(ctx context.Context, schedule *models.JobSchedule) error
+	// JobScheduleList lists the namespace's job schedules, from the newest to the oldest.
+	JobScheduleList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.JobSchedule, int, error)
+	// JobScheduleListEnabled lists the enabled job schedules of every namespace.
+	JobScheduleListEnabled(ctx context.Context) ([]models.JobSchedule, error)
+	// JobScheduleGet gets the job schedule with the ID.
+	JobScheduleGet(ctx context.Context, id string) (*models.JobSchedule, error)
+	// JobScheduleUpdate updates the job schedule. It returns [ErrNoDocuments] when the job schedule isn't found.
+	JobScheduleUpdate(ctx context.Context, id string, changes *models.JobScheduleChanges) error
+	// JobScheduleRun records the run of the enabled job schedule at the time, replacing the devices waiting to
+	// reconnect by the missed ones. It returns [ErrNoDocuments] when the job schedule isn't found, is disabled or
+	// was already run at, or after, the time.
+	JobScheduleRun(ctx context.Context, id string, at time.Time, missed []string) error
+	// JobScheduleRemoveMissed removes the devices from the ones waiting to reconnect on the job schedule.
+	JobScheduleRemoveMissed(ctx context.Context, id string, uids []string) error
+	// JobScheduleDelete deletes the job schedule, keeping its runs. It returns [ErrNoDocuments] when the job schedule
+	// isn't found.
+	JobScheduleDelete(ctx context.Context, id string) error
+}
@@ -542,6 +542,36 @@ func (_m *Store) DeviceGet(ctx context.Context, uid models.UID) (*models.Device,
 	return r0, r1
 }
 
+// DeviceGetByAlias provides a mock function with given fields: ctx, alias, tenantID
+func (_m *Store) DeviceGetByAlias(ctx context.Context, alias string, tenantID string) (*models.Device, error) {
+	ret := _m.Called(ctx, alias, tenantID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceGetByAlias")
+	}
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Device, error)); ok {
+		return rf(ctx, alias, tenantID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Device); ok {
+		r0 = rf(ctx, alias, tenantID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, alias, tenantID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeviceGetByMac provides a mock function with given fields: ctx, mac, tenantID, status
 func (_m *Store) DeviceGetByMac(ctx context.Context, mac string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
 	ret := _m.Called(ctx, mac, tenantID, status)
@@ -736,6 +766,36 @@ func (_m *Store) DeviceList(ctx context.Context, status models.DeviceStatus, pag
 	return r0, r1, r2
 }
 
+// DeviceListBySelector provides a mock function with given fields: ctx, tenantID, selector
+func (_m *Store) DeviceListBySelector(ctx context.Context, tenantID string, selector *models.JobSelector) ([]models.Device, error) {
+	ret := _m.Called(ctx, tenantID, selector)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceListBySelector")
+	}
+
+	var r0 []models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.JobSelector) ([]models.Device, error)); ok {
+		return rf(ctx, tenantID, selector)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.JobSelector) []models.Device); ok {
+		r0 = rf(ctx, tenantID, selector)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *models.JobSelector) error); ok {
+		r1 = rf(ctx, tenantID, selector)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeviceListByUsage provides a mock function with given fields: ctx, tenantID
 func (_m *Store) DeviceListByUsage(ctx context.Context, tenantID string) ([]models.UID, error) {
 	ret := _m.Called(ctx, tenantID)
@@ -981,6 +1041,42 @@ func (_m *Store) DeviceRename(ctx context.Context, uid models.UID, hostname stri
 	return r0
 }
 
+// DeviceSetAliases provides a mock function with given fields: ctx, uid, aliases
+func (_m *Store) DeviceSetAliases(ctx context.Context, uid models.UID, aliases []string) error {
+	ret := _m.Called(ctx, uid, aliases)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceSetAliases")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, []string) error); ok {
+		r0 = rf(ctx, uid, aliases)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceSetHostKey provides a mock function with given fields: ctx, uid, hostKey
+func (_m *Store) DeviceSetHostKey(ctx context.Context, uid models.UID, hostKey string) error {
+	ret := _m.Called(ctx, uid, hostKey)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceSetHostKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, hostKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceSetOnline provides a mock function with given fields: ctx, uid, timestamp, online
 func (_m *Store) DeviceSetOnline(ctx context.Context, uid models.UID, timestamp time.Time, online bool) error {
 	ret := _m.Called(ctx, uid, timestamp, online)
@@ -1520,6 +1616,409 @@ func (_m *Store) GetStatusMFA(ctx context.Context, id string) (bool, error) {
 	return r0, r1
 }
 
+// JobCreate provides a mock function with given fields: ctx, job, results
+func (_m *Store) JobCreate(ctx context.Context, job *models.Job, results []models.JobResult) error {
+	ret := _m.Called(ctx, job, results)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Job, []models.JobResult) error); ok {
+		r0 = rf(ctx, job, results)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobDeleteExpired provides a mock function with given fields: ctx, now
+func (_m *Store) JobDeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobDeleteExpired")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, now)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JobGet provides a mock function with given fields: ctx, id
+func (_m *Store) JobGet(ctx context.Context, id string) (*models.Job, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobGet")
+	}
+
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Job, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Job); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JobList provides a mock function with given fields: ctx, tenant, schedule, paginator
+func (_m *Store) JobList(ctx context.Context, tenant string, schedule string, paginator query.Paginator) ([]models.Job, int, error) {
+	ret := _m.Called(ctx, tenant, schedule, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobList")
+	}
+
+	var r0 []models.Job
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, query.Paginator) ([]models.Job, int, error)); ok {
+		return rf(ctx, tenant, schedule, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, query.Paginator) []models.Job); ok {
+		r0 = rf(ctx, tenant, schedule, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, schedule, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, schedule, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// JobListRunning provides a mock function with given fields: ctx
+func (_m *Store) JobListRunning(ctx context.Context) ([]models.Job, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobListRunning")
+	}
+
+	var r0 []models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Job, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Job); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JobResultList provides a mock function with given fields: ctx, id
+func (_m *Store) JobResultList(ctx context.Context, id string) ([]models.JobResult, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobResultList")
+	}
+
+	var r0 []models.JobResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.JobResult, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.JobResult); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JobResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JobResultUpdate provides a mock function with given fields: ctx, result
+func (_m *Store) JobResultUpdate(ctx context.Context, result *models.JobResult) error {
+	ret := _m.Called(ctx, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobResultUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.JobResult) error); ok {
+		r0 = rf(ctx, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobScheduleCreate provides a mock function with given fields: ctx, schedule
+func (_m *Store) JobScheduleCreate(ctx context.Context, schedule *models.JobSchedule) error {
+	ret := _m.Called(ctx, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.JobSchedule) error); ok {
+		r0 = rf(ctx, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobScheduleDelete provides a mock function with given fields: ctx, id
+func (_m *Store) JobScheduleDelete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobScheduleGet provides a mock function with given fields: ctx, id
+func (_m *Store) JobScheduleGet(ctx context.Context, id string) (*models.JobSchedule, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleGet")
+	}
+
+	var r0 *models.JobSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.JobSchedule, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.JobSchedule); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JobScheduleList provides a mock function with given fields: ctx, tenant, paginator
+func (_m *Store) JobScheduleList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.JobSchedule, int, error) {
+	ret := _m.Called(ctx, tenant, paginator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleList")
+	}
+
+	var r0 []models.JobSchedule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) ([]models.JobSchedule, int, error)); ok {
+		return rf(ctx, tenant, paginator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, query.Paginator) []models.JobSchedule); ok {
+		r0 = rf(ctx, tenant, paginator)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, query.Paginator) int); ok {
+		r1 = rf(ctx, tenant, paginator)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, query.Paginator) error); ok {
+		r2 = rf(ctx, tenant, paginator)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// JobScheduleListEnabled provides a mock function with given fields: ctx
+func (_m *Store) JobScheduleListEnabled(ctx context.Context) ([]models.JobSchedule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleListEnabled")
+	}
+
+	var r0 []models.JobSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.JobSchedule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.JobSchedule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JobScheduleRemoveMissed provides a mock function with given fields: ctx, id, uids
+func (_m *Store) JobScheduleRemoveMissed(ctx context.Context, id string, uids []string) error {
+	ret := _m.Called(ctx, id, uids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleRemoveMissed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, id, uids)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobScheduleRun provides a mock function with given fields: ctx, id, at, missed
+func (_m *Store) JobScheduleRun(ctx context.Context, id string, at time.Time, missed []string) error {
+	ret := _m.Called(ctx, id, at, missed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, []string) error); ok {
+		r0 = rf(ctx, id, at, missed)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobScheduleUpdate provides a mock function with given fields: ctx, id, changes
+func (_m *Store) JobScheduleUpdate(ctx context.Context, id string, changes *models.JobScheduleChanges) error {
+	ret := _m.Called(ctx, id, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.JobScheduleChanges) error); ok {
+		r0 = rf(ctx, id, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobUpdateStatus provides a mock function with given fields: ctx, id, status, expected
+func (_m *Store) JobUpdateStatus(ctx context.Context, id string, status models.JobStatus, expected ...models.JobStatus) error {
+	_va := make([]interface{}, len(expected))
+	for _i := range expected {
+		_va[_i] = expected[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, id, status)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobUpdateStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.JobStatus, ...models.JobStatus) error); ok {
+		r0 = rf(ctx, id, status, expected...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // LicenseLoad provides a mock function with given fields: ctx
 func (_m *Store) LicenseLoad(ctx context.Context) (*models.License, error) {
 	ret := _m.Called(ctx)
@@ -1867,6 +2366,24 @@ func (_m *Store) NamespaceRemoveMember(ctx context.Context, tenantID string, mem
 	return r0, r1
 }
 
+// NamespaceSetCA provides a mock function with given fields: ctx, tenant, ca
+func (_m *Store) NamespaceSetCA(ctx context.Context, tenant string, ca *models.NamespaceCA) error {
+	ret := _m.Called(ctx, tenant, ca)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NamespaceSetCA")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.NamespaceCA) error); ok {
+		r0 = rf(ctx, tenant, ca)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NamespaceSetSessionRecord provides a mock function with given fields: ctx, sessionRecord, tenantID
 func (_m *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error {
 	ret := _m.Called(ctx, sessionRecord, tenantID)
@@ -2248,6 +2765,24 @@ func (_m *Store) PublicKeyUpdate(ctx context.Context, fingerprint string, tenant
 	return r0, r1
 }
 
+// SessionAddEvent provides a mock function with given fields: ctx, uid, event
+func (_m *Store) SessionAddEvent(ctx context.Context, uid models.UID, event *models.SessionEvent) error {
+	ret := _m.Called(ctx, uid, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionAddEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.SessionEvent) error); ok {
+		r0 = rf(ctx, uid, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SessionCreate provides a mock function with given fields: ctx, session
 func (_m *Store) SessionCreate(ctx context.Context, session models.Session) (*models.Session, error) {
 	ret := _m.Called(ctx, session)
@@ -2434,9 +2969,9 @@ func (_m *Store) SessionGetRecordFrame(ctx context.Context, uid models.UID) ([]m
 	return r0, r1, r2
 }
 
-// SessionList provides a mock function with given fields: ctx, paginator
-func (_m *Store) SessionList(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
-	ret := _m.Called(ctx, paginator)
+// SessionList provides a mock function with given fields: ctx, paginator, filters, sorter
+func (_m *Store) SessionList(ctx context.Context, paginator query.Paginator, filters query.Filters, sorter query.Sorter) ([]models.Session, int, error) {
+	ret := _m.Called(ctx, paginator, filters, sorter)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SessionList")
@@ -2445,25 +2980,25 @@ func (_m *Store) SessionList(ctx context.Context, paginator query.Paginator) ([]
 	var r0 []models.Session
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) ([]models.Session, int, error)); ok {
-		return rf(ctx, paginator)
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator, query.Filters, query.Sorter) ([]models.Session, int, error)); ok {
+		return rf(ctx, paginator, filters, sorter)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator) []models.Session); ok {
-		r0 = rf(ctx, paginator)
+	if rf, ok := ret.Get(0).(func(context.Context, query.Paginator, query.Filters, query.Sorter) []models.Session); ok {
+		r0 = rf(ctx, paginator, filters, sorter)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]models.Session)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator) int); ok {
-		r1 = rf(ctx, paginator)
+	if rf, ok := ret.Get(1).(func(context.Context, query.Paginator, query.Filters, query.Sorter) int); ok {
+		r1 = rf(ctx, paginator, filters, sorter)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator) error); ok {
-		r2 = rf(ctx, paginator)
+	if rf, ok := ret.Get(2).(func(context.Context, query.Paginator, query.Filters, query.Sorter) error); ok {
+		r2 = rf(ctx, paginator, filters, sorter)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -2489,6 +3024,24 @@ func (_m *Store) SessionSetAuthenticated(ctx context.Context, uid models.UID, au
 	return r0
 }
 
+// SessionSetDisconnectReason provides a mock function with given fields: ctx, uid, reason
+func (_m *Store) SessionSetDisconnectReason(ctx context.Context, uid models.UID, reason string) error {
+	ret := _m.Called(ctx, uid, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionSetDisconnectReason")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SessionSetLastSeen provides a mock function with given fields: ctx, uid
 func (_m *Store) SessionSetLastSeen(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -2992,6 +3545,24 @@ func (_m *Store) UserUpdatePassword(ctx context.Context, newPassword string, id
 	return r0
 }
 
+// UserUpdatePreferences provides a mock function with given fields: ctx, id, preferences
+func (_m *Store) UserUpdatePreferences(ctx context.Context, id string, preferences models.UserPreferences) error {
+	ret := _m.Called(ctx, id, preferences)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserUpdatePreferences")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.UserPreferences) error); ok {
+		r0 = rf(ctx, id, preferences)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStore(t interface {
@@ -317,8 +317,15 @@ func (s *Store) DeviceLookup(ctx context.Context, namespace, hostname string) (*
 		return nil, FromMongoError(err)
 	}
 
+	// NOTICE: A device can be looked up by any of its aliases, what never conflict with the other devices' names.
+	filter := bson.M{
+		"tenant_id": ns.TenantID,
+		"status":    "accepted",
+		"$or":       bson.A{bson.M{"name": hostname}, bson.M{"aliases": hostname}},
+	}
+
 	device := new(models.Device)
-	if err := s.db.Collection("devices").FindOne(ctx, bson.M{"tenant_id": ns.TenantID, "name": hostname, "status": "accepted"}).Decode(&device); err != nil {
+	if err := s.db.Collection("devices").FindOne(ctx, filter).Decode(&device); err != nil {
 		return nil, FromMongoError(err)
 	}
 
@@ -461,6 +468,72 @@ func (s *Store) DeviceListByUsage(ctx context.Context, tenant string) ([]models.
 	return uids, nil
 }
 
+func (s *Store) DeviceListBySelector(ctx context.Context, tenant string, selector *models.JobSelector) ([]models.Device, error) {
+	filters := query.Filters{Raw: selector.Filter}
+	if err := filters.Unmarshal(); err != nil {
+		return nil, err
+	}
+
+	match := bson.M{
+		"tenant_id": tenant,
+		"status":    models.DeviceStatusAccepted,
+	}
+
+	or := make([]bson.M, 0, 2)
+	if len(selector.UIDs) > 0 {
+		or = append(or, bson.M{"uid": bson.M{"$in": selector.UIDs}})
+	}
+
+	if len(selector.Tags) > 0 {
+		or = append(or, bson.M{"tags": bson.M{"$in": selector.Tags}})
+	}
+
+	if len(or) > 0 {
+		match["$or"] = or
+	}
+
+	// NOTICE: The filters are appended after the tenant's match, so they can only narrow the selected devices.
+	query := []bson.M{
+		{
+			"$match": match,
+		},
+		{
+			"$lookup": bson.M{
+				"from":         "connected_devices",
+				"localField":   "uid",
+				"foreignField": "uid",
+				"as":           "online",
+			},
+		},
+		{
+			"$addFields": bson.M{
+				"online": bson.M{"$anyElementTrue": []interface{}{"$online"}},
+			},
+		},
+	}
+
+	queryMatch, err := queries.FromFilters(&filters)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	query = append(query, queryMatch...)
+	query = append(query, bson.M{"$sort": bson.M{"name": 1}})
+
+	cursor, err := s.db.Collection("devices").Aggregate(ctx, query)
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	devices := make([]models.Device, 0)
+	if err := cursor.All(ctx, &devices); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return devices, nil
+}
+
 func (s *Store) DeviceGetByMac(ctx context.Context, mac string, tenantID string, status models.DeviceStatus) (*models.Device, error) {
 	device := new(models.Device)
 
@@ -522,6 +595,51 @@ func (s *Store) DeviceSetPosition(ctx context.Context, uid models.UID, position
 	return nil
 }
 
+func (s *Store) DeviceSetHostKey(ctx context.Context, uid models.UID, hostKey string) error {
+	dev, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid, "host_key": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"host_key": hostKey}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if dev.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	// NOTICE: A cached device without the host key would allow it to be pinned again by the next authentication.
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetAliases(ctx context.Context, uid models.UID, aliases []string) error {
+	dev, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"aliases": aliases}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if dev.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"device", string(uid)}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceGetByAlias(ctx context.Context, alias string, tenantID string) (*models.Device, error) {
+	device := new(models.Device)
+
+	if err := s.db.Collection("devices").FindOne(ctx, bson.M{"tenant_id": tenantID, "status": "accepted", "aliases": alias}).Decode(&device); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return device, nil
+}
+
 func (s *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []string) error {
 	filter := bson.M{
 		"status":    "accepted",
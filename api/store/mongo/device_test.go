@@ -1335,6 +1335,172 @@ func TestDeviceSetPosition(t *testing.T) {
 	}
 }
 
+func TestDeviceSetHostKey(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		pinned      string
+		hostKey     string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("nonexistent"),
+			hostKey:     "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPlN00vtv1eFaaa2bwZixnKiZIlIoBQNDBjS7AlTm9GQ",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the device is found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			hostKey:     "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPlN00vtv1eFaaa2bwZixnKiZIlIoBQNDBjS7AlTm9GQ",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    nil,
+		},
+		{
+			description: "fails when the device already has a host key",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			pinned:      "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl",
+			hostKey:     "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIPlN00vtv1eFaaa2bwZixnKiZIlIoBQNDBjS7AlTm9GQ",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			if tc.pinned != "" {
+				assert.NoError(t, mongostore.DeviceSetHostKey(context.TODO(), tc.uid, tc.pinned))
+			}
+
+			err := mongostore.DeviceSetHostKey(context.TODO(), tc.uid, tc.hostKey)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestDeviceSetAliases(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		aliases     []string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("nonexistent"),
+			aliases:     []string{"web01"},
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the device is found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			aliases:     []string{"web01"},
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.DeviceSetAliases(context.TODO(), tc.uid, tc.aliases)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestDeviceGetByAlias(t *testing.T) {
+	type Expected struct {
+		uid string
+		err error
+	}
+
+	cases := []struct {
+		description string
+		uid         models.UID
+		alias       string
+		tenant      string
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "fails when no device has the alias",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			alias:       "nonexistent",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    Expected{uid: "", err: store.ErrNoDocuments},
+		},
+		{
+			description: "fails when the device with the alias is from another tenant",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			alias:       "web01",
+			tenant:      "nonexistent",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    Expected{uid: "", err: store.ErrNoDocuments},
+		},
+		{
+			description: "fails when the device with the alias is not accepted",
+			uid:         models.UID("3300330e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809d"),
+			alias:       "web01",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    Expected{uid: "", err: store.ErrNoDocuments},
+		},
+		{
+			description: "succeeds when a device of the tenant has the alias",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			alias:       "web01",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    Expected{uid: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c", err: nil},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.DeviceSetAliases(context.TODO(), tc.uid, []string{"web01"})
+			assert.NoError(t, err)
+
+			device, err := mongostore.DeviceGetByAlias(context.TODO(), tc.alias, tc.tenant)
+			assert.Equal(t, tc.expected.err, err)
+			if tc.expected.err == nil {
+				assert.Equal(t, tc.expected.uid, device.UID)
+			}
+		})
+	}
+}
+
 func TestDeviceChooser(t *testing.T) {
 	cases := []struct {
 		description string
@@ -1406,3 +1572,72 @@ func TestDeviceDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestDeviceListBySelector(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		selector    *models.JobSelector
+		fixtures    []string
+		expected    []string
+	}{
+		{
+			description: "succeeds listing the accepted devices of the tenant",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			selector:    &models.JobSelector{},
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    []string{"device-1", "device-2", "device-3"},
+		},
+		{
+			description: "succeeds listing the devices with the UIDs or the tags",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			selector: &models.JobSelector{
+				UIDs: []string{"4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e"},
+				Tags: []string{"tag-1"},
+			},
+			fixtures: []string{fixtures.FixtureDevices},
+			expected: []string{"device-1", "device-2", "device-3"},
+		},
+		{
+			description: "succeeds listing the devices with the tags that match the filter",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			selector: &models.JobSelector{
+				Tags: []string{"tag-1"},
+				// [{"type":"property","params":{"name":"name","operator":"eq","value":"device-3"}}]
+				Filter: "W3sidHlwZSI6InByb3BlcnR5IiwicGFyYW1zIjp7Im5hbWUiOiJuYW1lIiwib3BlcmF0b3IiOiJlcSIsInZhbHVlIjoiZGV2aWNlLTMifX1d",
+			},
+			fixtures: []string{fixtures.FixtureDevices},
+			expected: []string{"device-3"},
+		},
+		{
+			description: "succeeds listing no devices from another tenant",
+			tenant:      "nonexistent",
+			selector:    &models.JobSelector{Tags: []string{"tag-1"}},
+			fixtures:    []string{fixtures.FixtureDevices},
+			expected:    []string{},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			devices, err := mongostore.DeviceListBySelector(context.TODO(), tc.tenant, tc.selector)
+			assert.NoError(t, err)
+
+			names := make([]string, len(devices))
+			for i, device := range devices {
+				names[i] = device.Name
+			}
+
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) JobCreate(ctx context.Context, job *models.Job, results []models.JobResult) error {
+	if _, err := s.db.Collection("jobs").InsertOne(ctx, job); err != nil {
+		return FromMongoError(err)
+	}
+
+	if len(results) == 0 {
+		return nil
+	}
+
+	documents := make([]interface{}, len(results))
+	for i := range results {
+		documents[i] = results[i]
+	}
+
+	_, err := s.db.Collection("job_results").InsertMany(ctx, documents)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) JobList(ctx context.Context, tenant string, schedule string, paginator query.Paginator) ([]models.Job, int, error) {
+	match := bson.M{"tenant_id": tenant}
+	if schedule != "" {
+		match["schedule_id"] = schedule
+	}
+
+	query := []bson.M{
+		{
+			"$match": match,
+		},
+	}
+
+	count, err := AggregateCount(ctx, s.db.Collection("jobs"), append(query, bson.M{"$count": "count"}))
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("jobs").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	jobs := make([]models.Job, 0)
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return jobs, count, nil
+}
+
+func (s *Store) JobGet(ctx context.Context, id string) (*models.Job, error) {
+	job := new(models.Job)
+	if err := s.db.Collection("jobs").FindOne(ctx, bson.M{"_id": id}).Decode(job); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return job, nil
+}
+
+func (s *Store) JobUpdateStatus(ctx context.Context, id string, status models.JobStatus, expected ...models.JobStatus) error {
+	changes := bson.M{"status": status}
+	switch status {
+	case models.JobStatusRunning:
+		changes["started_at"] = clock.Now()
+	case models.JobStatusFinished, models.JobStatusCancelled, models.JobStatusFailed:
+		changes["finished_at"] = clock.Now()
+	}
+
+	filter := bson.M{"_id": id}
+	if len(expected) > 0 {
+		filter["status"] = bson.M{"$in": expected}
+	}
+
+	r, err := s.db.Collection("jobs").UpdateOne(ctx, filter, bson.M{"$set": changes})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) JobListRunning(ctx context.Context) ([]models.Job, error) {
+	cursor, err := s.db.Collection("jobs").Find(ctx, bson.M{"status": models.JobStatusRunning})
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	jobs := make([]models.Job, 0)
+	if err := cursor.All(ctx, &jobs); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return jobs, nil
+}
+
+func (s *Store) JobResultList(ctx context.Context, id string) ([]models.JobResult, error) {
+	cursor, err := s.db.Collection("job_results").Find(ctx, bson.M{"job_id": id}, options.Find().SetSort(bson.M{"name": 1}))
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	results := make([]models.JobResult, 0)
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return results, nil
+}
+
+func (s *Store) JobResultUpdate(ctx context.Context, result *models.JobResult) error {
+	r, err := s.db.Collection("job_results").ReplaceOne(ctx, bson.M{"job_id": result.JobID, "uid": result.UID}, result)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) JobDeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	cursor, err := s.db.Collection("namespaces").Find(ctx, bson.M{"settings.job_retention": bson.M{"$gt": 0}})
+	if err != nil {
+		return 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	var deleted int64
+	for cursor.Next(ctx) {
+		namespace := new(models.Namespace)
+		if err := cursor.Decode(namespace); err != nil {
+			return deleted, FromMongoError(err)
+		}
+
+		filter := bson.M{
+			"tenant_id":   namespace.TenantID,
+			"finished_at": bson.M{"$lt": now.AddDate(0, 0, -namespace.Settings.JobRetention)},
+		}
+
+		ids, err := s.db.Collection("jobs").Distinct(ctx, "_id", filter)
+		if err != nil {
+			return deleted, FromMongoError(err)
+		}
+
+		if len(ids) == 0 {
+			continue
+		}
+
+		if _, err := s.db.Collection("job_results").DeleteMany(ctx, bson.M{"job_id": bson.M{"$in": ids}}); err != nil {
+			return deleted, FromMongoError(err)
+		}
+
+		r, err := s.db.Collection("jobs").DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+		if err != nil {
+			return deleted, FromMongoError(err)
+		}
+
+		deleted += r.DeletedCount
+	}
+
+	return deleted, FromMongoError(cursor.Err())
+}
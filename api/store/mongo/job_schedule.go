@@ -0,0 +1,130 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) JobScheduleCreate(ctx context.Context, schedule *models.JobSchedule) error {
+	_, err := s.db.Collection("job_schedules").InsertOne(ctx, schedule)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) JobScheduleList(ctx context.Context, tenant string, paginator query.Paginator) ([]models.JobSchedule, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenant},
+		},
+	}
+
+	count, err := AggregateCount(ctx, s.db.Collection("job_schedules"), append(query, bson.M{"$count": "count"}))
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, bson.M{"$sort": bson.M{"created_at": -1}})
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	cursor, err := s.db.Collection("job_schedules").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	schedules := make([]models.JobSchedule, 0)
+	if err := cursor.All(ctx, &schedules); err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	return schedules, count, nil
+}
+
+func (s *Store) JobScheduleListEnabled(ctx context.Context) ([]models.JobSchedule, error) {
+	cursor, err := s.db.Collection("job_schedules").Find(ctx, bson.M{"enabled": true})
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	schedules := make([]models.JobSchedule, 0)
+	if err := cursor.All(ctx, &schedules); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return schedules, nil
+}
+
+func (s *Store) JobScheduleGet(ctx context.Context, id string) (*models.JobSchedule, error) {
+	schedule := new(models.JobSchedule)
+	if err := s.db.Collection("job_schedules").FindOne(ctx, bson.M{"_id": id}).Decode(schedule); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return schedule, nil
+}
+
+func (s *Store) JobScheduleUpdate(ctx context.Context, id string, changes *models.JobScheduleChanges) error {
+	r, err := s.db.Collection("job_schedules").UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": changes})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) JobScheduleRun(ctx context.Context, id string, at time.Time, missed []string) error {
+	if missed == nil {
+		missed = []string{}
+	}
+
+	// NOTICE: Every API instance schedules the job schedules, so only the first one to record the run executes it.
+	filter := bson.M{
+		"_id":     id,
+		"enabled": true,
+		"$or": []bson.M{
+			{"last_run_at": bson.M{"$exists": false}},
+			{"last_run_at": bson.M{"$lt": at}},
+		},
+	}
+
+	r, err := s.db.Collection("job_schedules").UpdateOne(ctx, filter, bson.M{"$set": bson.M{"last_run_at": at, "missed": missed}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) JobScheduleRemoveMissed(ctx context.Context, id string, uids []string) error {
+	_, err := s.db.Collection("job_schedules").UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$pull": bson.M{"missed": bson.M{"$in": uids}}})
+
+	return FromMongoError(err)
+}
+
+func (s *Store) JobScheduleDelete(ctx context.Context, id string) error {
+	r, err := s.db.Collection("job_schedules").DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if r.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
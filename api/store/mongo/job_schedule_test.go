@@ -0,0 +1,103 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJobSchedule(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+
+	schedule := &models.JobSchedule{
+		ID:             "schedule",
+		TenantID:       "00000000-0000-4000-0000-000000000000",
+		Name:           "cleanup",
+		Cron:           "@daily",
+		Command:        "rm -rf /tmp/cache",
+		Selector:       models.JobSelector{Tags: []string{"edge"}},
+		User:           "root",
+		Fingerprint:    "fingerprint",
+		Concurrency:    10,
+		Timeout:        60,
+		Enabled:        true,
+		RunOnReconnect: true,
+		Missed:         []string{},
+		CreatedAt:      time.Now(),
+	}
+
+	t.Run("creates the job schedule", func(t *testing.T) {
+		require.NoError(t, mongostore.JobScheduleCreate(ctx, schedule))
+
+		created, err := mongostore.JobScheduleGet(ctx, "schedule")
+		require.NoError(t, err)
+		assert.Equal(t, schedule.Cron, created.Cron)
+
+		schedules, count, err := mongostore.JobScheduleList(ctx, schedule.TenantID, query.Paginator{Page: 1, PerPage: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Len(t, schedules, 1)
+
+		enabled, err := mongostore.JobScheduleListEnabled(ctx)
+		require.NoError(t, err)
+		assert.Len(t, enabled, 1)
+	})
+
+	t.Run("records each scheduled time once", func(t *testing.T) {
+		at := time.Now().UTC().Truncate(time.Minute)
+
+		require.NoError(t, mongostore.JobScheduleRun(ctx, "schedule", at, []string{"device-1", "device-2"}))
+		assert.ErrorIs(t, mongostore.JobScheduleRun(ctx, "schedule", at, nil), store.ErrNoDocuments)
+
+		updated, err := mongostore.JobScheduleGet(ctx, "schedule")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"device-1", "device-2"}, updated.Missed)
+		assert.Equal(t, at, updated.LastRunAt.UTC())
+	})
+
+	t.Run("removes the reconnected devices", func(t *testing.T) {
+		require.NoError(t, mongostore.JobScheduleRemoveMissed(ctx, "schedule", []string{"device-1"}))
+
+		updated, err := mongostore.JobScheduleGet(ctx, "schedule")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"device-2"}, updated.Missed)
+	})
+
+	t.Run("disables the job schedule", func(t *testing.T) {
+		disabled := false
+		require.NoError(t, mongostore.JobScheduleUpdate(ctx, "schedule", &models.JobScheduleChanges{Enabled: &disabled}))
+
+		updated, err := mongostore.JobScheduleGet(ctx, "schedule")
+		require.NoError(t, err)
+		assert.False(t, updated.Enabled)
+		assert.Equal(t, "@daily", updated.Cron)
+
+		enabled, err := mongostore.JobScheduleListEnabled(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, enabled)
+
+		assert.ErrorIs(t, mongostore.JobScheduleRun(ctx, "schedule", time.Now().Add(time.Hour), nil), store.ErrNoDocuments)
+	})
+
+	t.Run("deletes the job schedule", func(t *testing.T) {
+		require.NoError(t, mongostore.JobScheduleDelete(ctx, "schedule"))
+
+		_, err := mongostore.JobScheduleGet(ctx, "schedule")
+		assert.ErrorIs(t, err, store.ErrNoDocuments)
+
+		assert.ErrorIs(t, mongostore.JobScheduleDelete(ctx, "schedule"), store.ErrNoDocuments)
+	})
+}
@@ -0,0 +1,115 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJob(t *testing.T) {
+	ctx := context.TODO()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	job := &models.Job{
+		ID:          "job",
+		TenantID:    "00000000-0000-4000-0000-000000000000",
+		Command:     "hostname",
+		User:        "root",
+		Fingerprint: "fingerprint",
+		Concurrency: 1,
+		Timeout:     60,
+		Devices:     []string{"device"},
+		Status:      models.JobStatusPending,
+		CreatedAt:   time.Now(),
+	}
+
+	results := []models.JobResult{
+		{JobID: "job", UID: "device", Name: "device", Status: models.JobResultStatusPending},
+	}
+
+	t.Run("creates the job with its results", func(t *testing.T) {
+		require.NoError(t, mongostore.JobCreate(ctx, job, results))
+
+		created, err := mongostore.JobGet(ctx, "job")
+		require.NoError(t, err)
+		assert.Equal(t, job.Command, created.Command)
+		assert.Equal(t, models.JobStatusPending, created.Status)
+
+		jobs, count, err := mongostore.JobList(ctx, job.TenantID, "", query.Paginator{Page: 1, PerPage: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Len(t, jobs, 1)
+
+		list, err := mongostore.JobResultList(ctx, "job")
+		require.NoError(t, err)
+		assert.Equal(t, results, list)
+	})
+
+	t.Run("updates the job's status only from the expected ones", func(t *testing.T) {
+		assert.Equal(t, store.ErrNoDocuments, mongostore.JobUpdateStatus(ctx, "job", models.JobStatusFinished, models.JobStatusRunning))
+		require.NoError(t, mongostore.JobUpdateStatus(ctx, "job", models.JobStatusRunning, models.JobStatusPending))
+
+		updated, err := mongostore.JobGet(ctx, "job")
+		require.NoError(t, err)
+		assert.Equal(t, models.JobStatusRunning, updated.Status)
+		assert.NotNil(t, updated.StartedAt)
+	})
+
+	t.Run("lists the running jobs", func(t *testing.T) {
+		jobs, err := mongostore.JobListRunning(ctx)
+		require.NoError(t, err)
+		require.Len(t, jobs, 1)
+		assert.Equal(t, "job", jobs[0].ID)
+	})
+
+	t.Run("updates the result of the job's device", func(t *testing.T) {
+		code := 0
+		result := models.JobResult{JobID: "job", UID: "device", Name: "device", Status: models.JobResultStatusSucceeded, ExitCode: &code, Stdout: "device\n"}
+		require.NoError(t, mongostore.JobResultUpdate(ctx, &result))
+
+		list, err := mongostore.JobResultList(ctx, "job")
+		require.NoError(t, err)
+		assert.Equal(t, []models.JobResult{result}, list)
+
+		assert.Equal(t, store.ErrNoDocuments, mongostore.JobResultUpdate(ctx, &models.JobResult{JobID: "job", UID: "unknown"}))
+	})
+
+	t.Run("deletes the jobs finished before the namespace's retention", func(t *testing.T) {
+		assert.NoError(t, fixtures.Apply(fixtures.FixtureNamespaces))
+		defer fixtures.Teardown() // nolint: errcheck
+
+		require.NoError(t, mongostore.JobUpdateStatus(ctx, "job", models.JobStatusFinished))
+
+		deleted, err := mongostore.JobDeleteExpired(ctx, time.Now().AddDate(0, 0, 10))
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), deleted)
+
+		retention := 7
+		require.NoError(t, mongostore.NamespaceEdit(ctx, job.TenantID, &models.NamespaceChanges{JobRetention: &retention}))
+
+		deleted, err = mongostore.JobDeleteExpired(ctx, time.Now().AddDate(0, 0, 10))
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), deleted)
+
+		_, err = mongostore.JobGet(ctx, "job")
+		assert.Equal(t, store.ErrNoDocuments, err)
+
+		list, err := mongostore.JobResultList(ctx, "job")
+		require.NoError(t, err)
+		assert.Empty(t, list)
+	})
+}
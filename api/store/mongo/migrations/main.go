@@ -74,6 +74,10 @@ func GenerateMigrations() []migrate.Migration {
 		migration62,
 		migration63,
 		migration64,
+		migration65,
+		migration66,
+		migration67,
+		migration68,
 	}
 }
 
@@ -0,0 +1,57 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var migration65 = migrate.Migration{
+	Version:     65,
+	Description: "Adding the 'settings.agent_forwarding' attribute to the namespace if it does not already exist.",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   65,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		filter := bson.M{
+			"settings.agent_forwarding": bson.M{"$exists": false},
+		}
+
+		update := bson.M{
+			"$set": bson.M{
+				"settings.agent_forwarding": true,
+			},
+		}
+
+		_, err := db.
+			Collection("namespaces").
+			UpdateMany(ctx, filter, update)
+
+		return err
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   65,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		update := bson.M{
+			"$unset": bson.M{
+				"settings.agent_forwarding": "",
+			},
+		}
+
+		_, err := db.
+			Collection("namespaces").
+			UpdateMany(ctx, bson.M{}, update)
+
+		return err
+	}),
+}
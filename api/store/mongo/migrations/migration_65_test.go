@@ -0,0 +1,120 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envMocks "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration65(t *testing.T) {
+	logrus.Info("Testing Migration 65")
+
+	ctx := context.Background()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mock := &envMocks.Backend{}
+	envs.DefaultBackend = mock
+
+	type namespace struct {
+		Settings map[string]interface{} `bson:"settings"`
+	}
+
+	cases := []struct {
+		description string
+		setup       func() error
+		test        func() error
+	}{
+		{
+			description: "Success to apply up on migration 65",
+			setup: func() error {
+				_, err := db.
+					Client().
+					Database("test").
+					Collection("namespaces").
+					InsertOne(ctx, bson.M{
+						"tenant_id": "00000000-0000-4000-0000-000000000000",
+						"settings":  bson.M{"session_record": true},
+					})
+
+				return err
+			},
+			test: func() error {
+				migrations := GenerateMigrations()[64:65]
+				migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+				err := migrates.Up(context.Background(), migrate.AllAvailable)
+				if err != nil {
+					return err
+				}
+
+				query := db.
+					Client().
+					Database("test").
+					Collection("namespaces").
+					FindOne(context.TODO(), bson.M{"tenant_id": "00000000-0000-4000-0000-000000000000"})
+
+				ns := new(namespace)
+				if err := query.Decode(ns); err != nil {
+					return errors.New("unable to find the namespace")
+				}
+
+				if ns.Settings["agent_forwarding"] != true {
+					return errors.New("unable to apply the migration")
+				}
+
+				return nil
+			},
+		},
+		{
+			description: "Success to apply down on migration 65",
+			setup: func() error {
+				return nil
+			},
+			test: func() error {
+				migrations := GenerateMigrations()[64:65]
+				migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+				err := migrates.Down(context.Background(), migrate.AllAvailable)
+				if err != nil {
+					return err
+				}
+
+				query := db.
+					Client().
+					Database("test").
+					Collection("namespaces").
+					FindOne(context.TODO(), bson.M{"tenant_id": "00000000-0000-4000-0000-000000000000"})
+
+				ns := new(namespace)
+				if err := query.Decode(ns); err != nil {
+					return errors.New("unable to find the namespace")
+				}
+
+				if _, ok := ns.Settings["agent_forwarding"]; ok {
+					return errors.New("unable to revert the migration")
+				}
+
+				return nil
+			},
+		},
+	}
+
+	for _, test := range cases {
+		tc := test
+		t.Run(tc.description, func(t *testing.T) {
+			err := tc.setup()
+			assert.NoError(t, err)
+
+			err = tc.test()
+			assert.NoError(t, err)
+		})
+	}
+}
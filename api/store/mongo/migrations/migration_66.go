@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration66 = migrate.Migration{
+	Version:     66,
+	Description: "create indexes for tenant_id on jobs and for job_id and uid on job_results",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   66,
+			"action":    "Up",
+		}).Info("Applying migration up")
+
+		if _, err := db.Collection("jobs").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "tenant_id", Value: 1},
+				{Key: "created_at", Value: -1},
+			},
+			Options: options.Index().SetName("tenant_id"),
+		}); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("job_results").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "job_id", Value: 1},
+				{Key: "uid", Value: 1},
+			},
+			Options: options.Index().SetName("job_id").SetUnique(true),
+		}); err != nil {
+			return err
+		}
+
+		return nil
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		log.WithFields(log.Fields{
+			"component": "migration",
+			"version":   66,
+			"action":    "Down",
+		}).Info("Applying migration down")
+
+		if _, err := db.Collection("jobs").Indexes().DropOne(ctx, "tenant_id"); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("job_results").Indexes().DropOne(ctx, "job_id"); err != nil {
+			return err
+		}
+
+		return nil
+	}),
+}
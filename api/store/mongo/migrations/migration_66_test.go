@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envMocks "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration66(t *testing.T) {
+	logrus.Info("Testing Migration 66")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mock := &envMocks.Backend{}
+	envs.DefaultBackend = mock
+
+	indexed := func(t *testing.T, collection, name string) bool {
+		cursor, err := db.Client().Database("test").Collection(collection).Indexes().List(context.Background())
+		require.NoError(t, err)
+
+		for cursor.Next(context.Background()) {
+			var index bson.M
+			require.NoError(t, cursor.Decode(&index))
+
+			if index["name"] == name {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	migrations := GenerateMigrations()[65:66]
+	migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+
+	t.Run("Success to apply up on migration 66", func(t *testing.T) {
+		assert.NoError(t, migrates.Up(context.Background(), migrate.AllAvailable))
+
+		assert.True(t, indexed(t, "jobs", "tenant_id"))
+		assert.True(t, indexed(t, "job_results", "job_id"))
+	})
+
+	t.Run("Success to apply down on migration 66", func(t *testing.T) {
+		assert.NoError(t, migrates.Down(context.Background(), migrate.AllAvailable))
+
+		assert.False(t, indexed(t, "jobs", "tenant_id"))
+		assert.False(t, indexed(t, "job_results", "job_id"))
+	})
+}
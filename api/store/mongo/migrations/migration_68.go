@@ -0,0 +1,71 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration68 = migrate.Migration{
+	Version:     68,
+	Description: "Setting the namespace's owner as the 'created_by' of the public keys created without it.",
+	Up: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   68,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		cursor, err := db.
+			Collection("namespaces").
+			Find(ctx, bson.M{}, options.Find().SetProjection(bson.M{"tenant_id": 1, "owner": 1}))
+		if err != nil {
+			return err
+		}
+
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			namespace := new(struct {
+				TenantID string `bson:"tenant_id"`
+				Owner    string `bson:"owner"`
+			})
+
+			if err := cursor.Decode(namespace); err != nil {
+				return err
+			}
+
+			filter := bson.M{
+				"tenant_id":  namespace.TenantID,
+				"created_by": bson.M{"$exists": false},
+			}
+
+			update := bson.M{
+				"$set": bson.M{
+					"created_by": namespace.Owner,
+				},
+			}
+
+			if _, err := db.Collection("public_keys").UpdateMany(ctx, filter, update); err != nil {
+				return err
+			}
+		}
+
+		return cursor.Err()
+	}),
+	Down: migrate.MigrationFunc(func(ctx context.Context, db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   68,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		// NOTICE: The public keys whose creator was set by this migration cannot be told apart from the ones created
+		// by the namespace's owner, so they are kept.
+		return nil
+	}),
+}
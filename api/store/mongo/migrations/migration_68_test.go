@@ -0,0 +1,62 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	envMocks "github.com/shellhub-io/shellhub/pkg/envs/mocks"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration68(t *testing.T) {
+	logrus.Info("Testing Migration 68")
+
+	ctx := context.Background()
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mock := &envMocks.Backend{}
+	envs.DefaultBackend = mock
+
+	_, err := db.Client().Database("test").Collection("namespaces").InsertOne(ctx, bson.M{
+		"tenant_id": "00000000-0000-4000-0000-000000000000",
+		"owner":     "507f1f77bcf86cd799439011",
+	})
+	require.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("public_keys").InsertMany(ctx, []interface{}{
+		bson.M{"fingerprint": "old", "tenant_id": "00000000-0000-4000-0000-000000000000"},
+		bson.M{"fingerprint": "new", "tenant_id": "00000000-0000-4000-0000-000000000000", "created_by": "507f191e810c19729de860ea"},
+	})
+	require.NoError(t, err)
+
+	creator := func(t *testing.T, fingerprint string) interface{} {
+		key := make(bson.M)
+		require.NoError(t, db.Client().Database("test").Collection("public_keys").FindOne(ctx, bson.M{"fingerprint": fingerprint}).Decode(&key))
+
+		return key["created_by"]
+	}
+
+	migrations := GenerateMigrations()[67:68]
+	migrates := migrate.NewMigrate(db.Client().Database("test"), migrations...)
+
+	t.Run("Success to apply up on migration 68", func(t *testing.T) {
+		assert.NoError(t, migrates.Up(ctx, migrate.AllAvailable))
+
+		assert.Equal(t, "507f1f77bcf86cd799439011", creator(t, "old"))
+		assert.Equal(t, "507f191e810c19729de860ea", creator(t, "new"))
+	})
+
+	t.Run("Success to apply down on migration 68", func(t *testing.T) {
+		assert.NoError(t, migrates.Down(ctx, migrate.AllAvailable))
+
+		assert.Equal(t, "507f1f77bcf86cd799439011", creator(t, "old"))
+	})
+}
@@ -255,6 +255,20 @@ func (s *Store) NamespaceEdit(ctx context.Context, tenant string, changes *model
 	return nil
 }
 
+func (s *Store) NamespaceSetCA(ctx context.Context, tenant string, ca *models.NamespaceCA) error {
+	if _, err := s.db.
+		Collection("namespaces").
+		UpdateOne(ctx, bson.M{"tenant_id": tenant, "ca": bson.M{"$exists": false}}, bson.M{"$set": bson.M{"ca": ca}}); err != nil {
+		return FromMongoError(err)
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenant}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
 func (s *Store) NamespaceUpdate(ctx context.Context, tenantID string, namespace *models.Namespace) error {
 	ns, err := s.db.Collection("namespaces").UpdateOne(
 		ctx,
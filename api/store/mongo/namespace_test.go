@@ -445,6 +445,52 @@ func TestNamespaceEdit(t *testing.T) {
 	}
 }
 
+func TestNamespaceSetCA(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		cas         []*models.NamespaceCA
+		expected    *models.NamespaceCA
+	}{
+		{
+			description: "succeeds setting the certificate authority",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			cas:         []*models.NamespaceCA{{PublicKey: "first", PrivateKey: []byte("first")}},
+			expected:    &models.NamespaceCA{PublicKey: "first", PrivateKey: []byte("first")},
+		},
+		{
+			description: "succeeds keeping the certificate authority already set",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			cas: []*models.NamespaceCA{
+				{PublicKey: "first", PrivateKey: []byte("first")},
+				{PublicKey: "second", PrivateKey: []byte("second")},
+			},
+			expected: &models.NamespaceCA{PublicKey: "first", PrivateKey: []byte("first")},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(fixtures.FixtureNamespaces))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			for _, ca := range tc.cas {
+				assert.NoError(t, mongostore.NamespaceSetCA(context.TODO(), tc.tenant, ca))
+			}
+
+			namespace, err := mongostore.NamespaceGet(context.TODO(), tc.tenant)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, namespace.CA)
+		})
+	}
+}
+
 func TestNamespaceUpdate(t *testing.T) {
 	cases := []struct {
 		description string
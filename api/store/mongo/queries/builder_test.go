@@ -2,6 +2,7 @@ package queries
 
 import (
 	"testing"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"github.com/stretchr/testify/assert"
@@ -181,6 +182,44 @@ func TestFromFilters(t *testing.T) {
 				err:  nil,
 			},
 		},
+		{
+			description: "Success when operator in property is lt with a number",
+			filters: &query.Filters{
+				Data: []query.Filter{
+					{
+						Type: "property",
+						Params: &query.FilterProperty{
+							Name:     "test",
+							Operator: "lt",
+							Value:    "10",
+						},
+					},
+				},
+			},
+			expected: Expected{
+				data: []bson.M{{"$match": bson.M{"$or": []bson.M{{"test": bson.M{"$lt": 10}}}}}},
+				err:  nil,
+			},
+		},
+		{
+			description: "Success when operator in property is gt with a date",
+			filters: &query.Filters{
+				Data: []query.Filter{
+					{
+						Type: "property",
+						Params: &query.FilterProperty{
+							Name:     "started_at",
+							Operator: "gt",
+							Value:    "2023-01-01T12:00:00Z",
+						},
+					},
+				},
+			},
+			expected: Expected{
+				data: []bson.M{{"$match": bson.M{"$or": []bson.M{{"started_at": bson.M{"$gt": time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)}}}}}},
+				err:  nil,
+			},
+		},
 		{
 			description: "Fail when operator in operator is invalid",
 			filters: &query.Filters{
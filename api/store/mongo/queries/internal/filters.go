@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/api/query"
 	"go.mongodb.org/mongo-driver/bson"
@@ -42,6 +43,9 @@ func ParseFilterProperty(fp *query.FilterProperty) (bson.M, bool, error) {
 	case "gt":
 		res, err = fromGt(fp.Value)
 		ok = true
+	case "lt":
+		res, err = fromLt(fp.Value)
+		ok = true
 	default:
 		return nil, false, nil
 	}
@@ -84,16 +88,40 @@ func fromBool(value interface{}) (bson.M, error) {
 
 // fromGt converts a "gt" JSON expression to a Bson expression using "$gt".
 func fromGt(value interface{}) (bson.M, error) {
-	switch v := value.(type) {
-	case int:
-		value = v
-	case string:
-		var err error
-		value, err = strconv.Atoi(v)
-		if err != nil {
-			return nil, err
-		}
+	value, err := fromComparable(value)
+	if err != nil {
+		return nil, err
 	}
 
 	return bson.M{"$gt": value}, nil
 }
+
+// fromLt converts a "lt" JSON expression to a Bson expression using "$lt".
+func fromLt(value interface{}) (bson.M, error) {
+	value, err := fromComparable(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.M{"$lt": value}, nil
+}
+
+// fromComparable converts the value of a comparison expression, like "gt" and "lt", to a value comparable by MongoDB.
+// Strings are parsed as integers or, when they are not, as RFC 3339 dates.
+func fromComparable(value interface{}) (interface{}, error) {
+	v, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	if integer, err := strconv.Atoi(v); err == nil {
+		return integer, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, err
+	}
+
+	return date, nil
+}
@@ -15,7 +15,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-func (s *Store) SessionList(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error) {
+func (s *Store) SessionList(ctx context.Context, paginator query.Paginator, filters query.Filters, sorter query.Sorter) ([]models.Session, int, error) {
 	query := []bson.M{
 		{
 			"$match": bson.M{
@@ -35,21 +35,10 @@ func (s *Store) SessionList(ctx context.Context, paginator query.Paginator) ([]m
 		})
 	}
 
-	queryCount := query
-	queryCount = append(queryCount, bson.M{"$count": "count"})
-	count, err := AggregateCount(ctx, s.db.Collection("sessions"), queryCount)
-	if err != nil {
-		return nil, 0, FromMongoError(err)
-	}
-
-	query = append(query, bson.M{
-		"$sort": bson.M{
-			"started_at": -1,
-		},
-	})
-
-	query = append(query, queries.FromPaginator(&paginator)...)
-	query = append(query, []bson.M{
+	// NOTICE: The "active" attribute is computed from the "active_sessions" collection. Joining it on every session is
+	// expensive, so it's only computed before the filters when they use it. Otherwise, it's computed only for the page's
+	// sessions.
+	active := []bson.M{
 		{
 			"$lookup": bson.M{
 				"from":         "active_sessions",
@@ -63,7 +52,36 @@ func (s *Store) SessionList(ctx context.Context, paginator query.Paginator) ([]m
 				"active": bson.M{"$anyElementTrue": []interface{}{"$active"}},
 			},
 		},
-	}...)
+	}
+
+	byActive := sessionFiltersActive(&filters)
+	if byActive {
+		query = append(query, active...)
+	}
+
+	queryMatch, err := queries.FromFilters(&filters)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	query = append(query, queryMatch...)
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("sessions"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	if sorter.By == "" {
+		sorter.By = "started_at"
+	}
+
+	query = append(query, queries.FromSorter(&sorter)...)
+	query = append(query, queries.FromPaginator(&paginator)...)
+
+	if !byActive {
+		query = append(query, active...)
+	}
 
 	sessions := make([]models.Session, 0)
 	cursor, err := s.db.Collection("sessions").Aggregate(ctx, query)
@@ -91,6 +109,17 @@ func (s *Store) SessionList(ctx context.Context, paginator query.Paginator) ([]m
 	return sessions, count, err
 }
 
+// sessionFiltersActive checks if any of the filters is applied to the session's "active" attribute.
+func sessionFiltersActive(filters *query.Filters) bool {
+	for _, filter := range filters.Data {
+		if property, ok := filter.Params.(*query.FilterProperty); ok && property.Name == "active" {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (s *Store) SessionGet(ctx context.Context, uid models.UID) (*models.Session, error) {
 	query := []bson.M{
 		{
@@ -176,6 +205,32 @@ func (s *Store) SessionSetRecorded(ctx context.Context, uid models.UID, recorded
 	return nil
 }
 
+func (s *Store) SessionSetDisconnectReason(ctx context.Context, uid models.UID, reason string) error {
+	session, err := s.db.Collection("sessions").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"disconnect_reason": reason}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if session.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) SessionAddEvent(ctx context.Context, uid models.UID, event *models.SessionEvent) error {
+	session, err := s.db.Collection("sessions").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$push": bson.M{"events": event}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if session.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
 func (s *Store) SessionCreate(ctx context.Context, session models.Session) (*models.Session, error) {
 	session.StartedAt = clock.Now()
 	session.LastSeen = session.StartedAt
@@ -210,7 +210,7 @@ func TestSessionList(t *testing.T) {
 			assert.NoError(t, fixtures.Apply(tc.fixtures...))
 			defer fixtures.Teardown() // nolint: errcheck
 
-			s, count, err := mongostore.SessionList(context.TODO(), tc.paginator)
+			s, count, err := mongostore.SessionList(context.TODO(), tc.paginator, query.Filters{}, query.Sorter{})
 			sort(tc.expected.s)
 			sort(s)
 			assert.Equal(t, tc.expected, Expected{s: s, count: count, err: err})
@@ -477,6 +477,96 @@ func TestSessionSetRecorded(t *testing.T) {
 	}
 }
 
+func TestSessionSetDisconnectReason(t *testing.T) {
+	cases := []struct {
+		description string
+		UID         models.UID
+		reason      string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when session is not found",
+			UID:         models.UID("nonexistent"),
+			reason:      models.SessionDisconnectReasonIdleTimeout,
+			fixtures:    []string{fixtures.FixtureSessions},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when session is found",
+			UID:         models.UID("a3b0431f5df6a7827945d2e34872a5c781452bc36de42f8b1297fd9ecb012f68"),
+			reason:      models.SessionDisconnectReasonIdleTimeout,
+			fixtures:    []string{fixtures.FixtureSessions},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.SessionSetDisconnectReason(context.TODO(), tc.UID, tc.reason)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestSessionAddEvent(t *testing.T) {
+	cases := []struct {
+		description string
+		UID         models.UID
+		event       *models.SessionEvent
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when session is not found",
+			UID:         models.UID("nonexistent"),
+			event: &models.SessionEvent{
+				Type:      models.SessionEventTypePortForwardingDenied,
+				Timestamp: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+				Data:      map[string]string{"host": "localhost", "port": "80"},
+			},
+			fixtures: []string{fixtures.FixtureSessions},
+			expected: store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when session is found",
+			UID:         models.UID("a3b0431f5df6a7827945d2e34872a5c781452bc36de42f8b1297fd9ecb012f68"),
+			event: &models.SessionEvent{
+				Type:      models.SessionEventTypePortForwardingDenied,
+				Timestamp: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+				Data:      map[string]string{"host": "localhost", "port": "80"},
+			},
+			fixtures: []string{fixtures.FixtureSessions},
+			expected: nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.SessionAddEvent(context.TODO(), tc.UID, tc.event)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
 func TestSessionSetLastSeen(t *testing.T) {
 	cases := []struct {
 		description string
@@ -751,3 +841,39 @@ func TestSessionDeleteRecordFrameByDate(t *testing.T) {
 		})
 	}
 }
+
+func TestSessionFiltersActive(t *testing.T) {
+	cases := []struct {
+		description string
+		filters     query.Filters
+		expected    bool
+	}{
+		{
+			description: "returns false without filters",
+			filters:     query.Filters{},
+			expected:    false,
+		},
+		{
+			description: "returns false when the filters don't use the active attribute",
+			filters: query.Filters{Data: []query.Filter{
+				{Type: query.FilterTypeProperty, Params: &query.FilterProperty{Name: "username", Operator: "eq", Value: "john_doe"}},
+			}},
+			expected: false,
+		},
+		{
+			description: "returns true when a filter uses the active attribute",
+			filters: query.Filters{Data: []query.Filter{
+				{Type: query.FilterTypeProperty, Params: &query.FilterProperty{Name: "username", Operator: "eq", Value: "john_doe"}},
+				{Type: query.FilterTypeOperator, Params: &query.FilterOperator{Name: "and"}},
+				{Type: query.FilterTypeProperty, Params: &query.FilterProperty{Name: "active", Operator: "eq", Value: true}},
+			}},
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, sessionFiltersActive(&tc.filters))
+		})
+	}
+}
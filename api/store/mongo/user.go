@@ -215,6 +215,24 @@ func (s *Store) UserUpdatePassword(ctx context.Context, newPassword string, id s
 	return nil
 }
 
+func (s *Store) UserUpdatePreferences(ctx context.Context, id string, preferences models.UserPreferences) error {
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	user, err := s.db.Collection("users").UpdateOne(ctx, bson.M{"_id": objID}, bson.M{"$set": bson.M{"preferences": preferences}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if user.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
 // UserUpdateAccountStatus sets the 'confirmed' attribute of a user to true.
 func (s *Store) UserUpdateAccountStatus(ctx context.Context, id string) error {
 	objID, err := primitive.ObjectIDFromHex(id)
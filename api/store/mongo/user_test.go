@@ -543,6 +543,54 @@ func TestUserUpdatePassword(t *testing.T) {
 	}
 }
 
+func TestUserUpdatePreferences(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		preferences models.UserPreferences
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when user id is not valid",
+			id:          "invalid",
+			preferences: models.UserPreferences{DefaultNamespace: "00000000-0000-4000-0000-000000000000"},
+			fixtures:    []string{fixtures.FixtureUsers},
+			expected:    store.ErrInvalidHex,
+		},
+		{
+			description: "fails when user is not found",
+			id:          "000000000000000000000000",
+			preferences: models.UserPreferences{DefaultNamespace: "00000000-0000-4000-0000-000000000000"},
+			fixtures:    []string{fixtures.FixtureUsers},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when user is found",
+			id:          "507f1f77bcf86cd799439011",
+			preferences: models.UserPreferences{DefaultNamespace: "00000000-0000-4000-0000-000000000000"},
+			fixtures:    []string{fixtures.FixtureUsers},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.UserUpdatePreferences(context.TODO(), tc.id, tc.preferences)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
 func TestUserUpdateAccountStatus(t *testing.T) {
 	cases := []struct {
 		description string
@@ -17,6 +17,10 @@ type NamespaceStore interface {
 	// It returns an error, if any, or store.ErrNoDocuments if the namespace does not exist.
 	NamespaceEdit(ctx context.Context, tenant string, changes *models.NamespaceChanges) error
 
+	// NamespaceSetCA sets the certificate authority of the namespace with the specified tenant, only when it has none,
+	// keeping the one set by a concurrent call. It returns an error, if any.
+	NamespaceSetCA(ctx context.Context, tenant string, ca *models.NamespaceCA) error
+
 	NamespaceUpdate(ctx context.Context, tenantID string, namespace *models.Namespace) error
 	NamespaceDelete(ctx context.Context, tenantID string) error
 	NamespaceAddMember(ctx context.Context, tenantID string, memberID string, memberRole string) (*models.Namespace, error)
@@ -9,7 +9,7 @@ import (
 )
 
 type SessionStore interface {
-	SessionList(ctx context.Context, paginator query.Paginator) ([]models.Session, int, error)
+	SessionList(ctx context.Context, paginator query.Paginator, filters query.Filters, sorter query.Sorter) ([]models.Session, int, error)
 	SessionGet(ctx context.Context, uid models.UID) (*models.Session, error)
 	SessionCreate(ctx context.Context, session models.Session) (*models.Session, error)
 	SessionSetAuthenticated(ctx context.Context, uid models.UID, authenticated bool) error
@@ -21,4 +21,6 @@ type SessionStore interface {
 	SessionDeleteRecordFrame(ctx context.Context, uid models.UID) error
 	SessionDeleteRecordFrameByDate(ctx context.Context, lte time.Time) (deletedCount int64, updatedCount int64, err error)
 	SessionSetRecorded(ctx context.Context, uid models.UID, recorded bool) error
+	SessionSetDisconnectReason(ctx context.Context, uid models.UID, reason string) error
+	SessionAddEvent(ctx context.Context, uid models.UID, event *models.SessionEvent) error
 }
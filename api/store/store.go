@@ -18,4 +18,6 @@ type Store interface {
 	StatsStore
 	MFAStore
 	APIKeyStore
+	JobStore
+	JobScheduleStore
 }
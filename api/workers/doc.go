@@ -14,5 +14,16 @@
 // The maximum number of devices to wait for before triggering is defined by the `SHELLHUB_ASYNQ_GROUP_MAX_SIZE` (default is 500).
 // Another triggering mechanism involves a timeout defined in the `SHELLHUB_ASYNQ_GROUP_MAX_DELAY` environment variable.
 //
+// The `jobs` worker executes the jobs' commands on their devices, through the SSH server, limited by each job's
+// concurrency and stopped when the job is cancelled. Jobs finished before the retention of their namespaces are
+// deleted periodically, following the cron expression from `SHELLHUB_JOB_CLEANUP_SCHEDULE` (default is @daily). Jobs
+// still running after their deadlines, what happens when their workers are lost, are failed following the cron
+// expression from `SHELLHUB_JOB_REAP_SCHEDULE` (default is @every 5m).
+//
+// The `jobSchedules` worker creates a job at each scheduled time of the enabled job schedules, what are synchronized
+// with the scheduler every minute. When a job schedule runs on reconnect, the devices offline at the scheduled time
+// execute the command when they are online again, checked every minute too. Before each run, a job schedule whose
+// creator left the namespace, lost the permission to create jobs or removed its public key is disabled instead.
+//
 // The patterns of tasks used by the handlers are available as constants with the "Task" prefix.
 package workers
@@ -0,0 +1,367 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// jobScheduleSyncInterval is the interval between the synchronizations of the enabled job schedules with the
+	// scheduler.
+	jobScheduleSyncInterval = time.Minute
+	// jobScheduleCatchUpSchedule is the cron expression of the checks for reconnected devices that missed a run.
+	jobScheduleCatchUpSchedule = "@every 1m"
+)
+
+// jobScheduleEntry is a job schedule registered on the scheduler.
+type jobScheduleEntry struct {
+	id   string
+	cron string
+}
+
+// registerJobSchedules registers the worker that creates a job at each scheduled time of a job schedule, and the
+// worker that creates a job for the devices that reconnected after missing the last run of a job schedule. The job
+// schedules are registered on the scheduler by [Workers.syncJobSchedules].
+func (w *Workers) registerJobSchedules() {
+	// NOTICE: A failed run is logged and isn't retried, as the next scheduled time runs the job schedule again.
+	w.mux.HandleFunc(TaskJobScheduleRun, func(ctx context.Context, task *asynq.Task) error {
+		w.runJobSchedule(ctx, string(task.Payload())) //nolint:errcheck
+
+		return nil
+	})
+
+	w.mux.HandleFunc(TaskJobScheduleCatchUp, func(ctx context.Context, _ *asynq.Task) error {
+		return w.catchUpJobSchedules(ctx)
+	})
+
+	task := asynq.NewTask(TaskJobScheduleCatchUp, nil, asynq.TaskID(TaskJobScheduleCatchUp), asynq.Queue("api"))
+	if _, err := w.scheduler.Register(jobScheduleCatchUpSchedule, task); err != nil {
+		log.WithFields(log.Fields{"component": "worker", "task": TaskJobScheduleCatchUp}).
+			WithError(err).
+			Error("Failed to register the scheduler.")
+	}
+}
+
+// syncJobSchedules keeps the scheduler synchronized with the enabled job schedules until the context is done. As every
+// API instance schedules them, each scheduled time is run only once by [Workers.runJobSchedule].
+func (w *Workers) syncJobSchedules(ctx context.Context) {
+	ticker := time.NewTicker(jobScheduleSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		w.syncJobScheduleEntries(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// syncJobScheduleEntries registers the enabled job schedules on the scheduler, re-registering the ones whose cron
+// expression changed and unregistering the ones disabled or deleted.
+func (w *Workers) syncJobScheduleEntries(ctx context.Context) {
+	logger := log.WithFields(log.Fields{"component": "worker", "task": TaskJobScheduleRun})
+
+	schedules, err := w.store.JobScheduleListEnabled(ctx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list the enabled job schedules")
+
+		return
+	}
+
+	enabled := make(map[string]bool, len(schedules))
+	for _, schedule := range schedules {
+		enabled[schedule.ID] = true
+
+		entry, ok := w.entries[schedule.ID]
+		if ok && entry.cron == schedule.Cron {
+			continue
+		}
+
+		if ok {
+			w.scheduler.Unregister(entry.id) //nolint:errcheck
+			delete(w.entries, schedule.ID)
+		}
+
+		// NOTICE: The task has no fixed ID, as a failed run would conflict with the next ones while it's kept by the
+		// queue. The scheduled times run by more than one API instance are deduplicated by [Workers.runJobSchedule].
+		task := asynq.NewTask(
+			TaskJobScheduleRun,
+			[]byte(schedule.ID),
+			asynq.Queue("api"),
+			asynq.MaxRetry(0),
+		)
+
+		id, err := w.scheduler.Register(schedule.Cron, task)
+		if err != nil {
+			logger.WithError(err).WithField("schedule", schedule.ID).Warn("Failed to register the job schedule")
+
+			continue
+		}
+
+		w.entries[schedule.ID] = jobScheduleEntry{id: id, cron: schedule.Cron}
+	}
+
+	for schedule, entry := range w.entries {
+		if !enabled[schedule] {
+			w.scheduler.Unregister(entry.id) //nolint:errcheck
+			delete(w.entries, schedule)
+		}
+	}
+}
+
+// runJobSchedule creates the job of the job schedule's scheduled time, for the devices selected at this time. When
+// the job schedule runs on reconnect, the offline devices are left to the reconnection instead.
+func (w *Workers) runJobSchedule(ctx context.Context, id string) error {
+	logger := log.WithFields(log.Fields{"component": "worker", "task": TaskJobScheduleRun, "schedule": id})
+
+	schedule, err := w.store.JobScheduleGet(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			return nil
+		}
+
+		logger.WithError(err).Error("Failed to get the job schedule")
+
+		return err
+	}
+
+	if !schedule.Enabled {
+		return nil
+	}
+
+	if authorized, err := w.authorizeJobSchedule(ctx, schedule); err != nil || !authorized {
+		return err
+	}
+
+	at := clock.Now().Truncate(time.Minute)
+
+	devices, err := w.store.DeviceListBySelector(ctx, schedule.TenantID, &schedule.Selector)
+	if err != nil {
+		logger.WithError(err).Error("Failed to select the job schedule's devices")
+
+		return err
+	}
+
+	missed := make([]string, 0)
+	if schedule.RunOnReconnect {
+		online := make([]models.Device, 0, len(devices))
+		for _, device := range devices {
+			if device.Online {
+				online = append(online, device)
+			} else {
+				missed = append(missed, device.UID)
+			}
+		}
+
+		devices = online
+	}
+
+	if err := w.store.JobScheduleRun(ctx, id, at, missed); err != nil {
+		if errors.Is(err, store.ErrNoDocuments) {
+			logger.WithField("at", at).Debug("Job schedule already run at the scheduled time")
+
+			return nil
+		}
+
+		logger.WithError(err).Error("Failed to record the job schedule's run")
+
+		return err
+	}
+
+	logger.WithFields(log.Fields{"devices": len(devices), "missed": len(missed)}).Info("Job schedule run")
+
+	if len(devices) == 0 {
+		return nil
+	}
+
+	return w.createJobScheduleRun(ctx, schedule, devices)
+}
+
+// catchUpJobSchedules creates a job, for each job schedule that runs on reconnect, to the devices that missed its last
+// run and are online again.
+func (w *Workers) catchUpJobSchedules(ctx context.Context) error {
+	schedules, err := w.store.JobScheduleListEnabled(ctx)
+	if err != nil {
+		log.WithFields(log.Fields{"component": "worker", "task": TaskJobScheduleCatchUp}).
+			WithError(err).
+			Error("Failed to list the enabled job schedules")
+
+		return err
+	}
+
+	for i := range schedules {
+		schedule := &schedules[i]
+		if !schedule.RunOnReconnect || len(schedule.Missed) == 0 {
+			continue
+		}
+
+		logger := log.WithFields(log.Fields{"component": "worker", "task": TaskJobScheduleCatchUp, "schedule": schedule.ID})
+
+		if authorized, err := w.authorizeJobSchedule(ctx, schedule); err != nil || !authorized {
+			continue
+		}
+
+		devices, err := w.store.DeviceListBySelector(ctx, schedule.TenantID, &models.JobSelector{UIDs: schedule.Missed})
+		if err != nil {
+			logger.WithError(err).Warn("Failed to list the devices that missed the job schedule")
+
+			continue
+		}
+
+		reconnected := make([]models.Device, 0, len(devices))
+		uids := make([]string, 0, len(devices))
+		for _, device := range devices {
+			if device.Online {
+				reconnected = append(reconnected, device)
+				uids = append(uids, device.UID)
+			}
+		}
+
+		if len(reconnected) == 0 {
+			continue
+		}
+
+		// NOTICE: The devices are removed before the job is created, so they don't run the missed command twice.
+		if err := w.store.JobScheduleRemoveMissed(ctx, schedule.ID, uids); err != nil {
+			logger.WithError(err).Warn("Failed to remove the reconnected devices from the job schedule")
+
+			continue
+		}
+
+		logger.WithField("devices", len(reconnected)).Info("Job schedule run on the reconnected devices")
+
+		w.createJobScheduleRun(ctx, schedule, reconnected) //nolint:errcheck
+	}
+
+	return nil
+}
+
+// authorizeJobSchedule checks, before each run, that the job schedule can still run the command on behalf of its
+// creator. When it cannot, the job schedule is disabled, so it isn't run again until someone allowed enables it.
+func (w *Workers) authorizeJobSchedule(ctx context.Context, schedule *models.JobSchedule) (bool, error) {
+	logger := log.WithFields(log.Fields{"component": "worker", "schedule": schedule.ID})
+
+	reason, err := w.orphanedJobSchedule(ctx, schedule)
+	if err != nil {
+		logger.WithError(err).Error("Failed to check the job schedule's creator")
+
+		return false, err
+	}
+
+	if reason == "" {
+		return true, nil
+	}
+
+	disabled := false
+	if err := w.store.JobScheduleUpdate(ctx, schedule.ID, &models.JobScheduleChanges{Enabled: &disabled}); err != nil {
+		logger.WithError(err).Error("Failed to disable the orphaned job schedule")
+
+		return false, err
+	}
+
+	logger.WithField("reason", reason).Warn("Orphaned job schedule disabled")
+
+	return false, nil
+}
+
+// orphanedJobSchedule returns why the job schedule cannot run on behalf of its creator anymore, like when the creator
+// left the namespace or its public key was removed, or an empty reason when it can.
+func (w *Workers) orphanedJobSchedule(ctx context.Context, schedule *models.JobSchedule) (string, error) {
+	user, err := w.store.UserGetByUsername(ctx, schedule.CreatedBy)
+	switch {
+	case errors.Is(err, store.ErrNoDocuments):
+		return "the creator doesn't exist", nil
+	case err != nil:
+		return "", err
+	}
+
+	namespace, err := w.store.NamespaceGet(ctx, schedule.TenantID)
+	switch {
+	case errors.Is(err, store.ErrNoDocuments):
+		return "the namespace doesn't exist", nil
+	case err != nil:
+		return "", err
+	}
+
+	member, ok := namespace.FindMember(user.ID)
+	if !ok {
+		return "the creator isn't a member of the namespace", nil
+	}
+
+	if err := guard.EvaluatePermission(member.Role, guard.Actions.Job.Create, func() error { return nil }); err != nil {
+		return "the creator's role cannot create jobs", nil
+	}
+
+	_, err = w.store.PublicKeyGet(ctx, schedule.Fingerprint, schedule.TenantID)
+	switch {
+	case errors.Is(err, store.ErrNoDocuments):
+		return "the public key doesn't exist", nil
+	case err != nil:
+		return "", err
+	}
+
+	return "", nil
+}
+
+// createJobScheduleRun creates the job schedule's job for the devices, enqueueing it to be executed.
+func (w *Workers) createJobScheduleRun(ctx context.Context, schedule *models.JobSchedule, devices []models.Device) error {
+	logger := log.WithFields(log.Fields{"component": "worker", "schedule": schedule.ID})
+
+	job := &models.Job{
+		ID:          uuid.Generate(),
+		TenantID:    schedule.TenantID,
+		Command:     schedule.Command,
+		Selector:    schedule.Selector,
+		User:        schedule.User,
+		Fingerprint: schedule.Fingerprint,
+		SignedData:  schedule.SignedData,
+		Signature:   schedule.Signature,
+		Concurrency: schedule.Concurrency,
+		Timeout:     schedule.Timeout,
+		Devices:     make([]string, len(devices)),
+		ScheduleID:  schedule.ID,
+		Status:      models.JobStatusPending,
+		CreatedBy:   schedule.CreatedBy,
+		CreatedAt:   clock.Now(),
+	}
+
+	results := make([]models.JobResult, len(devices))
+	for i, device := range devices {
+		job.Devices[i] = device.UID
+		results[i] = models.JobResult{
+			JobID:  job.ID,
+			UID:    device.UID,
+			Name:   device.Name,
+			Status: models.JobResultStatusPending,
+		}
+	}
+
+	if err := w.store.JobCreate(ctx, job, results); err != nil {
+		logger.WithError(err).Error("Failed to create the job schedule's job")
+
+		return err
+	}
+
+	if err := w.client.JobEnqueue(job.ID, job.Deadline()); err != nil {
+		// NOTICE: The job is cancelled, so it isn't listed as pending forever.
+		w.store.JobUpdateStatus(ctx, job.ID, models.JobStatusCancelled) //nolint:errcheck
+
+		logger.WithError(err).WithField("job", job.ID).Error("Failed to enqueue the job schedule's job")
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,290 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	storemocks "github.com/shellhub-io/shellhub/api/store/mocks"
+	clientmocks "github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// authorized mocks the checks of the job schedule's creator, who is an administrator of the namespace with the public
+// key.
+func authorized(storeMock *storemocks.Store, schedule *models.JobSchedule) {
+	storeMock.On("UserGetByUsername", mock.Anything, schedule.CreatedBy).
+		Return(&models.User{ID: "user"}, nil).Once()
+	storeMock.On("NamespaceGet", mock.Anything, schedule.TenantID).
+		Return(&models.Namespace{TenantID: schedule.TenantID, Members: []models.Member{{ID: "user", Role: guard.RoleAdministrator}}}, nil).Once()
+	storeMock.On("PublicKeyGet", mock.Anything, schedule.Fingerprint, schedule.TenantID).
+		Return(&models.PublicKey{Fingerprint: schedule.Fingerprint}, nil).Once()
+}
+
+// withDevices matches a job of the job schedule with the devices.
+func withDevices(schedule string, uids ...string) interface{} {
+	return mock.MatchedBy(func(job *models.Job) bool {
+		return job.ScheduleID == schedule && assert.ObjectsAreEqual(uids, job.Devices)
+	})
+}
+
+func TestRunJobSchedule(t *testing.T) {
+	schedule := &models.JobSchedule{
+		ID:          "schedule",
+		TenantID:    "tenant",
+		Cron:        "0 3 * * *",
+		Command:     "rm -rf /tmp/cache",
+		Selector:    models.JobSelector{Tags: []string{"edge"}},
+		User:        "root",
+		Fingerprint: "fingerprint",
+		SignedData:  "data",
+		Signature:   "signature",
+		Concurrency: 10,
+		Timeout:     60,
+		Enabled:     true,
+		CreatedBy:   "john",
+	}
+
+	devices := []models.Device{
+		{UID: "device-1", Name: "device-1", Online: true},
+		{UID: "device-2", Name: "device-2", Online: false},
+	}
+
+	t.Run("executes the command on every selected device", func(t *testing.T) {
+		storeMock := new(storemocks.Store)
+		clientMock := new(clientmocks.Client)
+
+		storeMock.On("JobScheduleGet", mock.Anything, "schedule").Return(schedule, nil).Once()
+		authorized(storeMock, schedule)
+		storeMock.On("DeviceListBySelector", mock.Anything, "tenant", &schedule.Selector).Return(devices, nil).Once()
+		storeMock.On("JobScheduleRun", mock.Anything, "schedule", mock.Anything, []string{}).Return(nil).Once()
+		storeMock.On("JobCreate", mock.Anything, mock.MatchedBy(func(job *models.Job) bool {
+			return job.ScheduleID == "schedule" && job.SignedData == "data" && job.Signature == "signature" && len(job.Devices) == 2
+		}), mock.Anything).Return(nil).Once()
+		clientMock.On("JobEnqueue", mock.Anything, mock.Anything).Return(nil).Once()
+
+		w := &Workers{store: storeMock, client: clientMock}
+		assert.NoError(t, w.runJobSchedule(context.Background(), "schedule"))
+
+		storeMock.AssertExpectations(t)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("leaves the offline devices to the reconnection", func(t *testing.T) {
+		storeMock := new(storemocks.Store)
+		clientMock := new(clientmocks.Client)
+
+		reconnect := *schedule
+		reconnect.RunOnReconnect = true
+
+		storeMock.On("JobScheduleGet", mock.Anything, "schedule").Return(&reconnect, nil).Once()
+		authorized(storeMock, &reconnect)
+		storeMock.On("DeviceListBySelector", mock.Anything, "tenant", &reconnect.Selector).Return(devices, nil).Once()
+		storeMock.On("JobScheduleRun", mock.Anything, "schedule", mock.Anything, []string{"device-2"}).Return(nil).Once()
+		storeMock.On("JobCreate", mock.Anything, withDevices("schedule", "device-1"), mock.Anything).Return(nil).Once()
+		clientMock.On("JobEnqueue", mock.Anything, mock.Anything).Return(nil).Once()
+
+		w := &Workers{store: storeMock, client: clientMock}
+		assert.NoError(t, w.runJobSchedule(context.Background(), "schedule"))
+
+		storeMock.AssertExpectations(t)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("skips the scheduled time already run", func(t *testing.T) {
+		storeMock := new(storemocks.Store)
+		clientMock := new(clientmocks.Client)
+
+		storeMock.On("JobScheduleGet", mock.Anything, "schedule").Return(schedule, nil).Once()
+		authorized(storeMock, schedule)
+		storeMock.On("DeviceListBySelector", mock.Anything, "tenant", &schedule.Selector).Return(devices, nil).Once()
+		storeMock.On("JobScheduleRun", mock.Anything, "schedule", mock.Anything, []string{}).Return(store.ErrNoDocuments).Once()
+
+		w := &Workers{store: storeMock, client: clientMock}
+		assert.NoError(t, w.runJobSchedule(context.Background(), "schedule"))
+
+		storeMock.AssertExpectations(t)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("disables the job schedule whose creator left the namespace", func(t *testing.T) {
+		storeMock := new(storemocks.Store)
+		clientMock := new(clientmocks.Client)
+
+		disabled := false
+
+		storeMock.On("JobScheduleGet", mock.Anything, "schedule").Return(schedule, nil).Once()
+		storeMock.On("UserGetByUsername", mock.Anything, "john").Return(&models.User{ID: "user"}, nil).Once()
+		storeMock.On("NamespaceGet", mock.Anything, "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+		storeMock.On("JobScheduleUpdate", mock.Anything, "schedule", &models.JobScheduleChanges{Enabled: &disabled}).Return(nil).Once()
+
+		w := &Workers{store: storeMock, client: clientMock}
+		assert.NoError(t, w.runJobSchedule(context.Background(), "schedule"))
+
+		storeMock.AssertExpectations(t)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("disables the job schedule whose creator cannot create jobs", func(t *testing.T) {
+		storeMock := new(storemocks.Store)
+		clientMock := new(clientmocks.Client)
+
+		disabled := false
+
+		storeMock.On("JobScheduleGet", mock.Anything, "schedule").Return(schedule, nil).Once()
+		storeMock.On("UserGetByUsername", mock.Anything, "john").Return(&models.User{ID: "user"}, nil).Once()
+		storeMock.On("NamespaceGet", mock.Anything, "tenant").
+			Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "user", Role: guard.RoleOperator}}}, nil).Once()
+		storeMock.On("JobScheduleUpdate", mock.Anything, "schedule", &models.JobScheduleChanges{Enabled: &disabled}).Return(nil).Once()
+
+		w := &Workers{store: storeMock, client: clientMock}
+		assert.NoError(t, w.runJobSchedule(context.Background(), "schedule"))
+
+		storeMock.AssertExpectations(t)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("disables the job schedule whose public key was removed", func(t *testing.T) {
+		storeMock := new(storemocks.Store)
+		clientMock := new(clientmocks.Client)
+
+		disabled := false
+
+		storeMock.On("JobScheduleGet", mock.Anything, "schedule").Return(schedule, nil).Once()
+		storeMock.On("UserGetByUsername", mock.Anything, "john").Return(&models.User{ID: "user"}, nil).Once()
+		storeMock.On("NamespaceGet", mock.Anything, "tenant").
+			Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "user", Role: guard.RoleOwner}}}, nil).Once()
+		storeMock.On("PublicKeyGet", mock.Anything, "fingerprint", "tenant").Return(nil, store.ErrNoDocuments).Once()
+		storeMock.On("JobScheduleUpdate", mock.Anything, "schedule", &models.JobScheduleChanges{Enabled: &disabled}).Return(nil).Once()
+
+		w := &Workers{store: storeMock, client: clientMock}
+		assert.NoError(t, w.runJobSchedule(context.Background(), "schedule"))
+
+		storeMock.AssertExpectations(t)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("skips the disabled job schedule", func(t *testing.T) {
+		storeMock := new(storemocks.Store)
+		clientMock := new(clientmocks.Client)
+
+		disabled := *schedule
+		disabled.Enabled = false
+
+		storeMock.On("JobScheduleGet", mock.Anything, "schedule").Return(&disabled, nil).Once()
+
+		w := &Workers{store: storeMock, client: clientMock}
+		assert.NoError(t, w.runJobSchedule(context.Background(), "schedule"))
+
+		storeMock.AssertExpectations(t)
+		clientMock.AssertExpectations(t)
+	})
+}
+
+func TestJobScheduleRunTask(t *testing.T) {
+	storeMock := new(storemocks.Store)
+	clientMock := new(clientmocks.Client)
+
+	schedule := &models.JobSchedule{
+		ID:          "schedule",
+		TenantID:    "tenant",
+		Selector:    models.JobSelector{Tags: []string{"edge"}},
+		Fingerprint: "fingerprint",
+		Concurrency: 10,
+		Timeout:     60,
+		Enabled:     true,
+		CreatedBy:   "john",
+	}
+
+	w := &Workers{
+		store:     storeMock,
+		client:    clientMock,
+		mux:       asynq.NewServeMux(),
+		scheduler: asynq.NewScheduler(asynq.RedisClientOpt{Addr: "localhost:6379"}, nil),
+		entries:   make(map[string]jobScheduleEntry),
+	}
+
+	w.registerJobSchedules()
+
+	task := asynq.NewTask(TaskJobScheduleRun, []byte("schedule"))
+
+	t.Run("a failed run doesn't block the next", func(t *testing.T) {
+		storeMock.On("JobScheduleGet", mock.Anything, "schedule").Return(schedule, nil).Once()
+		authorized(storeMock, schedule)
+		storeMock.On("DeviceListBySelector", mock.Anything, "tenant", &schedule.Selector).Return(nil, errors.New("error")).Once()
+
+		assert.NoError(t, w.mux.ProcessTask(context.Background(), task))
+
+		storeMock.On("JobScheduleGet", mock.Anything, "schedule").Return(schedule, nil).Once()
+		authorized(storeMock, schedule)
+		storeMock.On("DeviceListBySelector", mock.Anything, "tenant", &schedule.Selector).
+			Return([]models.Device{{UID: "device-1", Online: true}}, nil).Once()
+		storeMock.On("JobScheduleRun", mock.Anything, "schedule", mock.Anything, []string{}).Return(nil).Once()
+		storeMock.On("JobCreate", mock.Anything, withDevices("schedule", "device-1"), mock.Anything).Return(nil).Once()
+		clientMock.On("JobEnqueue", mock.Anything, mock.Anything).Return(nil).Once()
+
+		assert.NoError(t, w.mux.ProcessTask(context.Background(), task))
+
+		storeMock.AssertExpectations(t)
+		clientMock.AssertExpectations(t)
+	})
+}
+
+func TestCatchUpJobSchedules(t *testing.T) {
+	storeMock := new(storemocks.Store)
+	clientMock := new(clientmocks.Client)
+
+	schedules := []models.JobSchedule{
+		{ID: "schedule", TenantID: "tenant", Fingerprint: "fingerprint", Enabled: true, RunOnReconnect: true, Missed: []string{"device-1", "device-2"}, Concurrency: 10, Timeout: 60, CreatedBy: "john"},
+		{ID: "other", TenantID: "tenant", Enabled: true, RunOnReconnect: false, Missed: []string{"device-3"}},
+	}
+
+	storeMock.On("JobScheduleListEnabled", mock.Anything).Return(schedules, nil).Once()
+	authorized(storeMock, &schedules[0])
+	storeMock.On("DeviceListBySelector", mock.Anything, "tenant", &models.JobSelector{UIDs: []string{"device-1", "device-2"}}).
+		Return([]models.Device{{UID: "device-1", Online: true}, {UID: "device-2", Online: false}}, nil).Once()
+	storeMock.On("JobScheduleRemoveMissed", mock.Anything, "schedule", []string{"device-1"}).Return(nil).Once()
+	storeMock.On("JobCreate", mock.Anything, withDevices("schedule", "device-1"), mock.Anything).Return(nil).Once()
+	clientMock.On("JobEnqueue", mock.Anything, mock.Anything).Return(nil).Once()
+
+	w := &Workers{store: storeMock, client: clientMock}
+	assert.NoError(t, w.catchUpJobSchedules(context.Background()))
+
+	storeMock.AssertExpectations(t)
+	clientMock.AssertExpectations(t)
+}
+
+func TestSyncJobScheduleEntries(t *testing.T) {
+	storeMock := new(storemocks.Store)
+
+	scheduler := asynq.NewScheduler(asynq.RedisClientOpt{Addr: "localhost:6379"}, nil)
+	w := &Workers{store: storeMock, scheduler: scheduler, entries: make(map[string]jobScheduleEntry)}
+
+	storeMock.On("JobScheduleListEnabled", mock.Anything).Return([]models.JobSchedule{
+		{ID: "daily", Cron: "@daily"},
+		{ID: "hourly", Cron: "@hourly"},
+		{ID: "invalid", Cron: "@sometimes"},
+	}, nil).Once()
+
+	w.syncJobScheduleEntries(context.Background())
+	require.Len(t, w.entries, 2)
+	assert.Equal(t, "@daily", w.entries["daily"].cron)
+	hourly := w.entries["hourly"].id
+
+	storeMock.On("JobScheduleListEnabled", mock.Anything).Return([]models.JobSchedule{
+		{ID: "daily", Cron: "0 3 * * *"},
+	}, nil).Once()
+
+	w.syncJobScheduleEntries(context.Background())
+	require.Len(t, w.entries, 1)
+	assert.Equal(t, "0 3 * * *", w.entries["daily"].cron)
+	assert.Error(t, scheduler.Unregister(hourly))
+
+	storeMock.AssertExpectations(t)
+}
@@ -0,0 +1,299 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// jobWatchInterval is the interval between the checks of a running job's status, what stop its execution when it's
+// cancelled.
+const jobWatchInterval = 2 * time.Second
+
+// jobReapGrace is the time given, on top of a job's deadline, to its worker to record the job's end before the job is
+// considered lost.
+const jobReapGrace = time.Minute
+
+// ErrJobLost is the error recorded on the results left by a job whose execution was lost.
+var ErrJobLost = errors.New("the job's execution was lost before the command was executed on the device")
+
+// registerJobs registers the worker that executes the jobs' commands on their devices, enqueued when the jobs are
+// created, the worker that deletes the jobs finished before their namespaces' retention and the worker that fails the
+// jobs lost by their workers. The cleanup uses a cron expression from `SHELLHUB_JOB_CLEANUP_SCHEDULE`, and the reaper
+// one from `SHELLHUB_JOB_REAP_SCHEDULE`, to schedule their periodic execution.
+func (w *Workers) registerJobs() {
+	w.mux.HandleFunc(TaskJobRun, func(ctx context.Context, task *asynq.Task) error {
+		return w.runJob(ctx, string(task.Payload()))
+	})
+
+	w.mux.HandleFunc(TaskJobCleanup, func(ctx context.Context, _ *asynq.Task) error {
+		deleted, err := w.store.JobDeleteExpired(ctx, clock.Now())
+		if err != nil {
+			log.WithFields(log.Fields{"component": "worker", "task": TaskJobCleanup}).
+				WithError(err).
+				Error("Failed to delete the expired jobs")
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"component":       "worker",
+			"cron_expression": w.env.JobCleanupSchedule,
+			"task":            TaskJobCleanup,
+			"deleted_count":   deleted,
+		}).Trace("Finishing job cleanup worker.")
+
+		return nil
+	})
+
+	w.mux.HandleFunc(TaskJobReap, func(ctx context.Context, _ *asynq.Task) error {
+		return w.reapJobs(ctx)
+	})
+
+	task := asynq.NewTask(TaskJobCleanup, nil, asynq.TaskID(TaskJobCleanup), asynq.Queue("api"))
+	if _, err := w.scheduler.Register(w.env.JobCleanupSchedule, task); err != nil {
+		log.WithFields(log.Fields{"component": "worker", "task": TaskJobCleanup}).
+			WithError(err).
+			Error("Failed to register the scheduler.")
+	}
+
+	reap := asynq.NewTask(TaskJobReap, nil, asynq.TaskID(TaskJobReap), asynq.Queue("api"))
+	if _, err := w.scheduler.Register(w.env.JobReapSchedule, reap); err != nil {
+		log.WithFields(log.Fields{"component": "worker", "task": TaskJobReap}).
+			WithError(err).
+			Error("Failed to register the scheduler.")
+	}
+}
+
+// reapJobs fails the running jobs past their deadlines, what are the jobs whose workers were lost, like when they
+// crashed, as the jobs' tasks aren't retried. The results left by them are failed too.
+func (w *Workers) reapJobs(ctx context.Context) error {
+	logger := log.WithFields(log.Fields{"component": "worker", "task": TaskJobReap})
+
+	jobs, err := w.store.JobListRunning(ctx)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list the running jobs")
+
+		return err
+	}
+
+	for i := range jobs {
+		job := &jobs[i]
+		if job.StartedAt == nil || clock.Now().Before(job.StartedAt.Add(job.Deadline()+jobReapGrace)) {
+			continue
+		}
+
+		if err := w.store.JobUpdateStatus(ctx, job.ID, models.JobStatusFailed, models.JobStatusRunning); err != nil {
+			// NOTICE: The job was finished, or cancelled, since it was listed.
+			if errors.Is(err, store.ErrNoDocuments) {
+				continue
+			}
+
+			logger.WithError(err).WithField("job", job.ID).Error("Failed to fail the lost job")
+
+			return err
+		}
+
+		results, err := w.store.JobResultList(ctx, job.ID)
+		if err != nil {
+			logger.WithError(err).WithField("job", job.ID).Error("Failed to list the lost job's results")
+
+			return err
+		}
+
+		for j := range results {
+			result := &results[j]
+			if result.Status != models.JobResultStatusPending && result.Status != models.JobResultStatusRunning {
+				continue
+			}
+
+			result.Error = ErrJobLost.Error()
+			w.finishJobResult(ctx, result, models.JobResultStatusFailed)
+		}
+
+		logger.WithField("job", job.ID).Warn("Lost job failed")
+	}
+
+	return nil
+}
+
+// runJob executes the job's command on its devices, limited by the job's concurrency, until every device executes it
+// or the job is cancelled. Devices left when the job is cancelled, or when the task's deadline is reached, don't
+// execute the command.
+func (w *Workers) runJob(ctx context.Context, id string) error {
+	logger := log.WithFields(log.Fields{"component": "worker", "task": TaskJobRun, "job": id})
+
+	job, err := w.store.JobGet(ctx, id)
+	if err != nil {
+		logger.WithError(err).Error("Failed to get the job")
+
+		return err
+	}
+
+	results, err := w.store.JobResultList(ctx, id)
+	if err != nil {
+		logger.WithError(err).Error("Failed to list the job's results")
+
+		return err
+	}
+
+	if err := w.store.JobUpdateStatus(ctx, id, models.JobStatusRunning, models.JobStatusPending); err != nil {
+		if !errors.Is(err, store.ErrNoDocuments) {
+			logger.WithError(err).Error("Failed to start the job")
+
+			return err
+		}
+
+		// NOTICE: The job was cancelled before being started.
+		for i := range results {
+			w.finishJobResult(ctx, &results[i], models.JobResultStatusCancelled)
+		}
+
+		logger.Info("Job cancelled before being started")
+
+		return nil
+	}
+
+	logger.WithField("devices", len(results)).Info("Job started")
+
+	running, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go w.watchJob(running, cancel, id)
+
+	semaphore := make(chan struct{}, job.Concurrency)
+	wg := new(sync.WaitGroup)
+	for i := range results {
+		result := &results[i]
+
+		select {
+		case semaphore <- struct{}{}:
+		case <-running.Done():
+		}
+
+		if running.Err() != nil {
+			w.finishJobResult(ctx, result, models.JobResultStatusCancelled)
+
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			w.runJobCommand(running, job, result)
+		}()
+	}
+
+	wg.Wait()
+
+	// NOTICE: A cancelled job keeps its status.
+	if err := w.store.JobUpdateStatus(context.WithoutCancel(ctx), id, models.JobStatusFinished, models.JobStatusRunning); err != nil && !errors.Is(err, store.ErrNoDocuments) {
+		logger.WithError(err).Error("Failed to finish the job")
+
+		return err
+	}
+
+	logger.Info("Job finished")
+
+	return nil
+}
+
+// watchJob checks the job's status periodically, cancelling its execution when the job is cancelled, or failed as lost.
+func (w *Workers) watchJob(ctx context.Context, cancel context.CancelFunc, id string) {
+	ticker := time.NewTicker(jobWatchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job, err := w.store.JobGet(ctx, id)
+			if err != nil {
+				continue
+			}
+
+			if job.Status == models.JobStatusCancelled || job.Status == models.JobStatusFailed {
+				cancel()
+
+				return
+			}
+		}
+	}
+}
+
+// runJobCommand executes the job's command on the result's device, recording its output on the result.
+func (w *Workers) runJobCommand(ctx context.Context, job *models.Job, result *models.JobResult) {
+	started := clock.Now()
+	result.Status = models.JobResultStatusRunning
+	result.StartedAt = &started
+
+	// NOTICE: The results are stored even when the job is cancelled, so the context isn't cancelled on them.
+	if err := w.store.JobResultUpdate(context.WithoutCancel(ctx), result); err != nil {
+		log.WithFields(log.Fields{"component": "worker", "job": job.ID, "device": result.UID}).
+			WithError(err).
+			Warn("Failed to update the job's result")
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, time.Duration(job.Timeout)*time.Second+models.JobConnectTimeout)
+	defer cancel()
+
+	output, err := w.client.CommandExecute(timeout, &requests.DeviceCommand{
+		DeviceParam: requests.DeviceParam{UID: result.UID},
+		User:        job.User,
+		Fingerprint: job.Fingerprint,
+		SignedData:  job.SignedData,
+		Signature:   job.Signature,
+		Command:     job.Command,
+		Timeout:     job.Timeout,
+	})
+
+	switch {
+	case ctx.Err() != nil:
+		w.finishJobResult(ctx, result, models.JobResultStatusCancelled)
+	case err != nil:
+		result.Error = err.Error()
+		w.finishJobResult(ctx, result, models.JobResultStatusFailed)
+	default:
+		result.Stdout = output.Stdout
+		result.Stderr = output.Stderr
+		result.Truncated = output.Truncated
+
+		status := models.JobResultStatusFailed
+		switch {
+		case output.TimedOut:
+			status = models.JobResultStatusTimeout
+		case output.ExitCode == 0:
+			status = models.JobResultStatusSucceeded
+		}
+
+		if !output.TimedOut {
+			result.ExitCode = &output.ExitCode
+		}
+
+		w.finishJobResult(ctx, result, status)
+	}
+}
+
+// finishJobResult records the result's final status.
+func (w *Workers) finishJobResult(ctx context.Context, result *models.JobResult, status models.JobResultStatus) {
+	finished := clock.Now()
+	result.Status = status
+	result.FinishedAt = &finished
+
+	if err := w.store.JobResultUpdate(context.WithoutCancel(ctx), result); err != nil {
+		log.WithFields(log.Fields{"component": "worker", "job": result.JobID, "device": result.UID}).
+			WithError(err).
+			Warn("Failed to update the job's result")
+	}
+}
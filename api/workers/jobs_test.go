@@ -0,0 +1,176 @@
+package workers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	storemocks "github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	clientmocks "github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withStatus matches a job's result of the device with the status.
+func withStatus(uid string, status models.JobResultStatus) interface{} {
+	return mock.MatchedBy(func(result *models.JobResult) bool {
+		return result.UID == uid && result.Status == status
+	})
+}
+
+func TestRunJob(t *testing.T) {
+	job := &models.Job{
+		ID:          "job",
+		Command:     "uptime",
+		User:        "root",
+		Fingerprint: "fingerprint",
+		SignedData:  "data",
+		Signature:   "signature",
+		Concurrency: 1,
+		Timeout:     30,
+		Status:      models.JobStatusPending,
+	}
+
+	results := []models.JobResult{
+		{JobID: "job", UID: "device-1", Status: models.JobResultStatusPending},
+		{JobID: "job", UID: "device-2", Status: models.JobResultStatusPending},
+		{JobID: "job", UID: "device-3", Status: models.JobResultStatusPending},
+	}
+
+	command := func(uid string) *requests.DeviceCommand {
+		return &requests.DeviceCommand{
+			DeviceParam: requests.DeviceParam{UID: uid},
+			User:        "root",
+			Fingerprint: "fingerprint",
+			SignedData:  "data",
+			Signature:   "signature",
+			Command:     "uptime",
+			Timeout:     30,
+		}
+	}
+
+	t.Run("executes the command on every device", func(t *testing.T) {
+		storeMock := new(storemocks.Store)
+		clientMock := new(clientmocks.Client)
+
+		storeMock.On("JobGet", mock.Anything, "job").Return(job, nil).Once()
+		storeMock.On("JobResultList", mock.Anything, "job").Return(append([]models.JobResult{}, results...), nil).Once()
+		storeMock.On("JobUpdateStatus", mock.Anything, "job", models.JobStatusRunning, models.JobStatusPending).Return(nil).Once()
+
+		for _, uid := range []string{"device-1", "device-2", "device-3"} {
+			storeMock.On("JobResultUpdate", mock.Anything, withStatus(uid, models.JobResultStatusRunning)).Return(nil).Once()
+		}
+
+		clientMock.On("CommandExecute", mock.Anything, command("device-1")).
+			Return(&models.CommandOutput{ExitCode: 0, Stdout: "up"}, nil).Once()
+		clientMock.On("CommandExecute", mock.Anything, command("device-2")).
+			Return(&models.CommandOutput{ExitCode: 1, Stderr: "error"}, nil).Once()
+		clientMock.On("CommandExecute", mock.Anything, command("device-3")).
+			Return(nil, internalclient.ErrCommandForbidden).Once()
+
+		storeMock.On("JobResultUpdate", mock.Anything, mock.MatchedBy(func(result *models.JobResult) bool {
+			return result.UID == "device-1" && result.Status == models.JobResultStatusSucceeded && result.Stdout == "up" && *result.ExitCode == 0
+		})).Return(nil).Once()
+		storeMock.On("JobResultUpdate", mock.Anything, mock.MatchedBy(func(result *models.JobResult) bool {
+			return result.UID == "device-2" && result.Status == models.JobResultStatusFailed && result.Stderr == "error" && *result.ExitCode == 1
+		})).Return(nil).Once()
+		storeMock.On("JobResultUpdate", mock.Anything, mock.MatchedBy(func(result *models.JobResult) bool {
+			return result.UID == "device-3" && result.Status == models.JobResultStatusFailed && result.Error == internalclient.ErrCommandForbidden.Error()
+		})).Return(nil).Once()
+
+		storeMock.On("JobUpdateStatus", mock.Anything, "job", models.JobStatusFinished, models.JobStatusRunning).Return(nil).Once()
+
+		w := &Workers{store: storeMock, client: clientMock}
+		assert.NoError(t, w.runJob(context.Background(), "job"))
+
+		storeMock.AssertExpectations(t)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("cancels the devices when the job was cancelled before being started", func(t *testing.T) {
+		storeMock := new(storemocks.Store)
+		clientMock := new(clientmocks.Client)
+
+		storeMock.On("JobGet", mock.Anything, "job").Return(job, nil).Once()
+		storeMock.On("JobResultList", mock.Anything, "job").Return(append([]models.JobResult{}, results...), nil).Once()
+		storeMock.On("JobUpdateStatus", mock.Anything, "job", models.JobStatusRunning, models.JobStatusPending).
+			Return(store.ErrNoDocuments).Once()
+
+		for _, uid := range []string{"device-1", "device-2", "device-3"} {
+			storeMock.On("JobResultUpdate", mock.Anything, withStatus(uid, models.JobResultStatusCancelled)).Return(nil).Once()
+		}
+
+		w := &Workers{store: storeMock, client: clientMock}
+		assert.NoError(t, w.runJob(context.Background(), "job"))
+
+		storeMock.AssertExpectations(t)
+		clientMock.AssertExpectations(t)
+	})
+
+	t.Run("stops when the job's context is cancelled", func(t *testing.T) {
+		storeMock := new(storemocks.Store)
+		clientMock := new(clientmocks.Client)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		storeMock.On("JobGet", mock.Anything, "job").Return(job, nil).Once()
+		storeMock.On("JobResultList", mock.Anything, "job").Return(append([]models.JobResult{}, results...), nil).Once()
+		storeMock.On("JobUpdateStatus", mock.Anything, "job", models.JobStatusRunning, models.JobStatusPending).Return(nil).Once()
+		storeMock.On("JobResultUpdate", mock.Anything, withStatus("device-1", models.JobResultStatusRunning)).Return(nil).Once()
+
+		clientMock.On("CommandExecute", mock.Anything, command("device-1")).
+			Run(func(mock.Arguments) { cancel() }).
+			Return(nil, context.Canceled).Once()
+
+		for _, uid := range []string{"device-1", "device-2", "device-3"} {
+			storeMock.On("JobResultUpdate", mock.Anything, withStatus(uid, models.JobResultStatusCancelled)).Return(nil).Once()
+		}
+
+		storeMock.On("JobUpdateStatus", mock.Anything, "job", models.JobStatusFinished, models.JobStatusRunning).Return(nil).Once()
+
+		w := &Workers{store: storeMock, client: clientMock}
+		assert.NoError(t, w.runJob(ctx, "job"))
+
+		storeMock.AssertExpectations(t)
+		clientMock.AssertExpectations(t)
+	})
+}
+
+func TestReapJobs(t *testing.T) {
+	storeMock := new(storemocks.Store)
+
+	lost := time.Now().Add(-time.Hour)
+	started := time.Now()
+
+	jobs := []models.Job{
+		{ID: "lost", Concurrency: 1, Timeout: 30, Devices: []string{"device-1", "device-2", "device-3"}, Status: models.JobStatusRunning, StartedAt: &lost},
+		{ID: "finished", Concurrency: 1, Timeout: 30, Devices: []string{"device-1"}, Status: models.JobStatusRunning, StartedAt: &lost},
+		{ID: "running", Concurrency: 1, Timeout: 30, Devices: []string{"device-1"}, Status: models.JobStatusRunning, StartedAt: &started},
+	}
+
+	storeMock.On("JobListRunning", mock.Anything).Return(jobs, nil).Once()
+	storeMock.On("JobUpdateStatus", mock.Anything, "lost", models.JobStatusFailed, models.JobStatusRunning).Return(nil).Once()
+	storeMock.On("JobUpdateStatus", mock.Anything, "finished", models.JobStatusFailed, models.JobStatusRunning).
+		Return(store.ErrNoDocuments).Once()
+	storeMock.On("JobResultList", mock.Anything, "lost").Return([]models.JobResult{
+		{JobID: "lost", UID: "device-1", Status: models.JobResultStatusSucceeded},
+		{JobID: "lost", UID: "device-2", Status: models.JobResultStatusRunning},
+		{JobID: "lost", UID: "device-3", Status: models.JobResultStatusPending},
+	}, nil).Once()
+
+	for _, uid := range []string{"device-2", "device-3"} {
+		uid := uid
+		storeMock.On("JobResultUpdate", mock.Anything, mock.MatchedBy(func(result *models.JobResult) bool {
+			return result.UID == uid && result.Status == models.JobResultStatusFailed && result.Error == ErrJobLost.Error()
+		})).Return(nil).Once()
+	}
+
+	w := &Workers{store: storeMock}
+	assert.NoError(t, w.reapJobs(context.Background()))
+
+	storeMock.AssertExpectations(t)
+}
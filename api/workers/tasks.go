@@ -1,6 +1,13 @@
 package workers
 
+import "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+
 const (
-	TaskSessionCleanup = "session_record:cleanup"
-	TaskHeartbeat      = "api:heartbeat"
+	TaskSessionCleanup     = "session_record:cleanup"
+	TaskHeartbeat          = "api:heartbeat"
+	TaskJobRun             = internalclient.TaskJobRun
+	TaskJobCleanup         = "api:job_cleanup"
+	TaskJobReap            = "api:job_reap"
+	TaskJobScheduleRun     = "api:job_schedule"
+	TaskJobScheduleCatchUp = "api:job_schedule_catchup"
 )
@@ -10,6 +10,9 @@ type Envs struct {
 	RedisURI                      string `env:"REDIS_URI,default=redis://redis:6379"`
 	SessionRecordCleanupSchedule  string `env:"SESSION_RECORD_CLEANUP_SCHEDULE,default=@daily"`
 	SessionRecordCleanupRetention int    `env:"RECORD_RETENTION,default=0"`
+	JobCleanupSchedule            string `env:"JOB_CLEANUP_SCHEDULE,default=@daily"`
+	// JobReapSchedule is the cron expression of the checks of the running jobs past their deadlines, what are failed.
+	JobReapSchedule string `env:"JOB_REAP_SCHEDULE,default=@every 5m"`
 	// AsynqGroupMaxDelay is the maximum duration to wait before processing a group of tasks.
 	//
 	// Its time unit is second.
@@ -9,22 +9,26 @@ import (
 
 	"github.com/hibiken/asynq"
 	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
 	log "github.com/sirupsen/logrus"
 )
 
 type Workers struct {
-	store store.Store
+	store  store.Store
+	client internalclient.Client
 
 	addr      asynq.RedisConnOpt
 	srv       *asynq.Server
 	mux       *asynq.ServeMux
 	env       *Envs
 	scheduler *asynq.Scheduler
+	// entries are the job schedules registered on the scheduler, indexed by their IDs.
+	entries map[string]jobScheduleEntry
 }
 
-// New creates a new Workers instance with the provided store. It initializes
+// New creates a new Workers instance with the provided store and internal client. It initializes
 // the worker's components, such as server, scheduler, and environment settings.
-func New(store store.Store) (*Workers, error) {
+func New(store store.Store, client internalclient.Client) (*Workers, error) {
 	env, err := getEnvs()
 	if err != nil {
 		log.WithFields(log.Fields{"component": "worker"}).
@@ -80,6 +84,8 @@ func New(store store.Store) (*Workers, error) {
 		mux:       mux,
 		scheduler: scheduler,
 		store:     store,
+		client:    client,
+		entries:   make(map[string]jobScheduleEntry),
 	}
 
 	return w, nil
@@ -93,6 +99,8 @@ func (w *Workers) Start(ctx context.Context) {
 
 	w.setupHandlers()
 
+	go w.syncJobSchedules(ctx)
+
 	go func() {
 		if err := w.srv.Run(w.mux); err != nil {
 			log.WithFields(log.Fields{"component": "worker"}).
@@ -124,4 +132,6 @@ func (w *Workers) Start(ctx context.Context) {
 func (w *Workers) setupHandlers() {
 	w.registerSessionCleanup()
 	w.registerHeartbeat()
+	w.registerJobs()
+	w.registerJobSchedules()
 }
@@ -49,6 +49,7 @@ func (s *service) NamespaceCreate(ctx context.Context, input *inputs.NamespaceCr
 		Settings: &models.NamespaceSettings{
 			SessionRecord:          true,
 			ConnectionAnnouncement: "",
+			AgentForwarding:        true,
 		},
 		CreatedAt: clock.Now(),
 	}
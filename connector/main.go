@@ -3,7 +3,9 @@ package main
 import (
 	"path"
 
+	"github.com/shellhub-io/shellhub/pkg/agent"
 	"github.com/shellhub-io/shellhub/pkg/agent/connector"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -82,6 +84,17 @@ func main() {
 		},
 	}
 
+	rootCmd.AddCommand(&cobra.Command{ // nolint: exhaustruct
+		Use:   "sftp",
+		Short: "Starts the SFTP server",
+		Long: `Starts the SFTP server. This command is used internally by the connector and should not be used directly.
+It is executed inside the container, where the connector copies itself to, when a new SFTP session is created.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			agent.NewSFTPServer(command.SFTPServerMode(args[0]))
+		},
+	})
+
 	rootCmd.Version = ConnectorVersion
 	rootCmd.Execute() // nolint: errcheck
 }
@@ -61,7 +61,6 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/api/client"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/models"
-	"github.com/shellhub-io/shellhub/pkg/revdial"
 	"github.com/shellhub-io/shellhub/pkg/validator"
 	log "github.com/sirupsen/logrus"
 )
@@ -339,6 +338,12 @@ func (a *Agent) probeServerInfo() error {
 
 // authorize send auth request to the server.
 func (a *Agent) authorize() error {
+	// The device key is also the SSH server's host key, so it's reported to be pinned by the server.
+	hostKey, err := keygen.EncodePublicKeyToAuthorized(a.pubKey)
+	if err != nil {
+		return err
+	}
+
 	data, err := a.cli.AuthDevice(&models.DeviceAuthRequest{
 		Info: a.Info,
 		DeviceAuth: &models.DeviceAuth{
@@ -346,6 +351,7 @@ func (a *Agent) authorize() error {
 			Identity:  a.Identity,
 			TenantID:  a.config.TenantID,
 			PublicKey: string(keygen.EncodePublicKeyToPem(a.pubKey)),
+			HostKey:   string(hostKey),
 		},
 	})
 
@@ -354,7 +360,7 @@ func (a *Agent) authorize() error {
 	return err
 }
 
-func (a *Agent) NewReverseListener(ctx context.Context) (*revdial.Listener, error) {
+func (a *Agent) NewReverseListener(ctx context.Context) (net.Listener, error) {
 	return a.cli.NewReverseListener(ctx, a.authData.Token)
 }
 
@@ -1,6 +1,7 @@
 package keygen
 
 import (
+	"bytes"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 
 	"github.com/pkg/errors"
+	"golang.org/x/crypto/ssh"
 )
 
 var ErrPemDecode = errors.New("PEM decode error")
@@ -74,3 +76,14 @@ func EncodePublicKeyToPem(key *rsa.PublicKey) []byte {
 		Bytes: x509.MarshalPKCS1PublicKey(key),
 	})
 }
+
+// EncodePublicKeyToAuthorized encodes the public key in the OpenSSH authorized_keys format, the one used to report the
+// agent's SSH host key.
+func EncodePublicKeyToAuthorized(key *rsa.PublicKey) ([]byte, error) {
+	pub, err := ssh.NewPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSpace(ssh.MarshalAuthorizedKey(pub)), nil
+}
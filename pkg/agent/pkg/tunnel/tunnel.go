@@ -6,7 +6,6 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/shellhub-io/shellhub/pkg/revdial"
 )
 
 type Tunnel struct {
@@ -84,7 +83,7 @@ func NewTunnel() *Tunnel {
 }
 
 // Listen to reverse listener.
-func (t *Tunnel) Listen(l *revdial.Listener) error {
+func (t *Tunnel) Listen(l net.Listener) error {
 	return t.srv.Serve(l)
 }
 
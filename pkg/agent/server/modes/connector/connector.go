@@ -6,11 +6,13 @@ package connector
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/docker/docker/api/types"
 	dockerclient "github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/process"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
 )
 
 type Mode struct {
@@ -73,6 +75,35 @@ func attachToContainer(ctx context.Context, cli dockerclient.APIClient, requestT
 	return &res, id.ID, err
 }
 
+// attachSFTPToContainer executes the SFTP server, copied to the container, as the user. The server changes its
+// credentials to the ones informed through the environment, what must match the user's.
+func attachSFTPToContainer(ctx context.Context, cli dockerclient.APIClient, container string, user *osauth.User) (*types.HijackedResponse, string, error) {
+	home := user.HomeDir
+	if home == "" {
+		home = "/"
+	}
+
+	id, err := cli.ContainerExecCreate(ctx, container, types.ExecConfig{
+		User:         user.Username,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Env: []string{
+			fmt.Sprintf("HOME=%s", home),
+			fmt.Sprintf("UID=%d", user.UID),
+			fmt.Sprintf("GID=%d", user.GID),
+		},
+		Cmd: []string{sftpServerPath, "sftp", string(command.SFTPServerModeConnector)},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	res, err := cli.ContainerExecAttach(ctx, id.ID, types.ExecStartCheck{})
+
+	return &res, id.ID, err
+}
+
 func exitCodeExecFromContainer(cli dockerclient.APIClient, id string) (int, error) {
 	inspected, err := cli.ContainerExecInspect(context.Background(), id)
 	if err != nil {
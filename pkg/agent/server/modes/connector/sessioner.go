@@ -206,7 +206,56 @@ func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 
 // SFTP handles the SSH's server sftp session when server is running in connector mode.
 //
-// sftp is a subsystem of SSH that allows file operations over SSH.
-func (s *Sessioner) SFTP(_ gliderssh.Session) error {
-	return errors.New("SFTP isn't supported to ShellHub Agent in connector mode")
+// sftp is a subsystem of SSH that allows file operations over SSH. The connector's executable is copied to the
+// container to serve the subsystem from inside it, as the user authenticated on the container.
+func (s *Sessioner) SFTP(session gliderssh.Session) error {
+	// NOTICE(r): To identify what the container the connector should connect to, we use the `deviceName` as the container name
+	container := *s.container
+
+	user, ok := session.Context().Value("user").(*osauth.User)
+	if !ok {
+		return ErrUserNotFound
+	}
+
+	if err := copySFTPServerToContainer(session.Context(), s.docker, container, sftpServerExecutable); err != nil {
+		return err
+	}
+
+	resp, id, err := attachSFTPToContainer(session.Context(), s.docker, container, user)
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer func() {
+			code, err := exitCodeExecFromContainer(s.docker, id)
+			if err != nil {
+				fmt.Println(err)
+			}
+
+			session.Exit(code) //nolint:errcheck
+		}()
+
+		if _, err := stdcopy.StdCopy(session, session.Stderr(), resp.Reader); err != nil && err != io.EOF {
+			fmt.Println(err)
+		}
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer resp.CloseWrite() //nolint:errcheck
+
+		if _, err := io.Copy(resp.Conn, session); err != nil && err != io.EOF {
+			fmt.Println(err)
+		}
+	}()
+
+	wg.Wait()
+
+	return nil
 }
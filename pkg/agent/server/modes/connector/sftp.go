@@ -0,0 +1,82 @@
+package connector
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	dockerclient "github.com/docker/docker/client"
+)
+
+// sftpServerPath is the path, inside the container, where the connector's executable is copied to serve the SFTP
+// sessions. The executable is linked statically, so it runs on any container, even on the ones without a libc.
+const sftpServerPath = "/.shellhub/sftp"
+
+// sftpServerExecutable is the connector's executable, what is copied to the containers.
+const sftpServerExecutable = "/proc/self/exe"
+
+// sftpServerMutex avoids copying the executable to a container by concurrent sessions.
+var sftpServerMutex sync.Mutex
+
+// copySFTPServerToContainer copies the executable to the container, through the Docker's archive API, when it isn't
+// there yet or it's from another version of the connector, what is checked by its size and modification time.
+func copySFTPServerToContainer(ctx context.Context, cli dockerclient.APIClient, container string, executable string) error {
+	sftpServerMutex.Lock()
+	defer sftpServerMutex.Unlock()
+
+	info, err := os.Stat(executable)
+	if err != nil {
+		return err
+	}
+
+	modified := info.ModTime().Truncate(time.Second)
+
+	if stat, err := cli.ContainerStatPath(ctx, container, sftpServerPath); err == nil {
+		if stat.Size == info.Size() && stat.Mtime.Unix() == modified.Unix() {
+			return nil
+		}
+	}
+
+	file, err := os.Open(executable)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	reader, writer := io.Pipe()
+	go func() {
+		archive := tar.NewWriter(writer)
+
+		if err := archive.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     strings.TrimPrefix(sftpServerPath, "/"),
+			Size:     info.Size(),
+			Mode:     0o755,
+			ModTime:  modified,
+		}); err != nil {
+			writer.CloseWithError(err)
+
+			return
+		}
+
+		if _, err := io.Copy(archive, file); err != nil {
+			writer.CloseWithError(err)
+
+			return
+		}
+
+		writer.CloseWithError(archive.Close())
+	}()
+
+	// NOTICE: The directories missing on the executable's path are created by Docker when the archive is extracted.
+	err = cli.CopyToContainer(ctx, container, "/", reader, types.CopyToContainerOptions{})
+	reader.CloseWithError(err)
+
+	return err
+}
@@ -0,0 +1,95 @@
+package connector
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// docker is a Docker's client whose container's filesystem holds only the files copied to it.
+type docker struct {
+	dockerclient.APIClient
+
+	files map[string]*tar.Header
+	data  map[string][]byte
+}
+
+func (d *docker) ContainerStatPath(_ context.Context, _ string, path string) (types.ContainerPathStat, error) {
+	header, ok := d.files[path]
+	if !ok {
+		return types.ContainerPathStat{}, errors.New("no such file or directory")
+	}
+
+	return types.ContainerPathStat{Name: filepath.Base(path), Size: header.Size, Mtime: header.ModTime}, nil
+}
+
+func (d *docker) CopyToContainer(_ context.Context, _ string, path string, content io.Reader, _ types.CopyToContainerOptions) error {
+	archive := tar.NewReader(content)
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		data, err := io.ReadAll(archive)
+		if err != nil {
+			return err
+		}
+
+		d.files[filepath.Join(path, header.Name)] = header
+		d.data[filepath.Join(path, header.Name)] = data
+	}
+}
+
+func TestCopySFTPServerToContainer(t *testing.T) {
+	executable := filepath.Join(t.TempDir(), "connector")
+	require.NoError(t, os.WriteFile(executable, []byte("connector"), 0o755))
+
+	t.Run("copies the executable when it isn't on the container", func(t *testing.T) {
+		cli := &docker{files: make(map[string]*tar.Header), data: make(map[string][]byte)}
+
+		require.NoError(t, copySFTPServerToContainer(context.Background(), cli, "container", executable))
+
+		require.Contains(t, cli.files, sftpServerPath)
+		assert.Equal(t, int64(0o755), cli.files[sftpServerPath].Mode)
+		assert.Equal(t, []byte("connector"), cli.data[sftpServerPath])
+	})
+
+	t.Run("doesn't copy the executable when it is already on the container", func(t *testing.T) {
+		cli := &docker{files: make(map[string]*tar.Header), data: make(map[string][]byte)}
+
+		require.NoError(t, copySFTPServerToContainer(context.Background(), cli, "container", executable))
+		cli.data[sftpServerPath] = []byte("untouched")
+
+		require.NoError(t, copySFTPServerToContainer(context.Background(), cli, "container", executable))
+		assert.Equal(t, []byte("untouched"), cli.data[sftpServerPath])
+	})
+
+	t.Run("copies the executable when it is from another version", func(t *testing.T) {
+		cli := &docker{files: make(map[string]*tar.Header), data: make(map[string][]byte)}
+		cli.files[sftpServerPath] = &tar.Header{Size: 3}
+		cli.data[sftpServerPath] = []byte("old")
+
+		require.NoError(t, copySFTPServerToContainer(context.Background(), cli, "container", executable))
+		assert.Equal(t, []byte("connector"), cli.data[sftpServerPath])
+	})
+
+	t.Run("fails when the executable doesn't exist", func(t *testing.T) {
+		cli := &docker{files: make(map[string]*tar.Header), data: make(map[string][]byte)}
+
+		assert.Error(t, copySFTPServerToContainer(context.Background(), cli, "container", filepath.Join(t.TempDir(), "missing")))
+	})
+}
@@ -5,4 +5,7 @@ type SFTPServerMode string
 const (
 	SFTPServerModeNative SFTPServerMode = "native"
 	SFTPServerModeDocker SFTPServerMode = "docker"
+	// SFTPServerModeConnector is the mode of the SFTP server executed inside a container by the connector, serving
+	// the container's filesystem as it is.
+	SFTPServerModeConnector SFTPServerMode = "connector"
 )
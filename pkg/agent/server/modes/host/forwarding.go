@@ -0,0 +1,215 @@
+package host
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// forwardAgent exposes the client's SSH agent to the session's command when the client requested agent forwarding.
+//
+// It listens on a Unix socket, owned by the session's user, forwarding each connection to the client through an
+// "auth-agent@openssh.com" channel. It returns the environment variables to be appended to the command's environment
+// and a function to stop the forwarding, what must be called when the session ends.
+func forwardAgent(session gliderssh.Session) ([]string, func()) {
+	if !gliderssh.AgentRequested(session) {
+		return nil, func() {}
+	}
+
+	listener, socket, err := listenAgent()
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"user": session.User(),
+		}).Warn("failed to listen for the agent forwarding")
+
+		return nil, func() {}
+	}
+
+	// NOTICE: The listener's address is the socket's path inside the agent, what may differ from the path exported to the
+	// command when the agent runs in a container.
+	path := listener.Addr().String()
+
+	stop := func() {
+		listener.Close()                 //nolint:errcheck
+		os.RemoveAll(filepath.Dir(path)) //nolint:errcheck
+	}
+
+	user := new(osauth.OSAuth).LookupUser(session.User())
+
+	// NOTICE: The socket and its directory are created by the agent's user, so they must be owned by the session's user
+	// to be accessible by the command.
+	for _, p := range []string{filepath.Dir(path), path} {
+		if err := os.Chown(p, int(user.UID), int(user.GID)); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"user": session.User(),
+				"path": p,
+			}).Warn("failed to change the owner of the agent forwarding socket")
+
+			stop()
+
+			return nil, func() {}
+		}
+	}
+
+	go gliderssh.ForwardAgentConnections(listener, session)
+
+	log.WithFields(log.Fields{
+		"user":   session.User(),
+		"socket": socket,
+	}).Info("agent forwarding started")
+
+	return []string{"SSH_AUTH_SOCK=" + socket}, stop
+}
+
+const (
+	// x11DisplayOffset is the first display number tried to forward X11 connections, leaving the lower ones to local X
+	// servers.
+	x11DisplayOffset = 10
+	// x11MaxDisplays is the number of display numbers tried to forward X11 connections.
+	x11MaxDisplays = 1000
+)
+
+// forwardX11 exposes a display to the session's command when the client requested X11 forwarding.
+//
+// It listens on the first free display's TCP port on the loopback, forwarding each connection to the client through a
+// "x11" channel, and adds the client's authorization cookie to the user's Xauthority. It returns the environment
+// variables to be appended to the command's environment and a function to stop the forwarding, what must be called
+// when the session ends.
+func forwardX11(session gliderssh.Session, deviceName string) ([]string, func()) {
+	req, ok := modes.X11Requested(session)
+	if !ok {
+		return nil, func() {}
+	}
+
+	logger := log.WithFields(log.Fields{
+		"user": session.User(),
+	})
+
+	conn, ok := session.Context().Value(gliderssh.ContextKeyConn).(gossh.Conn)
+	if !ok {
+		logger.Warn("failed to get the connection for the x11 forwarding")
+
+		return nil, func() {}
+	}
+
+	var listener net.Listener
+	var display int
+	for display = x11DisplayOffset; display < x11DisplayOffset+x11MaxDisplays; display++ {
+		l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", 6000+display))
+		if err == nil {
+			listener = l
+
+			break
+		}
+	}
+
+	if listener == nil {
+		logger.Warn("failed to find a free display for the x11 forwarding")
+
+		return nil, func() {}
+	}
+
+	user := new(osauth.OSAuth).LookupUser(session.User())
+	name := fmt.Sprintf("unix:%d.%d", display, req.ScreenNumber)
+
+	xauth := func(args ...string) error {
+		return command.NewCmd(user, user.Shell, "", deviceName, nil, append([]string{"xauth", "-q"}, args...)...).Run()
+	}
+
+	if err := xauth("add", name, req.AuthProtocol, req.AuthCookie); err != nil {
+		logger.WithError(err).Warn("failed to add the x11 forwarding cookie")
+
+		listener.Close() //nolint:errcheck
+
+		return nil, func() {}
+	}
+
+	stop := func() {
+		listener.Close() //nolint:errcheck
+
+		if err := xauth("remove", name); err != nil {
+			logger.WithError(err).Warn("failed to remove the x11 forwarding cookie")
+		}
+	}
+
+	go func() {
+		for {
+			client, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go forwardX11Connection(conn, client)
+
+			if req.SingleConnection {
+				listener.Close() //nolint:errcheck
+
+				return
+			}
+		}
+	}()
+
+	logger.WithField("display", display).Info("x11 forwarding started")
+
+	return []string{fmt.Sprintf("DISPLAY=localhost:%d.%d", display, req.ScreenNumber)}, stop
+}
+
+// forwardX11Connection opens a "x11" channel to the client, piping the X11 connection through it.
+//
+// Check www.ietf.org/rfc/rfc4254.txt at section 6.3.2 for more information.
+func forwardX11Connection(conn gossh.Conn, client net.Conn) {
+	defer client.Close()
+
+	type channelData struct {
+		OriginatorAddress string
+		OriginatorPort    uint32
+	}
+
+	data := channelData{}
+	if addr, ok := client.RemoteAddr().(*net.TCPAddr); ok {
+		data.OriginatorAddress = addr.IP.String()
+		data.OriginatorPort = uint32(addr.Port) //nolint:gosec
+	}
+
+	channel, reqs, err := conn.OpenChannel("x11", gossh.Marshal(&data))
+	if err != nil {
+		log.WithError(err).Warn("failed to open the x11 channel")
+
+		return
+	}
+
+	defer channel.Close()
+
+	go gossh.DiscardRequests(reqs)
+
+	wg := new(sync.WaitGroup)
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+
+		io.Copy(channel, client) //nolint:errcheck
+		channel.CloseWrite()     //nolint:errcheck
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		io.Copy(client, channel) //nolint:errcheck
+		if c, ok := client.(*net.TCPConn); ok {
+			c.CloseWrite() //nolint:errcheck
+		}
+	}()
+
+	wg.Wait()
+}
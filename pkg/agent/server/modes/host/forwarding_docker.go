@@ -0,0 +1,37 @@
+//go:build docker
+// +build docker
+
+package host
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// hostRoot is where the host's root filesystem is mounted inside the agent's container.
+var hostRoot = "/host"
+
+// listenAgent listens on a Unix socket for the agent forwarding in a temporary directory of the host.
+//
+// The session's command runs in the host's mount namespace, so it cannot see the container's temporary directory. The
+// socket is created under the host's root filesystem mounted in the container, returning its path as seen by the
+// command, without the mount point.
+func listenAgent() (net.Listener, string, error) {
+	dir, err := os.MkdirTemp(filepath.Join(hostRoot, "tmp"), "auth-agent")
+	if err != nil {
+		return nil, "", err
+	}
+
+	socket := filepath.Join(dir, "listener.sock")
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		os.RemoveAll(dir) //nolint:errcheck
+
+		return nil, "", err
+	}
+
+	return listener, filepath.Join("/", strings.TrimPrefix(socket, hostRoot)), nil
+}
@@ -0,0 +1,34 @@
+//go:build docker
+// +build docker
+
+package host
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListenAgent(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "tmp"), 0o1777))
+
+	previous := hostRoot
+	hostRoot = root
+	t.Cleanup(func() { hostRoot = previous })
+
+	listener, socket, err := listenAgent()
+	require.NoError(t, err)
+	defer listener.Close()
+
+	assert.True(t, strings.HasPrefix(socket, "/tmp/auth-agent"))
+	assert.Equal(t, filepath.Join(root, socket), listener.Addr().String())
+
+	info, err := os.Stat(filepath.Join(root, socket))
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeSocket, info.Mode().Type())
+}
@@ -0,0 +1,23 @@
+//go:build !docker
+// +build !docker
+
+package host
+
+import (
+	"net"
+
+	gliderssh "github.com/gliderlabs/ssh"
+)
+
+// listenAgent listens on a Unix socket for the agent forwarding in a temporary directory.
+//
+// It returns the listener and the socket's path as seen by the session's command, what is the same path the listener
+// is bound to when the agent runs on the host.
+func listenAgent() (net.Listener, string, error) {
+	listener, err := gliderssh.NewAgentListener()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return listener, listener.Addr().String(), nil
+}
@@ -68,7 +68,13 @@ func NewSessioner(deviceName *string, cmds map[string]*exec.Cmd) *Sessioner {
 func (s *Sessioner) Shell(session gliderssh.Session) error {
 	sspty, winCh, isPty := session.Pty()
 
-	scmd := newShellCmd(*s.deviceName, session.User(), sspty.Term, session.Environ())
+	agentEnvs, stopAgent := forwardAgent(session)
+	defer stopAgent()
+
+	x11Envs, stopX11 := forwardX11(session, *s.deviceName)
+	defer stopX11()
+
+	scmd := newShellCmd(*s.deviceName, session.User(), sspty.Term, append(append(session.Environ(), agentEnvs...), x11Envs...))
 
 	pts, err := startPty(scmd, session, winCh)
 	if err != nil {
@@ -125,7 +131,13 @@ func (s *Sessioner) Shell(session gliderssh.Session) error {
 func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 	_, _, isPty := session.Pty()
 
-	cmd := newShellCmd(*s.deviceName, session.User(), "", session.Environ())
+	agentEnvs, stopAgent := forwardAgent(session)
+	defer stopAgent()
+
+	x11Envs, stopX11 := forwardX11(session, *s.deviceName)
+	defer stopX11()
+
+	cmd := newShellCmd(*s.deviceName, session.User(), "", append(append(session.Environ(), agentEnvs...), x11Envs...))
 
 	stdout, _ := cmd.StdoutPipe()
 	stdin, _ := cmd.StdinPipe()
@@ -213,7 +225,13 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		term = "xterm"
 	}
 
-	cmd := command.NewCmd(user, shell, term, *s.deviceName, session.Environ(), shell, "-c", session.RawCommand())
+	agentEnvs, stopAgent := forwardAgent(session)
+	defer stopAgent()
+
+	x11Envs, stopX11 := forwardX11(session, *s.deviceName)
+	defer stopX11()
+
+	cmd := command.NewCmd(user, shell, term, *s.deviceName, append(append(session.Environ(), agentEnvs...), x11Envs...), shell, "-c", session.RawCommand())
 
 	wg := &sync.WaitGroup{}
 	if sIsPty {
@@ -0,0 +1,29 @@
+package modes
+
+import gliderssh "github.com/gliderlabs/ssh"
+
+// X11Request is the payload of an "x11-req" request, sent by the client to ask for X11 connections be forwarded from
+// the session to it.
+//
+// Check www.ietf.org/rfc/rfc4254.txt at section 6.3.1 for more information.
+type X11Request struct {
+	SingleConnection bool
+	AuthProtocol     string
+	AuthCookie       string
+	ScreenNumber     uint32
+}
+
+// contextKeyX11Request is the context key for storing the X11 forwarding requested by the client.
+var contextKeyX11Request = &struct{ name string }{"x11-req"}
+
+// SetX11Requested sets up the session's context so that [X11Requested] returns the request.
+func SetX11Requested(ctx gliderssh.Context, req *X11Request) {
+	ctx.SetValue(contextKeyX11Request, req)
+}
+
+// X11Requested returns the X11 forwarding requested by the client, if any.
+func X11Requested(session gliderssh.Session) (*X11Request, bool) {
+	req, ok := session.Context().Value(contextKeyX11Request).(*X11Request)
+
+	return req, ok
+}
@@ -79,6 +79,21 @@ const (
 	ChannelDirectTcpip string = "direct-tcpip"
 )
 
+// SSH global requests supported by the SSH server.
+//
+// Check www.ietf.org/rfc/rfc4254.txt at section 4 for more information.
+const (
+	// RequestTCPIPForward is the global request used by the client to ask the server to listen on an address and port,
+	// opening a "forwarded-tcpip" channel back to the client for each connection accepted.
+	//
+	// Check www.ietf.org/rfc/rfc4254.txt at section 7.1 for more information.
+	RequestTCPIPForward string = "tcpip-forward"
+	// RequestCancelTCPIPForward is the global request used by the client to cancel a [RequestTCPIPForward].
+	//
+	// Check www.ietf.org/rfc/rfc4254.txt at section 7.1 for more information.
+	RequestCancelTCPIPForward string = "cancel-tcpip-forward"
+)
+
 // NewServer creates a new server SSH agent server.
 func NewServer(api client.Client, authData *models.DeviceAuthResponse, privateKey string, keepAliveInterval uint, singleUserPassword string, mode modes.Mode) *Server {
 	server := &Server{
@@ -95,6 +110,8 @@ func NewServer(api client.Client, authData *models.DeviceAuthResponse, privateKe
 		m.Sessioner.SetCmds(server.cmds)
 	}
 
+	forwardHandler := &gliderssh.ForwardedTCPHandler{}
+
 	server.sshd = &gliderssh.Server{
 		PasswordHandler:        server.passwordHandler,
 		PublicKeyHandler:       server.publicKeyHandler,
@@ -119,8 +136,14 @@ func NewServer(api client.Client, authData *models.DeviceAuthResponse, privateKe
 		LocalPortForwardingCallback: func(ctx gliderssh.Context, destinationHost string, destinationPort uint32) bool {
 			return true
 		},
+		// NOTICE: Remote port forwarding is allowed or denied by the ShellHub's SSH server, based on the namespace's
+		// settings, before the request reaches the agent.
 		ReversePortForwardingCallback: func(ctx gliderssh.Context, destinationHost string, destinationPort uint32) bool {
-			return false
+			return true
+		},
+		RequestHandlers: map[string]gliderssh.RequestHandler{
+			RequestTCPIPForward:       forwardHandler.HandleSSHRequest,
+			RequestCancelTCPIPForward: forwardHandler.HandleSSHRequest,
 		},
 		ChannelHandlers: map[string]gliderssh.ChannelHandler{
 			ChannelSession:     gliderssh.DefaultSessionHandler,
@@ -128,6 +151,11 @@ func NewServer(api client.Client, authData *models.DeviceAuthResponse, privateKe
 		},
 	}
 
+	// NOTICE: X11 forwarding is supported only in host mode, where the session's command runs on the device.
+	if _, ok := mode.(*host.Mode); ok {
+		server.sshd.ChannelHandlers[ChannelSession] = x11SessionHandler
+	}
+
 	err := server.sshd.SetOption(gliderssh.HostKeyFile(privateKey))
 	if err != nil {
 		log.Warn(err)
@@ -0,0 +1,62 @@
+package server
+
+import (
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// RequestX11 is the session's request used by the client to ask for X11 connections be forwarded to it.
+//
+// Check www.ietf.org/rfc/rfc4254.txt at section 6.3.1 for more information.
+const RequestX11 string = "x11-req"
+
+// x11SessionHandler is a session's channel handler what handles the "x11-req" request, unsupported by the default
+// session's handler, before passing the channel to it.
+func x11SessionHandler(srv *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
+	gliderssh.DefaultSessionHandler(srv, conn, &x11Channel{NewChannel: newChan, ctx: ctx}, ctx)
+}
+
+// x11Channel wraps a session's channel to consume its "x11-req" requests, recording them on the session's context to
+// be handled when the session's command starts.
+type x11Channel struct {
+	gossh.NewChannel
+	ctx gliderssh.Context
+}
+
+func (c *x11Channel) Accept() (gossh.Channel, <-chan *gossh.Request, error) {
+	ch, reqs, err := c.NewChannel.Accept()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filtered := make(chan *gossh.Request)
+
+	go func() {
+		defer close(filtered)
+
+		for req := range reqs {
+			if req.Type != RequestX11 {
+				filtered <- req
+
+				continue
+			}
+
+			data := new(modes.X11Request)
+			if err := gossh.Unmarshal(req.Payload, data); err != nil {
+				log.WithError(err).Warn("failed to parse the x11 forwarding request")
+
+				req.Reply(false, nil) //nolint:errcheck
+
+				continue
+			}
+
+			modes.SetX11Requested(c.ctx, data)
+
+			req.Reply(true, nil) //nolint:errcheck
+		}
+	}()
+
+	return ch, filtered, nil
+}
@@ -11,7 +11,6 @@ import (
 
 	resty "github.com/go-resty/resty/v2"
 	"github.com/shellhub-io/shellhub/pkg/models"
-	"github.com/shellhub-io/shellhub/pkg/revdial"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -25,7 +24,7 @@ type publicAPI interface {
 	Endpoints() (*models.Endpoints, error)
 	AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthResponse, error)
 	AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (*models.PublicKeyAuthResponse, error)
-	NewReverseListener(ctx context.Context, token string) (*revdial.Listener, error)
+	NewReverseListener(ctx context.Context, token string) (net.Listener, error)
 }
 
 //go:generate mockery --name=Client --filename=client.go
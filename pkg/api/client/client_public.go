@@ -3,10 +3,10 @@ package client
 import (
 	"context"
 	"errors"
+	"net"
 
 	resty "github.com/go-resty/resty/v2"
 	"github.com/shellhub-io/shellhub/pkg/models"
-	"github.com/shellhub-io/shellhub/pkg/revdial"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -110,7 +110,7 @@ func (c *client) AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (
 // To obtain this listener from the server, the Agent needs to authenticates it using the token provided, and getting a
 // reverse authenticated connection, after that, it dials the server again for a new reverse connection on ShellHub's
 // SSH tunnel list.
-func (c *client) NewReverseListener(ctx context.Context, token string) (*revdial.Listener, error) {
+func (c *client) NewReverseListener(ctx context.Context, token string) (net.Listener, error) {
 	if token == "" {
 		return nil, errors.New("token is empty")
 	}
@@ -7,8 +7,7 @@ import (
 
 	models "github.com/shellhub-io/shellhub/pkg/models"
 	mock "github.com/stretchr/testify/mock"
-
-	revdial "github.com/shellhub-io/shellhub/pkg/revdial"
+	net "net"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -173,19 +172,19 @@ func (_m *Client) ListDevices() ([]models.Device, error) {
 }
 
 // NewReverseListener provides a mock function with given fields: ctx, token
-func (_m *Client) NewReverseListener(ctx context.Context, token string) (*revdial.Listener, error) {
+func (_m *Client) NewReverseListener(ctx context.Context, token string) (net.Listener, error) {
 	ret := _m.Called(ctx, token)
 
-	var r0 *revdial.Listener
+	var r0 net.Listener
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*revdial.Listener, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (net.Listener, error)); ok {
 		return rf(ctx, token)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *revdial.Listener); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) net.Listener); ok {
 		r0 = rf(ctx, token)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*revdial.Listener)
+			r0 = ret.Get(0).(net.Listener)
 		}
 	}
 
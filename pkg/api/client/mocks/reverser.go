@@ -5,8 +5,8 @@ package mocks
 import (
 	context "context"
 
-	revdial "github.com/shellhub-io/shellhub/pkg/revdial"
 	mock "github.com/stretchr/testify/mock"
+	net "net"
 )
 
 // IReverser is an autogenerated mock type for the IReverser type
@@ -29,19 +29,19 @@ func (_m *IReverser) Auth(ctx context.Context, token string) error {
 }
 
 // NewListener provides a mock function with given fields:
-func (_m *IReverser) NewListener() (*revdial.Listener, error) {
+func (_m *IReverser) NewListener() (net.Listener, error) {
 	ret := _m.Called()
 
-	var r0 *revdial.Listener
+	var r0 net.Listener
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*revdial.Listener, error)); ok {
+	if rf, ok := ret.Get(0).(func() (net.Listener, error)); ok {
 		return rf()
 	}
-	if rf, ok := ret.Get(0).(func() *revdial.Listener); ok {
+	if rf, ok := ret.Get(0).(func() net.Listener); ok {
 		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*revdial.Listener)
+			r0 = ret.Get(0).(net.Listener)
 		}
 	}
 
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/gorilla/websocket"
 	"github.com/shellhub-io/shellhub/pkg/revdial"
@@ -15,7 +17,7 @@ import (
 //go:generate mockery --name=IReverser --filename=reverser.go
 type IReverser interface {
 	Auth(ctx context.Context, token string) error
-	NewListener() (*revdial.Listener, error)
+	NewListener() (net.Listener, error)
 }
 
 type Reverser struct {
@@ -24,6 +26,8 @@ type Reverser struct {
 	//
 	// It is used to create the websocket connection to the ShellHub's server.
 	host string
+	// version is the reverse tunnel's version negotiated with the ShellHub's server.
+	version int
 }
 
 var _ IReverser = new(Reverser)
@@ -42,15 +46,17 @@ func (r *Reverser) Auth(ctx context.Context, token string) error {
 	}
 
 	header := http.Header{
-		"Authorization": []string{fmt.Sprintf("Bearer %s", token)},
+		"Authorization":       []string{fmt.Sprintf("Bearer %s", token)},
+		revdial.VersionHeader: []string{strconv.Itoa(revdial.VersionMux)},
 	}
 
-	conn, _, err := DialContext(ctx, uri, header)
+	conn, res, err := DialContext(ctx, uri, header)
 	if err != nil {
 		return err
 	}
 
 	r.conn = conn
+	r.version = revdial.Version(res.Header)
 
 	return nil
 }
@@ -60,11 +66,18 @@ func (r *Reverser) Auth(ctx context.Context, token string) error {
 // It uses the authenticated connection generate by the [Auth] method to create a new reverse listener. Through this
 // connection, the Agent will be able to receive connections from the ShellHub's server. This connections are,
 // essentially, the SSH operations requested by the user.
-func (r *Reverser) NewListener() (*revdial.Listener, error) {
+//
+// When the ShellHub's server supports it, the connections are multiplexed over the authenticated one. Otherwise, each
+// connection is picked up through a new one.
+func (r *Reverser) NewListener() (net.Listener, error) {
 	if r.conn == nil {
 		return nil, errors.New("listener is not authenticated")
 	}
 
+	if r.version >= revdial.VersionMux {
+		return revdial.NewMuxListener(wsconnadapter.New(r.conn))
+	}
+
 	return revdial.NewListener(wsconnadapter.New(r.conn), func(ctx context.Context, path string) (*websocket.Conn, *http.Response, error) {
 		uri, err := url.JoinPath(r.host, path)
 		if err != nil {
@@ -0,0 +1,32 @@
+package internalclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// authAPI defines methods for interacting with authentication-related functionality.
+type authAPI interface {
+	// AuthMFACode validates the TOTP code of the user with the specified ID.
+	// It returns an error if the request fails or if the code is invalid.
+	AuthMFACode(id, code string) error
+}
+
+var ErrMFACodeInvalid = errors.New("the MFA code is invalid")
+
+func (c *client) AuthMFACode(id, code string) error {
+	resp, err := c.http.
+		R().
+		SetBody(map[string]string{"code": code}).
+		Post(fmt.Sprintf("/internal/auth/mfa/%s", id))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ErrMFACodeInvalid
+	}
+
+	return nil
+}
@@ -24,6 +24,9 @@ type Client interface {
 	sessionAPI
 	sshkeyAPI
 	firewallAPI
+	authAPI
+	fileAPI
+	jobAPI
 }
 
 // Ensures the client implements Client.
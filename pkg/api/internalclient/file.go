@@ -0,0 +1,134 @@
+package internalclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// fileAPI defines methods for transferring files to and from the devices through the SSH server.
+type fileAPI interface {
+	// FileList lists the files of a directory on the device.
+	FileList(req *requests.DeviceFile) ([]models.File, error)
+
+	// FileDownload downloads a file from the device. The returned reader must be closed by the caller.
+	FileDownload(req *requests.DeviceFile) (io.ReadCloser, error)
+
+	// FileUpload uploads the content to a file on the device, replacing it when it already exists.
+	FileUpload(req *requests.DeviceFile, content io.Reader) (*models.File, error)
+
+	// FileRemove removes a file, or an empty directory, from the device.
+	FileRemove(req *requests.DeviceFile) error
+}
+
+var (
+	ErrFileForbidden = errors.New("failed to authenticate the user on the device")
+	ErrFileInvalid   = errors.New("failed to transfer the file from the requested path")
+	ErrFileTransfer  = errors.New("failed to transfer the file through the SSH server")
+)
+
+// fileURL returns the URL of the SSH server's files endpoint of the device.
+func fileURL(uid string) string {
+	return fmt.Sprintf("http://ssh:8080/devices/%s/files", uid)
+}
+
+// fileRequest creates a request to the SSH server's files endpoint with the file's path and the user's credentials.
+func fileRequest(req *requests.DeviceFile) *resty.Request {
+	// NOTICE: The default HTTP client retries on server errors, what cannot be done when the file's content is
+	// streamed, so a client without retries is used.
+	return resty.New().
+		R().
+		SetQueryParams(map[string]string{
+			"path":        req.Path,
+			"user":        req.User,
+			"fingerprint": req.Fingerprint,
+		}).
+		SetHeader("X-Device-Password", req.Password).
+		SetHeader("X-Device-Challenge", req.Challenge).
+		SetHeader("X-Device-Signature", req.Signature).
+		SetHeader("X-Device-MFA", req.MFA).
+		SetHeader("X-Username", req.Username)
+}
+
+// fileError returns the error of a failed response from the SSH server's files endpoint.
+func fileError(status int) error {
+	switch status {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ErrFileForbidden
+	case http.StatusBadRequest:
+		return ErrFileInvalid
+	default:
+		return ErrFileTransfer
+	}
+}
+
+func (c *client) FileList(req *requests.DeviceFile) ([]models.File, error) {
+	list := make([]models.File, 0)
+	resp, err := fileRequest(req).
+		SetResult(&list).
+		Get(fileURL(req.UID) + "/list")
+	if err != nil {
+		return nil, errors.Join(ErrConnectionFailed, err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fileError(resp.StatusCode())
+	}
+
+	return list, nil
+}
+
+func (c *client) FileDownload(req *requests.DeviceFile) (io.ReadCloser, error) {
+	resp, err := fileRequest(req).
+		SetDoNotParseResponse(true).
+		Get(fileURL(req.UID))
+	if err != nil {
+		return nil, errors.Join(ErrConnectionFailed, err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		resp.RawBody().Close()
+
+		return nil, fileError(resp.StatusCode())
+	}
+
+	return resp.RawBody(), nil
+}
+
+func (c *client) FileUpload(req *requests.DeviceFile, content io.Reader) (*models.File, error) {
+	file := new(models.File)
+	resp, err := fileRequest(req).
+		SetHeader("Content-Type", "application/octet-stream").
+		SetBody(content).
+		SetResult(file).
+		Put(fileURL(req.UID))
+	if err != nil {
+		return nil, errors.Join(ErrConnectionFailed, err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, fileError(resp.StatusCode())
+	}
+
+	return file, nil
+}
+
+func (c *client) FileRemove(req *requests.DeviceFile) error {
+	resp, err := fileRequest(req).Delete(fileURL(req.UID))
+	if err != nil {
+		return errors.Join(ErrConnectionFailed, err)
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return fileError(resp.StatusCode())
+	}
+
+	return nil
+}
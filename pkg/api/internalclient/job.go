@@ -0,0 +1,79 @@
+package internalclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// jobAPI defines methods for executing the jobs' commands on the devices.
+type jobAPI interface {
+	// JobEnqueue enqueues the job to be executed by the API's workers, what must finish it before the timeout.
+	JobEnqueue(id string, timeout time.Duration) error
+
+	// CommandExecute executes a command on the device, through the SSH server, as the device's user. The command is
+	// killed when the context is cancelled.
+	CommandExecute(ctx context.Context, req *requests.DeviceCommand) (*models.CommandOutput, error)
+}
+
+const TaskJobRun = "api:job"
+
+var (
+	ErrJobQueue         = errors.New("failed to enqueue the job")
+	ErrCommandForbidden = errors.New("failed to authenticate the user on the device")
+	ErrCommandInvalid   = errors.New("the command's request is invalid")
+	ErrCommandFailed    = errors.New("failed to execute the command through the SSH server")
+)
+
+func (c *client) JobEnqueue(id string, timeout time.Duration) error {
+	if c.asynq == nil {
+		return ErrJobQueue
+	}
+
+	// NOTICE: A job must not be retried, as its commands could be executed twice on the devices.
+	if _, err := c.asynq.Enqueue(
+		asynq.NewTask(TaskJobRun, []byte(id)),
+		asynq.Queue("api"),
+		asynq.TaskID(id),
+		asynq.MaxRetry(0),
+		asynq.Timeout(timeout),
+	); err != nil {
+		return errors.Join(ErrJobQueue, err)
+	}
+
+	return nil
+}
+
+func (c *client) CommandExecute(ctx context.Context, req *requests.DeviceCommand) (*models.CommandOutput, error) {
+	output := new(models.CommandOutput)
+
+	// NOTICE: The default HTTP client retries on server errors, what could execute the command twice, so a client
+	// without retries is used.
+	resp, err := resty.New().
+		R().
+		SetContext(ctx).
+		SetBody(req).
+		SetResult(output).
+		Post(fmt.Sprintf("http://ssh:8080/devices/%s/exec", req.UID))
+	if err != nil {
+		return nil, errors.Join(ErrConnectionFailed, err)
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return output, nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, ErrCommandForbidden
+	case http.StatusBadRequest:
+		return nil, ErrCommandInvalid
+	default:
+		return nil, ErrCommandFailed
+	}
+}
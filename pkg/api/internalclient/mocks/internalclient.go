@@ -3,10 +3,17 @@
 package mocks
 
 import (
-	models "github.com/shellhub-io/shellhub/pkg/models"
+	context "context"
+
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 
+	models "github.com/shellhub-io/shellhub/pkg/models"
+
 	requests "github.com/shellhub-io/shellhub/pkg/api/requests"
+
+	time "time"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -14,6 +21,24 @@ type Client struct {
 	mock.Mock
 }
 
+// AuthMFACode provides a mock function with given fields: id, code
+func (_m *Client) AuthMFACode(id string, code string) error {
+	ret := _m.Called(id, code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthMFACode")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(id, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // BillingEvaluate provides a mock function with given fields: tenantID
 func (_m *Client) BillingEvaluate(tenantID string) (*models.BillingEvaluation, int, error) {
 	ret := _m.Called(tenantID)
@@ -79,6 +104,36 @@ func (_m *Client) BillingReport(tenant string, action string) (int, error) {
 	return r0, r1
 }
 
+// CommandExecute provides a mock function with given fields: ctx, req
+func (_m *Client) CommandExecute(ctx context.Context, req *requests.DeviceCommand) (*models.CommandOutput, error) {
+	ret := _m.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommandExecute")
+	}
+
+	var r0 *models.CommandOutput
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCommand) (*models.CommandOutput, error)); ok {
+		return rf(ctx, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *requests.DeviceCommand) *models.CommandOutput); ok {
+		r0 = rf(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CommandOutput)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *requests.DeviceCommand) error); ok {
+		r1 = rf(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePrivateKey provides a mock function with given fields:
 func (_m *Client) CreatePrivateKey() (*models.PrivateKey, error) {
 	ret := _m.Called()
@@ -205,17 +260,125 @@ func (_m *Client) EvaluateKey(fingerprint string, dev *models.Device, username s
 	return r0, r1
 }
 
-// FinishSession provides a mock function with given fields: uid
-func (_m *Client) FinishSession(uid string) []error {
-	ret := _m.Called(uid)
+// FileDownload provides a mock function with given fields: req
+func (_m *Client) FileDownload(req *requests.DeviceFile) (io.ReadCloser, error) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileDownload")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*requests.DeviceFile) (io.ReadCloser, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(*requests.DeviceFile) io.ReadCloser); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*requests.DeviceFile) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FileList provides a mock function with given fields: req
+func (_m *Client) FileList(req *requests.DeviceFile) ([]models.File, error) {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileList")
+	}
+
+	var r0 []models.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*requests.DeviceFile) ([]models.File, error)); ok {
+		return rf(req)
+	}
+	if rf, ok := ret.Get(0).(func(*requests.DeviceFile) []models.File); ok {
+		r0 = rf(req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*requests.DeviceFile) error); ok {
+		r1 = rf(req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FileRemove provides a mock function with given fields: req
+func (_m *Client) FileRemove(req *requests.DeviceFile) error {
+	ret := _m.Called(req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileRemove")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*requests.DeviceFile) error); ok {
+		r0 = rf(req)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FileUpload provides a mock function with given fields: req, content
+func (_m *Client) FileUpload(req *requests.DeviceFile, content io.Reader) (*models.File, error) {
+	ret := _m.Called(req, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileUpload")
+	}
+
+	var r0 *models.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*requests.DeviceFile, io.Reader) (*models.File, error)); ok {
+		return rf(req, content)
+	}
+	if rf, ok := ret.Get(0).(func(*requests.DeviceFile, io.Reader) *models.File); ok {
+		r0 = rf(req, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*requests.DeviceFile, io.Reader) error); ok {
+		r1 = rf(req, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FinishSession provides a mock function with given fields: uid, reason
+func (_m *Client) FinishSession(uid string, reason string) []error {
+	ret := _m.Called(uid, reason)
 
 	if len(ret) == 0 {
 		panic("no return value specified for FinishSession")
 	}
 
 	var r0 []error
-	if rf, ok := ret.Get(0).(func(string) []error); ok {
-		r0 = rf(uid)
+	if rf, ok := ret.Get(0).(func(string, string) []error); ok {
+		r0 = rf(uid, reason)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]error)
@@ -333,6 +496,24 @@ func (_m *Client) GetPublicKey(fingerprint string, tenant string) (*models.Publi
 	return r0, r1
 }
 
+// JobEnqueue provides a mock function with given fields: id, timeout
+func (_m *Client) JobEnqueue(id string, timeout time.Duration) error {
+	ret := _m.Called(id, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobEnqueue")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration) error); ok {
+		r0 = rf(id, timeout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // KeepAliveSession provides a mock function with given fields: uid
 func (_m *Client) KeepAliveSession(uid string) []error {
 	ret := _m.Called(uid)
@@ -483,6 +664,24 @@ func (_m *Client) SessionAsAuthenticated(uid string) []error {
 	return r0
 }
 
+// SessionClose provides a mock function with given fields: uid, device
+func (_m *Client) SessionClose(uid string, device string) error {
+	ret := _m.Called(uid, device)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionClose")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(uid, device)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SessionCreate provides a mock function with given fields: session
 func (_m *Client) SessionCreate(session requests.SessionCreate) error {
 	ret := _m.Called(session)
@@ -501,6 +700,24 @@ func (_m *Client) SessionCreate(session requests.SessionCreate) error {
 	return r0
 }
 
+// SessionEvent provides a mock function with given fields: uid, event
+func (_m *Client) SessionEvent(uid string, event *models.SessionEvent) error {
+	ret := _m.Called(uid, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *models.SessionEvent) error); ok {
+		r0 = rf(uid, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewClient(t interface {
@@ -1,7 +1,12 @@
 package internalclient
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
 
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -17,16 +22,24 @@ type sessionAPI interface {
 	// It returns a slice of errors encountered during the operation.
 	SessionAsAuthenticated(uid string) []error
 
-	// FinishSession finishes the session with the specified uid.
+	// FinishSession finishes the session with the specified uid. When the session was disconnected by ShellHub, the
+	// reason is stored with it; otherwise, reason must be empty.
 	// It returns a slice of errors encountered during the operation.
-	FinishSession(uid string) []error
+	FinishSession(uid string, reason string) []error
 
 	// KeepAliveSession sends a keep-alive signal for the session with the specified uid.
 	// It returns a slice of errors encountered during the operation.
 	KeepAliveSession(uid string) []error
 
+	// SessionEvent registers an event what happened during the session with the specified uid.
+	SessionEvent(uid string, event *models.SessionEvent) error
+
 	// RecordSession records a session with the provided session information and record URL.
 	RecordSession(session *models.SessionRecorded, recordURL string) error
+
+	// SessionClose asks the SSH server to close the session with the specified uid, held by the specified device. The
+	// session is terminated on the device and the client's connection is dropped.
+	SessionClose(uid string, device string) error
 }
 
 func (c *client) SessionCreate(session requests.SessionCreate) error {
@@ -54,11 +67,14 @@ func (c *client) SessionAsAuthenticated(uid string) []error {
 	return errors
 }
 
-func (c *client) FinishSession(uid string) []error {
+func (c *client) FinishSession(uid string, reason string) []error {
 	var errors []error
 
 	_, err := c.http.
 		R().
+		SetBody(map[string]string{
+			"reason": reason,
+		}).
 		Post(fmt.Sprintf("/internal/sessions/%s/finish", uid))
 	if err != nil {
 		errors = append(errors, err)
@@ -80,6 +96,18 @@ func (c *client) KeepAliveSession(uid string) []error {
 	return errors
 }
 
+func (c *client) SessionEvent(uid string, event *models.SessionEvent) error {
+	_, err := c.http.
+		R().
+		SetBody(map[string]interface{}{
+			"type": event.Type,
+			"data": event.Data,
+		}).
+		Post(fmt.Sprintf("/internal/sessions/%s/events", uid))
+
+	return err
+}
+
 func (c *client) RecordSession(session *models.SessionRecorded, recordURL string) error {
 	_, err := c.http.
 		R().
@@ -88,3 +116,33 @@ func (c *client) RecordSession(session *models.SessionRecorded, recordURL string
 
 	return err
 }
+
+var ErrSessionClose = errors.New("failed to close the session on the SSH server")
+
+func (c *client) SessionClose(uid string, device string) error {
+	// NOTICE: The default HTTP client retries forever on server errors, what would block the caller when the device is
+	// unreachable. Closing a session is done only once, so we retry only network errors for a few times.
+	local := resty.New()
+	local.AddRetryCondition(func(_ *resty.Response, err error) bool {
+		_, ok := err.(net.Error)
+
+		return ok
+	})
+
+	resp, err := local.
+		SetRetryCount(3).
+		R().
+		SetBody(map[string]string{
+			"device": device,
+		}).
+		Post(fmt.Sprintf("http://ssh:8080/sessions/%s/close", uid))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return ErrSessionClose
+	}
+
+	return nil
+}
@@ -6,6 +6,13 @@ type AuthTokenGet struct {
 	MFA bool `json:"mfa"`
 }
 
+// AuthMFACode is the structure to represent the request data for the internal endpoint that validates a user's TOTP
+// code.
+type AuthMFACode struct {
+	UserParam
+	Code string `json:"code" validate:"required,len=6,numeric"`
+}
+
 // AuthTokenSwap is the structure to represent the request data for swap auth token endpoint.
 type AuthTokenSwap struct {
 	TenantParam
@@ -28,10 +28,11 @@ type DeviceOffline struct {
 
 // DeviceLookup is the structure to represent the request data for lookup device endpoint.
 type DeviceLookup struct {
-	Domain    string `query:"domain" validate:"required"`
+	Domain    string `query:"domain" validate:"required_without=User"`
 	Name      string `query:"name" validate:"required"`
 	Username  string `query:"username" validate:""`
 	IPAddress string `query:"ip_address" validate:""`
+	User      string `query:"user" validate:""`
 }
 
 // DeviceStatus is the structure to represent the request data for update device status to pending endpoint.
@@ -63,6 +64,12 @@ type DeviceUpdateTag struct {
 	Tags []string `json:"tags" validate:"required,min=0,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
 }
 
+// DeviceUpdateAliases is the structure to represent the request data for device update aliases endpoint.
+type DeviceUpdateAliases struct {
+	DeviceParam
+	Aliases []string `json:"aliases" validate:"max=5,unique,dive,device_name"`
+}
+
 type DeviceIdentity struct {
 	MAC string `json:"mac"`
 }
@@ -83,6 +90,7 @@ type DeviceAuth struct {
 	Identity  *DeviceIdentity `json:"identity,omitempty" validate:"required_without=Hostname,omitempty"`
 	PublicKey string          `json:"public_key" validate:"required"`
 	TenantID  string          `json:"tenant_id" validate:"required"`
+	HostKey   string          `json:"host_key,omitempty"`
 }
 
 type DeviceGetPublicURL struct {
@@ -99,3 +107,27 @@ type DeviceUpdate struct {
 type DevicePublicURLAddress struct {
 	PublicURLAddress string `param:"address" validate:"required"`
 }
+
+// DeviceFile is the structure to represent the request data for the device's files endpoints.
+type DeviceFile struct {
+	DeviceParam
+	// Path is the file's path on the device, relative to the user's home directory.
+	Path string `query:"path" validate:"required"`
+	// User is the device's user who accesses the file.
+	User string `query:"user" validate:"required"`
+	// Fingerprint is the fingerprint of a public key stored on the namespace, authenticating the user instead of a
+	// password.
+	Fingerprint string `query:"fingerprint" validate:"required_without=Password"`
+	// Challenge is a challenge issued by the API to transfer files on the device, accepted only once.
+	Challenge string `header:"X-Device-Challenge" validate:"required_with=Fingerprint"`
+	// Signature is the challenge signed by the public key's private key, encoded in base64, what proves the client
+	// holds it.
+	Signature string `header:"X-Device-Signature" validate:"required_with=Fingerprint"`
+	// MFA is the TOTP code of the ShellHub's user who created the public key, required when the device's namespace
+	// requires MFA.
+	MFA string `header:"X-Device-MFA"`
+	// Password is the user's password on the device.
+	Password string `header:"X-Device-Password" validate:"required_without=Fingerprint"`
+	// Username is the name of the ShellHub's user who requested the transfer, set by the gateway.
+	Username string `header:"X-Username"`
+}
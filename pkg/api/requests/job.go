@@ -0,0 +1,88 @@
+package requests
+
+import (
+	"github.com/shellhub-io/shellhub/pkg/api/query"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// JobParam is a structure to represent and validate a job ID as path param.
+type JobParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// JobCreate is the structure to represent the request data for create job endpoint.
+type JobCreate struct {
+	Command  string             `json:"command" validate:"required"`
+	Selector models.JobSelector `json:"selector"`
+	// User is the device's user that executes the command.
+	User string `json:"user" validate:"required"`
+	// Fingerprint is the fingerprint of the namespace's public key used to authenticate the user on the devices.
+	Fingerprint string `json:"fingerprint" validate:"required"`
+	// Signature is the canonical form of the job's [models.JobSignedData] signed by the public key's private key,
+	// encoded in base64, what proves the client holds it.
+	Signature string `json:"signature" validate:"required"`
+	// ExpiresAt is the Unix time, signed with the job, after which the signature is rejected.
+	ExpiresAt   int64 `json:"expires_at" validate:"required"`
+	Concurrency int   `json:"concurrency" validate:"omitempty,min=1,max=100"`
+	// Timeout is the number of seconds the command can run on each device.
+	Timeout int `json:"timeout" validate:"omitempty,min=1,max=86400"`
+}
+
+// JobGet is the structure to represent the request data for get job endpoint.
+type JobGet struct {
+	JobParam
+}
+
+// JobCancel is the structure to represent the request data for cancel job endpoint.
+type JobCancel struct {
+	JobParam
+}
+
+// DeviceCommand is the structure to represent the request data for the SSH server's endpoint that executes a command
+// on a device.
+type DeviceCommand struct {
+	DeviceParam
+	User        string `json:"user"`
+	Fingerprint string `json:"fingerprint"`
+	// SignedData is the canonical form of the job's [models.JobSignedData], what the signature signs.
+	SignedData string `json:"signed_data"`
+	// Signature is the signed data signed by the public key's private key, encoded in base64.
+	Signature string `json:"signature"`
+	Command   string `json:"command"`
+	// Timeout is the number of seconds the command can run. Zero doesn't limit it.
+	Timeout int `json:"timeout"`
+}
+
+// JobScheduleCreate is the structure to represent the request data for create job schedule endpoint.
+type JobScheduleCreate struct {
+	Name string `json:"name" validate:"required,max=64"`
+	// Cron is the cron expression, evaluated in UTC, of the times the command is executed.
+	Cron           string `json:"cron" validate:"required"`
+	RunOnReconnect bool   `json:"run_on_reconnect"`
+	JobCreate
+}
+
+// JobScheduleUpdate is the structure to represent the request data for update job schedule endpoint.
+type JobScheduleUpdate struct {
+	JobParam
+	Name           *string `json:"name" validate:"omitempty,max=64"`
+	Cron           *string `json:"cron"`
+	Enabled        *bool   `json:"enabled"`
+	RunOnReconnect *bool   `json:"run_on_reconnect"`
+}
+
+// JobScheduleGet is the structure to represent the request data for get job schedule endpoint.
+type JobScheduleGet struct {
+	JobParam
+}
+
+// JobScheduleDelete is the structure to represent the request data for delete job schedule endpoint.
+type JobScheduleDelete struct {
+	JobParam
+}
+
+// JobScheduleRuns is the structure to represent the request data for list job schedule's runs endpoint.
+type JobScheduleRuns struct {
+	JobParam
+	query.Paginator
+}
@@ -1,5 +1,7 @@
 package requests
 
+import "github.com/shellhub-io/shellhub/pkg/models"
+
 // TenantParam is a structure to represent and validate a namespace tenant as path param.
 type TenantParam struct {
 	Tenant string `param:"tenant" validate:"required,uuid"`
@@ -36,8 +38,17 @@ type NamespaceEdit struct {
 	TenantParam
 	Name     string `json:"name" validate:"omitempty,hostname_rfc1123,excludes=."`
 	Settings struct {
-		SessionRecord          *bool   `json:"session_record" validate:"omitempty"`
-		ConnectionAnnouncement *string `json:"connection_announcement" validate:"omitempty,min=0,max=127"`
+		SessionRecord          *bool                        `json:"session_record" validate:"omitempty"`
+		ConnectionAnnouncement *string                      `json:"connection_announcement" validate:"omitempty,min=0,max=127"`
+		SessionIdleTimeout     *int                         `json:"session_idle_timeout" validate:"omitempty,min=0,max=10080"`
+		SessionMaxDuration     *int                         `json:"session_max_duration" validate:"omitempty,min=0,max=10080"`
+		ReversePortForwarding  *bool                        `json:"reverse_port_forwarding" validate:"omitempty"`
+		PortForwarding         *models.PortForwardingPolicy `json:"port_forwarding" validate:"omitempty"`
+		AgentForwarding        *bool                        `json:"agent_forwarding" validate:"omitempty"`
+		X11Forwarding          *bool                        `json:"x11_forwarding" validate:"omitempty"`
+		UserCA                 *models.UserCA               `json:"user_ca" validate:"omitempty"`
+		MFARequired            *bool                        `json:"mfa_required" validate:"omitempty"`
+		JobRetention           *int                         `json:"job_retention" validate:"omitempty,min=0,max=3650"`
 	} `json:"settings"`
 }
 
@@ -66,3 +77,12 @@ type SessionEditRecordStatus struct {
 	TenantParam
 	SessionRecord bool `json:"session_record"`
 }
+
+// SSHCertificateCreate is the structure to represent the request data for the SSH certificate issuance endpoint.
+type SSHCertificateCreate struct {
+	TenantParam
+	// PublicKey is the member's public key, in the authorized_keys format, to be certified.
+	PublicKey string `json:"public_key" validate:"required"`
+	// Duration is the number of minutes the certificate is valid. When zero, the default duration is used.
+	Duration int `json:"duration" validate:"omitempty,min=1,max=1440"`
+}
@@ -28,6 +28,7 @@ type PublicKeyCreate struct {
 	Username    string          `json:"username" validate:"required,regexp"`
 	TenantID    string          `json:"-"`
 	Fingerprint string          `json:"-"`
+	CreatedBy   string          `json:"-"`
 }
 
 // PublicKeyUpdate is the structure to represent the request data for update public key endpoint.
@@ -30,9 +30,23 @@ type SessionCreate struct {
 // SessionFinish is the structure to represent the request data for finish session endpoint.
 type SessionFinish struct {
 	SessionIDParam
+	// Reason is the reason why the session was disconnected by ShellHub, if it was.
+	Reason string `json:"reason" validate:"omitempty,oneof=idle_timeout max_duration closed shutdown"`
 }
 
 // SessionFinish is the structure to represent the request data for keep alive session endpoint.
 type SessionKeepAlive struct {
 	SessionIDParam
 }
+
+// SessionEvent is the structure to represent the request data for register an event on session endpoint.
+type SessionEvent struct {
+	SessionIDParam
+	Type string            `json:"type" validate:"required"`
+	Data map[string]string `json:"data"`
+}
+
+// SessionClose is the structure to represent the request data for close an active session endpoint.
+type SessionClose struct {
+	SessionIDParam
+}
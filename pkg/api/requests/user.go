@@ -15,6 +15,12 @@ type UserDataUpdate struct {
 	Email    string `json:"email" validate:"required,email"`
 }
 
+// UserPreferencesUpdate is the structure to represent the request body for the update user preferences endpoint.
+type UserPreferencesUpdate struct {
+	UserParam
+	DefaultNamespace string `json:"default_namespace" validate:"omitempty,uuid"`
+}
+
 // UserPasswordUpdate is the structure to represent the request body for the update user password endpoint.
 type UserPasswordUpdate struct {
 	UserParam
@@ -0,0 +1,13 @@
+package responses
+
+import "time"
+
+// SSHCertificateCreate is the structure to represent the response data for the SSH certificate issuance endpoint.
+type SSHCertificateCreate struct {
+	// Certificate is the OpenSSH user certificate, in the authorized_keys format.
+	Certificate string    `json:"certificate"`
+	Serial      uint64    `json:"serial"`
+	Principals  []string  `json:"principals"`
+	ValidAfter  time.Time `json:"valid_after"`
+	ValidBefore time.Time `json:"valid_before"`
+}
@@ -0,0 +1,9 @@
+package responses
+
+import "github.com/shellhub-io/shellhub/pkg/models"
+
+// Job is the structure to represent the response data for the get job endpoint, with the results of its devices.
+type Job struct {
+	models.Job
+	Results []models.JobResult `json:"results"`
+}
@@ -9,4 +9,7 @@ type Cache interface {
 	Get(ctx context.Context, key string, value interface{}) error
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
+	// Increment atomically increments the counter stored on key, returning its new value. A counter is created at
+	// zero, expiring after the ttl, when the key doesn't exist.
+	Increment(ctx context.Context, key string, ttl time.Duration) (int64, error)
 }
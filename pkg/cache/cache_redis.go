@@ -8,8 +8,18 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
+// increment increments the counter on KEYS[1], setting its expiration, in milliseconds, to ARGV[1] when it's created.
+var increment = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+if count == 1 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+end
+return count
+`)
+
 type redisCache struct {
-	cache *rediscache.Cache
+	client *redis.Client
+	cache  *rediscache.Cache
 }
 
 var _ Cache = &redisCache{}
@@ -21,9 +31,12 @@ func NewRedisCache(uri string) (Cache, error) {
 		return nil, err
 	}
 
+	client := redis.NewClient(opt)
+
 	return &redisCache{
+		client: client,
 		cache: rediscache.New(&rediscache.Options{
-			Redis: redis.NewClient(opt),
+			Redis: client,
 		}),
 	}, nil
 }
@@ -52,3 +65,9 @@ func (c *redisCache) Delete(ctx context.Context, key string) error {
 
 	return c.cache.Delete(ctx, key)
 }
+
+// Increment atomically increments the counter stored on key, setting its expire time when it's created.
+// NOTE: the counter is stored as a plain integer, so it cannot be read through Get.
+func (c *redisCache) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	return increment.Run(ctx, c.client, []string{key}, ttl.Milliseconds()).Int64()
+}
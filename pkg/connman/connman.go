@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"net"
+	"net/url"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/revdial"
 	"github.com/shellhub-io/shellhub/pkg/wsconnadapter"
@@ -12,23 +14,56 @@ import (
 
 var ErrNoConnection = errors.New("no connection")
 
+// registryTTL is how long a connection is recorded on the [Registry] without a keep alive. It outlasts some keep alive
+// intervals, so a slow pong doesn't hide the connection from the other instances.
+const registryTTL = 2 * time.Minute
+
+// Dialer creates connections to a device through its reverse tunnel.
+type Dialer interface {
+	Dial(ctx context.Context) (net.Conn, error)
+	Done() <-chan struct{}
+	Close() error
+}
+
 type ConnectionManager struct {
-	dialers                 *SyncSliceMap
-	DialerDoneCallback      func(string, *revdial.Dialer)
-	DialerKeepAliveCallback func(string, *revdial.Dialer)
+	dialers *SyncSliceMap
+	// DialerPath is the path where the devices pick up the connections dialed to them.
+	DialerPath              string
+	DialerDoneCallback      func(string, Dialer)
+	DialerKeepAliveCallback func(string, Dialer)
+	// Registry shares the connections held by this instance, identified by Instance, with the other ones. When nil,
+	// the connections are only known locally.
+	Registry Registry
+	Instance string
 }
 
 func New() *ConnectionManager {
 	return &ConnectionManager{
-		dialers: &SyncSliceMap{},
-		DialerDoneCallback: func(string, *revdial.Dialer) {
+		dialers:    &SyncSliceMap{},
+		DialerPath: "/ssh/revdial",
+		DialerDoneCallback: func(string, Dialer) {
 		},
 	}
 }
 
+// Set stores the device's connection, where each connection to the device is picked up through a new request.
 func (m *ConnectionManager) Set(key string, conn *wsconnadapter.Adapter) {
-	dialer := revdial.NewDialer(conn, "/ssh/revdial")
+	m.set(key, conn, revdial.NewDialer(conn, m.DialerPath+"?"+KeyParam+"="+url.QueryEscape(key)))
+}
+
+// SetMux stores the device's connection, where each connection to the device is a stream multiplexed over it.
+func (m *ConnectionManager) SetMux(key string, conn *wsconnadapter.Adapter) error {
+	dialer, err := revdial.NewMuxDialer(conn)
+	if err != nil {
+		return err
+	}
+
+	m.set(key, conn, dialer)
 
+	return nil
+}
+
+func (m *ConnectionManager) set(key string, conn *wsconnadapter.Adapter, dialer Dialer) {
 	m.dialers.Store(key, dialer)
 
 	if size := m.dialers.Size(key); size > 1 {
@@ -38,6 +73,7 @@ func (m *ConnectionManager) Set(key string, conn *wsconnadapter.Adapter) {
 		}).Warning("Multiple connections stored for the same identifier.")
 	}
 
+	m.register(key)
 	m.DialerKeepAliveCallback(key, dialer)
 
 	// Start the ping loop and get the channel for pong responses
@@ -47,11 +83,16 @@ func (m *ConnectionManager) Set(key string, conn *wsconnadapter.Adapter) {
 		for {
 			select {
 			case <-pong:
+				m.register(key)
 				m.DialerKeepAliveCallback(key, dialer)
 
 				continue
 			case <-dialer.Done():
 				m.dialers.Delete(key, dialer)
+				if m.dialers.Size(key) == 0 {
+					m.unregister(key)
+				}
+
 				m.DialerDoneCallback(key, dialer)
 
 				return
@@ -73,5 +114,49 @@ func (m *ConnectionManager) Dial(ctx context.Context, key string) (net.Conn, err
 		}).Warning("Multiple connections found for the same identifier during reverse tunnel dialing.")
 	}
 
-	return dialer.(*revdial.Dialer).Dial(ctx)
+	return dialer.(Dialer).Dial(ctx)
+}
+
+// Locate returns the instance that holds the connection of the key when it isn't held by this one. It returns
+// [ErrNoConnection] when there is no [Registry] or no other instance holds the connection.
+func (m *ConnectionManager) Locate(ctx context.Context, key string) (string, error) {
+	if m.Registry == nil {
+		return "", ErrNoConnection
+	}
+
+	instance, err := m.Registry.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+
+	// NOTICE: A record pointing to this instance, for a connection that isn't here, is a leftover of a connection
+	// that is gone.
+	if instance == m.Instance {
+		return "", ErrNoConnection
+	}
+
+	return instance, nil
+}
+
+// register records, on the [Registry], that this instance holds the connection of the key.
+func (m *ConnectionManager) register(key string) {
+	if m.Registry == nil {
+		return
+	}
+
+	if err := m.Registry.Set(context.Background(), key, m.Instance, registryTTL); err != nil {
+		logrus.WithError(err).WithField("key", key).Error("failed to register the connection")
+	}
+}
+
+// unregister removes, from the [Registry], the record of the connection of the key when it is still held by this
+// instance.
+func (m *ConnectionManager) unregister(key string) {
+	if m.Registry == nil {
+		return
+	}
+
+	if err := m.Registry.Delete(context.Background(), key, m.Instance); err != nil {
+		logrus.WithError(err).WithField("key", key).Error("failed to unregister the connection")
+	}
 }
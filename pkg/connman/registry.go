@@ -0,0 +1,21 @@
+package connman
+
+import (
+	"context"
+	"time"
+)
+
+// KeyParam is the query parameter, added to the reverse dialer's pick up path, with the key of its connection. It lets
+// an instance that receives a pick up for a connection held by another one to find it through the [Registry].
+const KeyParam = "connman.key"
+
+// Registry records which instance holds the connection of each key, sharing the connections among the instances of a
+// horizontally scaled service.
+type Registry interface {
+	// Set records that the instance holds the connection of the key for, at most, the ttl.
+	Set(ctx context.Context, key, instance string, ttl time.Duration) error
+	// Get returns the instance that holds the connection of the key, or [ErrNoConnection] when none holds it.
+	Get(ctx context.Context, key string) (string, error)
+	// Delete removes the record of the key only when it is still held by the instance.
+	Delete(ctx context.Context, key, instance string) error
+}
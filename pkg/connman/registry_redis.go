@@ -0,0 +1,55 @@
+package connman
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// redisRegistryPrefix is the prefix of the keys where the registry records are stored.
+const redisRegistryPrefix = "connman/"
+
+// redisRegistryDelete deletes a record only when it still holds the instance, avoiding to remove the record of a
+// connection that was reestablished on another instance.
+var redisRegistryDelete = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+
+return 0
+`)
+
+type redisRegistry struct {
+	client *redis.Client
+}
+
+var _ Registry = &redisRegistry{}
+
+// NewRedisRegistry creates a [Registry] stored on the Redis at the uri.
+func NewRedisRegistry(uri string) (Registry, error) {
+	opt, err := redis.ParseURL(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	return &redisRegistry{client: redis.NewClient(opt)}, nil
+}
+
+func (r *redisRegistry) Set(ctx context.Context, key, instance string, ttl time.Duration) error {
+	return r.client.Set(ctx, redisRegistryPrefix+key, instance, ttl).Err()
+}
+
+func (r *redisRegistry) Get(ctx context.Context, key string) (string, error) {
+	instance, err := r.client.Get(ctx, redisRegistryPrefix+key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrNoConnection
+	}
+
+	return instance, err
+}
+
+func (r *redisRegistry) Delete(ctx context.Context, key, instance string) error {
+	return redisRegistryDelete.Run(ctx, r.client, []string{redisRegistryPrefix + key}, instance).Err()
+}
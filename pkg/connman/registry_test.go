@@ -0,0 +1,82 @@
+package connman
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryRegistry is a [Registry] kept in memory.
+type memoryRegistry map[string]string
+
+func (r memoryRegistry) Set(_ context.Context, key, instance string, _ time.Duration) error {
+	r[key] = instance
+
+	return nil
+}
+
+func (r memoryRegistry) Get(_ context.Context, key string) (string, error) {
+	instance, ok := r[key]
+	if !ok {
+		return "", ErrNoConnection
+	}
+
+	return instance, nil
+}
+
+func (r memoryRegistry) Delete(_ context.Context, key, instance string) error {
+	if r[key] == instance {
+		delete(r, key)
+	}
+
+	return nil
+}
+
+func TestLocate(t *testing.T) {
+	cases := []struct {
+		title    string
+		registry Registry
+		key      string
+		expected string
+		err      error
+	}{
+		{
+			title:    "fails when there is no registry",
+			registry: nil,
+			key:      "device",
+			err:      ErrNoConnection,
+		},
+		{
+			title:    "fails when no instance holds the connection",
+			registry: memoryRegistry{},
+			key:      "device",
+			err:      ErrNoConnection,
+		},
+		{
+			title:    "fails when the record points to this instance",
+			registry: memoryRegistry{"device": "ssh-1:8080"},
+			key:      "device",
+			err:      ErrNoConnection,
+		},
+		{
+			title:    "succeeds when another instance holds the connection",
+			registry: memoryRegistry{"device": "ssh-2:8080"},
+			key:      "device",
+			expected: "ssh-2:8080",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			manager := New()
+			manager.Registry = tc.registry
+			manager.Instance = "ssh-1:8080"
+
+			instance, err := manager.Locate(context.Background(), tc.key)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, instance)
+		})
+	}
+}
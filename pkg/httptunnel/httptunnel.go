@@ -3,15 +3,23 @@ package httptunnel
 import (
 	"bufio"
 	"context"
+	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
 	"github.com/shellhub-io/shellhub/pkg/connman"
 	"github.com/shellhub-io/shellhub/pkg/revdial"
 	"github.com/shellhub-io/shellhub/pkg/wsconnadapter"
+	log "github.com/sirupsen/logrus"
 )
 
 var upgrader = websocket.Upgrader{
@@ -26,11 +34,21 @@ var upgrader = websocket.Upgrader{
 const (
 	DefaultConnectionURL = "/connection"
 	DefaultRevdialURL    = "/revdial"
+	DefaultForwardURL    = "/internal/tunnel/:id"
 )
 
+// forwardProtocol is the protocol which the connection to a device is upgraded to when it is forwarded from the
+// instance that holds it.
+const forwardProtocol = "shellhub-tunnel"
+
+var ErrForwardFailed = errors.New("failed to forward the connection from the instance that holds it")
+
 type Tunnel struct {
-	ConnectionPath    string
-	DialerPath        string
+	ConnectionPath string
+	DialerPath     string
+	// ForwardPath is the path where the other instances reach the connections held by this one. It isn't meant to be
+	// exposed outside the instances' network.
+	ForwardPath       string
 	ConnectionHandler func(*http.Request) (string, error)
 	CloseHandler      func(string)
 	KeepAliveHandler  func(string)
@@ -43,6 +61,7 @@ func NewTunnel(connectionPath, dialerPath string) *Tunnel {
 	tunnel := &Tunnel{
 		ConnectionPath: connectionPath,
 		DialerPath:     dialerPath,
+		ForwardPath:    DefaultForwardURL,
 		ConnectionHandler: func(r *http.Request) (string, error) {
 			panic("ConnectionHandler not implemented")
 		},
@@ -55,22 +74,44 @@ func NewTunnel(connectionPath, dialerPath string) *Tunnel {
 		online:  make(chan bool),
 	}
 
-	tunnel.connman.DialerDoneCallback = func(id string, _ *revdial.Dialer) {
+	tunnel.connman.DialerPath = dialerPath
+
+	tunnel.connman.DialerDoneCallback = func(id string, _ connman.Dialer) {
+		// NOTICE: When the device has reconnected to another instance, it is still online.
+		if _, err := tunnel.connman.Locate(context.Background(), id); err == nil {
+			return
+		}
+
 		tunnel.CloseHandler(id)
 	}
 
-	tunnel.connman.DialerKeepAliveCallback = func(id string, _ *revdial.Dialer) {
+	tunnel.connman.DialerKeepAliveCallback = func(id string, _ connman.Dialer) {
 		tunnel.KeepAliveHandler(id)
 	}
 
 	return tunnel
 }
 
+// SetRegistry shares the connections of the tunnel among the instances of a horizontally scaled service through the
+// registry, where this instance is known by its address, reachable by the other ones.
+func (t *Tunnel) SetRegistry(registry connman.Registry, instance string) {
+	t.connman.Registry = registry
+	t.connman.Instance = instance
+}
+
 func (t *Tunnel) Router() http.Handler {
 	e := echo.New()
 
 	e.GET(t.ConnectionPath, func(c echo.Context) error {
-		conn, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
+		// NOTICE: The devices that support it get their connections multiplexed over this one. The older ones keep
+		// picking up each connection through a new request.
+		header := http.Header{}
+		mux := revdial.Version(c.Request().Header) >= revdial.VersionMux
+		if mux {
+			header.Set(revdial.VersionHeader, strconv.Itoa(revdial.VersionMux))
+		}
+
+		conn, err := upgrader.Upgrade(c.Response(), c.Request(), header)
 		if err != nil {
 			return c.String(http.StatusInternalServerError, err.Error())
 		}
@@ -82,22 +123,165 @@ func (t *Tunnel) Router() http.Handler {
 			return c.String(http.StatusBadRequest, err.Error())
 		}
 
-		t.connman.Set(id, wsconnadapter.New(conn))
+		if !mux {
+			t.connman.Set(id, wsconnadapter.New(conn))
+
+			return nil
+		}
+
+		if err := t.connman.SetMux(id, wsconnadapter.New(conn)); err != nil {
+			log.WithError(err).WithField("id", id).Error("failed to multiplex the device's connection")
+
+			conn.Close()
+		}
 
 		return nil
 	})
 
-	e.GET(t.DialerPath, echo.WrapHandler(revdial.ConnHandler(upgrader)))
+	handler := revdial.ConnHandler(upgrader)
+	e.GET(t.DialerPath, func(c echo.Context) error {
+		// NOTICE: The device picks up the connection through any instance, so, when the dialer isn't from this one,
+		// the pick up is proxied to the instance that holds the device's connection.
+		if !revdial.Registered(c.Request()) {
+			if instance, err := t.connman.Locate(c.Request().Context(), c.QueryParam(connman.KeyParam)); err == nil {
+				httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: instance}).ServeHTTP(c.Response(), c.Request())
+
+				return nil
+			}
+		}
+
+		handler.ServeHTTP(c.Response(), c.Request())
+
+		return nil
+	})
+
+	e.GET(t.ForwardPath, func(c echo.Context) error {
+		// NOTICE: Only the connections held by this instance are forwarded, avoiding loops between instances.
+		in, err := t.connman.Dial(c.Request().Context(), c.Param("id"))
+		if err != nil {
+			return c.String(http.StatusNotFound, err.Error())
+		}
+
+		defer in.Close()
+
+		out, buffer, err := http.NewResponseController(c.Response()).Hijack()
+		if err != nil {
+			return c.String(http.StatusInternalServerError, err.Error())
+		}
+
+		defer out.Close()
+
+		if _, err := fmt.Fprintf(out, "HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: %s\r\n\r\n", forwardProtocol); err != nil {
+			return nil
+		}
+
+		done := make(chan struct{}, 2)
+		go func() {
+			io.Copy(in, buffer) // nolint:errcheck
+			done <- struct{}{}
+		}()
+
+		go func() {
+			io.Copy(out, in) // nolint:errcheck
+			done <- struct{}{}
+		}()
+
+		<-done
+
+		return nil
+	})
 
 	return e
 }
 
+// Dial connects to the device identified by id. When the device's connection is held by another instance, the
+// connection is forwarded from it.
 func (t *Tunnel) Dial(ctx context.Context, id string) (net.Conn, error) {
-	return t.connman.Dial(ctx, id)
+	conn, err := t.connman.Dial(ctx, id)
+	if !errors.Is(err, connman.ErrNoConnection) {
+		return conn, err
+	}
+
+	instance, err := t.connman.Locate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.forward(ctx, instance, id)
+}
+
+// forward connects to the device identified by id through the instance that holds its connection.
+func (t *Tunnel) forward(ctx context.Context, instance, id string) (net.Conn, error) {
+	logger := log.WithFields(log.Fields{"id": id, "instance": instance})
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", instance)
+	if err != nil {
+		logger.WithError(err).Error("failed to connect to the instance that holds the connection")
+
+		return nil, errors.Join(ErrForwardFailed, err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline) // nolint:errcheck
+	}
+
+	path := strings.Replace(t.ForwardPath, ":id", url.PathEscape(id), 1)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+instance+path, nil)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", forwardProtocol)
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+
+		return nil, errors.Join(ErrForwardFailed, err)
+	}
+
+	reader := bufio.NewReader(conn)
+
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+
+		return nil, errors.Join(ErrForwardFailed, err)
+	}
+
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		conn.Close()
+
+		// NOTICE: The connection was gone from the instance before the registry's record expired.
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, connman.ErrNoConnection
+		}
+
+		return nil, fmt.Errorf("%w: %s", ErrForwardFailed, resp.Status)
+	}
+
+	conn.SetDeadline(time.Time{}) // nolint:errcheck
+
+	return &forwardedConn{Conn: conn, reader: reader}, nil
+}
+
+// forwardedConn is a connection forwarded from another instance, reading the data buffered while its upgrade response
+// was read.
+type forwardedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *forwardedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
 }
 
 func (t *Tunnel) SendRequest(ctx context.Context, id string, req *http.Request) (*http.Response, error) {
-	conn, err := t.connman.Dial(ctx, id)
+	conn, err := t.Dial(ctx, id)
 	if err != nil {
 		return nil, err
 	}
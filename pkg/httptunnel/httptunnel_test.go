@@ -0,0 +1,210 @@
+package httptunnel
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/shellhub-io/shellhub/pkg/connman"
+	"github.com/shellhub-io/shellhub/pkg/revdial"
+	"github.com/shellhub-io/shellhub/pkg/wsconnadapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// registry is a [connman.Registry] kept in memory, shared by the instances of a test.
+type registry struct {
+	mu      sync.Mutex
+	records map[string]string
+}
+
+func (r *registry) Set(_ context.Context, key, instance string, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.records[key] = instance
+
+	return nil
+}
+
+func (r *registry) Get(_ context.Context, key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	instance, ok := r.records[key]
+	if !ok {
+		return "", connman.ErrNoConnection
+	}
+
+	return instance, nil
+}
+
+func (r *registry) Delete(_ context.Context, key, instance string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.records[key] == instance {
+		delete(r.records, key)
+	}
+
+	return nil
+}
+
+// instance starts a tunnel sharing its connections through the registry.
+func instance(t *testing.T, registry connman.Registry) (*Tunnel, string) {
+	t.Helper()
+
+	tunnel := NewTunnel(DefaultConnectionURL, DefaultRevdialURL)
+	tunnel.ConnectionHandler = func(r *http.Request) (string, error) {
+		return r.Header.Get("X-Device-UID"), nil
+	}
+
+	server := httptest.NewServer(tunnel.Router())
+	t.Cleanup(server.Close)
+
+	address := strings.TrimPrefix(server.URL, "http://")
+	tunnel.SetRegistry(registry, address)
+
+	return tunnel, address
+}
+
+// connect connects a device to the instance at address, picking up the connections through the instance at pickup,
+// and answers each one with the device's uid.
+func connect(t *testing.T, registry connman.Registry, address, pickup, uid string) {
+	t.Helper()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws://"+address+DefaultConnectionURL, http.Header{"X-Device-UID": {uid}})
+	require.NoError(t, err)
+
+	serve(t, registry, uid, revdial.NewListener(wsconnadapter.New(conn), func(ctx context.Context, path string) (*websocket.Conn, *http.Response, error) {
+		return websocket.DefaultDialer.DialContext(ctx, "ws://"+pickup+path, nil)
+	}))
+}
+
+// connectMux connects a device to the instance at address, receiving the connections multiplexed over it, and answers
+// each one with the device's uid.
+func connectMux(t *testing.T, registry connman.Registry, address, uid string) {
+	t.Helper()
+
+	conn, res, err := websocket.DefaultDialer.Dial("ws://"+address+DefaultConnectionURL, http.Header{
+		"X-Device-UID":        {uid},
+		revdial.VersionHeader: {strconv.Itoa(revdial.VersionMux)},
+	})
+	require.NoError(t, err)
+	require.Equal(t, revdial.VersionMux, revdial.Version(res.Header))
+
+	listener, err := revdial.NewMuxListener(wsconnadapter.New(conn))
+	require.NoError(t, err)
+
+	serve(t, registry, uid, listener)
+}
+
+func serve(t *testing.T, registry connman.Registry, uid string, listener net.Listener) {
+	t.Helper()
+
+	t.Cleanup(func() {
+		listener.Close()
+	})
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			io.WriteString(conn, uid) // nolint:errcheck
+			conn.Close()
+		}
+	}()
+
+	require.Eventually(t, func() bool {
+		_, err := registry.Get(context.Background(), uid)
+
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestTunnelDial(t *testing.T) {
+	registry := &registry{records: make(map[string]string)}
+
+	first, firstAddress := instance(t, registry)
+	second, secondAddress := instance(t, registry)
+
+	read := func(t *testing.T, conn net.Conn, size int) string {
+		t.Helper()
+
+		defer conn.Close()
+
+		data := make([]byte, size)
+		_, err := io.ReadFull(conn, data)
+		require.NoError(t, err)
+
+		return string(data)
+	}
+
+	t.Run("dials a device connected to the instance", func(t *testing.T) {
+		connect(t, registry, firstAddress, firstAddress, "local")
+
+		conn, err := first.Dial(context.Background(), "local")
+		require.NoError(t, err)
+		assert.Equal(t, "local", read(t, conn, len("local")))
+	})
+
+	t.Run("dials a device connected to another instance", func(t *testing.T) {
+		connect(t, registry, firstAddress, firstAddress, "remote")
+
+		conn, err := second.Dial(context.Background(), "remote")
+		require.NoError(t, err)
+		assert.Equal(t, "remote", read(t, conn, len("remote")))
+	})
+
+	t.Run("dials a device that picks up through another instance", func(t *testing.T) {
+		connect(t, registry, firstAddress, secondAddress, "pickup")
+
+		conn, err := first.Dial(context.Background(), "pickup")
+		require.NoError(t, err)
+		assert.Equal(t, "pickup", read(t, conn, len("pickup")))
+	})
+
+	t.Run("dials a multiplexed device connected to the instance", func(t *testing.T) {
+		connectMux(t, registry, firstAddress, "mux")
+
+		conn, err := first.Dial(context.Background(), "mux")
+		require.NoError(t, err)
+		assert.Equal(t, "mux", read(t, conn, len("mux")))
+
+		// NOTICE: Many connections are multiplexed over the device's one.
+		conn, err = first.Dial(context.Background(), "mux")
+		require.NoError(t, err)
+		assert.Equal(t, "mux", read(t, conn, len("mux")))
+	})
+
+	t.Run("dials a multiplexed device connected to another instance", func(t *testing.T) {
+		connectMux(t, registry, firstAddress, "remote-mux")
+
+		conn, err := second.Dial(context.Background(), "remote-mux")
+		require.NoError(t, err)
+		assert.Equal(t, "remote-mux", read(t, conn, len("remote-mux")))
+	})
+
+	t.Run("fails to dial a device that isn't connected", func(t *testing.T) {
+		_, err := second.Dial(context.Background(), "offline")
+		assert.ErrorIs(t, err, connman.ErrNoConnection)
+	})
+
+	t.Run("fails to dial a device whose record is stale", func(t *testing.T) {
+		registry.Set(context.Background(), "stale", firstAddress, time.Minute) // nolint:errcheck
+
+		_, err := second.Dial(context.Background(), "stale")
+		assert.ErrorIs(t, err, connman.ErrNoConnection)
+	})
+}
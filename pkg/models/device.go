@@ -24,6 +24,7 @@ type Device struct {
 	Identity         *DeviceIdentity `json:"identity"`
 	Info             *DeviceInfo     `json:"info"`
 	PublicKey        string          `json:"public_key" bson:"public_key"`
+	HostKey          string          `json:"host_key" bson:"host_key,omitempty"`
 	TenantID         string          `json:"tenant_id" bson:"tenant_id"`
 	LastSeen         time.Time       `json:"last_seen" bson:"last_seen"`
 	Online           bool            `json:"online" bson:",omitempty"`
@@ -34,6 +35,7 @@ type Device struct {
 	RemoteAddr       string          `json:"remote_addr" bson:"remote_addr"`
 	Position         *DevicePosition `json:"position" bson:"position"`
 	Tags             []string        `json:"tags" bson:"tags,omitempty"`
+	Aliases          []string        `json:"aliases" bson:"aliases,omitempty"`
 	PublicURL        bool            `json:"public_url" bson:"public_url,omitempty"`
 	PublicURLAddress string          `json:"public_url_address" bson:"public_url_address,omitempty"`
 	Acceptable       bool            `json:"acceptable" bson:"acceptable,omitempty"`
@@ -61,6 +63,7 @@ type DeviceAuth struct {
 	Identity  *DeviceIdentity `json:"identity,omitempty" bson:"identity,omitempty" validate:"required_without=Hostname,omitempty"`
 	PublicKey string          `json:"public_key"`
 	TenantID  string          `json:"tenant_id"`
+	HostKey   string          `json:"host_key,omitempty" bson:"host_key,omitempty" hash:"-"`
 }
 
 type DeviceAuthResponse struct {
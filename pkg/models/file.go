@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// File is a file, or a directory, on a device's filesystem.
+type File struct {
+	Name       string    `json:"name"`
+	Size       int64     `json:"size"`
+	Mode       string    `json:"mode"`
+	Dir        bool      `json:"dir"`
+	ModifiedAt time.Time `json:"modified_at"`
+}
+
+// FileChallenge is the data signed by a public key's private key to transfer files with it, what is accepted only once
+// and until it expires.
+type FileChallenge struct {
+	Challenge string    `json:"challenge"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,186 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JobStatus is the status of a job, what executes a command once on each of its devices.
+type JobStatus string
+
+const (
+	JobStatusPending   JobStatus = "pending"
+	JobStatusRunning   JobStatus = "running"
+	JobStatusFinished  JobStatus = "finished"
+	JobStatusCancelled JobStatus = "cancelled"
+	// JobStatusFailed is the status of a job whose execution was lost, like when its worker crashed, as the job isn't
+	// retried.
+	JobStatusFailed JobStatus = "failed"
+)
+
+// JobResultStatus is the status of a job's command on a device.
+type JobResultStatus string
+
+const (
+	JobResultStatusPending   JobResultStatus = "pending"
+	JobResultStatusRunning   JobResultStatus = "running"
+	JobResultStatusSucceeded JobResultStatus = "succeeded"
+	// JobResultStatusFailed is the status of a command that exited with a non-zero code or couldn't be executed, like
+	// when the device is offline.
+	JobResultStatusFailed    JobResultStatus = "failed"
+	JobResultStatusTimeout   JobResultStatus = "timeout"
+	JobResultStatusCancelled JobResultStatus = "cancelled"
+)
+
+// JobSelector selects the namespace's accepted devices where a job's command is executed. A device is selected when it
+// matches the filter and, when the UIDs or the tags are informed, has one of the UIDs or one of the tags.
+type JobSelector struct {
+	UIDs []string `json:"uids,omitempty" bson:"uids,omitempty"`
+	Tags []string `json:"tags,omitempty" bson:"tags,omitempty"`
+	// Filter is the base64-encoded JSON of the filters, in the same format used to list the devices.
+	Filter string `json:"filter,omitempty" bson:"filter,omitempty"`
+}
+
+// Job is a command executed, asynchronously, on the devices selected when the job was created.
+type Job struct {
+	ID       string      `json:"id" bson:"_id"`
+	TenantID string      `json:"tenant_id" bson:"tenant_id"`
+	Command  string      `json:"command" bson:"command"`
+	Selector JobSelector `json:"selector" bson:"selector"`
+	// User is the device's user that executes the command.
+	User string `json:"user" bson:"user"`
+	// Fingerprint is the fingerprint of the namespace's public key used to authenticate the user on each device.
+	Fingerprint string `json:"fingerprint" bson:"fingerprint"`
+	// SignedData is the canonical form of the [JobSignedData] signed by the public key's private key.
+	SignedData string `json:"-" bson:"signed_data"`
+	// Signature is the signed data signed by the public key's private key, encoded in base64, what the SSH server
+	// verifies before executing the command on each device.
+	Signature string `json:"-" bson:"signature"`
+	// Concurrency is the maximum number of devices executing the command at the same time.
+	Concurrency int `json:"concurrency" bson:"concurrency"`
+	// Timeout is the number of seconds the command can run on each device.
+	Timeout int      `json:"timeout" bson:"timeout"`
+	Devices []string `json:"devices" bson:"devices"`
+	// ScheduleID is the schedule that created the job, when the job is one of its runs.
+	ScheduleID string     `json:"schedule_id,omitempty" bson:"schedule_id,omitempty"`
+	Status     JobStatus  `json:"status" bson:"status"`
+	CreatedBy  string     `json:"created_by" bson:"created_by"`
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty" bson:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" bson:"finished_at,omitempty"`
+}
+
+// JobConnectTimeout is the time, on top of the command's timeout, given to connect to each device.
+const JobConnectTimeout = time.Minute
+
+// Deadline returns the time the job's devices have to execute the command, considering that they execute it in
+// batches limited by the job's concurrency.
+func (j *Job) Deadline() time.Duration {
+	batches := (len(j.Devices) + j.Concurrency - 1) / j.Concurrency
+
+	return time.Duration(batches) * (time.Duration(j.Timeout)*time.Second + JobConnectTimeout)
+}
+
+// JobResult is the result of a job's command on a device.
+type JobResult struct {
+	JobID  string          `json:"job_id" bson:"job_id"`
+	UID    string          `json:"uid" bson:"uid"`
+	Name   string          `json:"name" bson:"name"`
+	Status JobResultStatus `json:"status" bson:"status"`
+	// ExitCode is the command's exit code, what is nil while the command doesn't exit or when it couldn't be executed.
+	ExitCode *int   `json:"exit_code,omitempty" bson:"exit_code,omitempty"`
+	Stdout   string `json:"stdout" bson:"stdout"`
+	Stderr   string `json:"stderr" bson:"stderr"`
+	// Truncated indicates that the command's output was bigger than the stored one.
+	Truncated  bool       `json:"truncated" bson:"truncated"`
+	Error      string     `json:"error,omitempty" bson:"error,omitempty"`
+	StartedAt  *time.Time `json:"started_at,omitempty" bson:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty" bson:"finished_at,omitempty"`
+}
+
+// CommandOutput is the output of a command executed on a device.
+type CommandOutput struct {
+	ExitCode int    `json:"exit_code"`
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	// Truncated indicates that the command wrote more than the output's limit.
+	Truncated bool `json:"truncated"`
+	// TimedOut indicates that the command was killed because it didn't exit in time.
+	TimedOut bool `json:"timed_out"`
+}
+
+// jobSignedDataLines is the number of lines of a [JobSignedData]'s canonical form.
+const jobSignedDataLines = 10
+
+var ErrJobSignedData = errors.New("the job's signed data is malformed")
+
+// JobSignedData is the data signed by the private key of a job's public key when the job, or the job schedule, is
+// created. It binds the signature to the namespace, the creator, the devices' user and selector, the schedule and the
+// command, so it cannot be used to execute anything else, and limits the time it can be used to create the job.
+type JobSignedData struct {
+	TenantID    string
+	CreatedBy   string
+	User        string
+	Fingerprint string
+	Selector    JobSelector
+	// Cron is the cron expression of the job schedule, empty for a job.
+	Cron string
+	// ExpiresAt is the Unix time after which the signature is rejected.
+	ExpiresAt int64
+	Command   string
+}
+
+// String returns the data's canonical form: a line for each field, in the order they are declared, with the
+// selector's UIDs and tags joined by commas. The command is the last line, as it may have line breaks.
+func (d *JobSignedData) String() string {
+	return strings.Join([]string{
+		d.TenantID,
+		d.CreatedBy,
+		d.User,
+		d.Fingerprint,
+		strings.Join(d.Selector.UIDs, ","),
+		strings.Join(d.Selector.Tags, ","),
+		d.Selector.Filter,
+		d.Cron,
+		strconv.FormatInt(d.ExpiresAt, 10),
+		d.Command,
+	}, "\n")
+}
+
+// ParseJobSignedData parses the canonical form of a [JobSignedData].
+func ParseJobSignedData(data string) (*JobSignedData, error) {
+	lines := strings.SplitN(data, "\n", jobSignedDataLines)
+	if len(lines) != jobSignedDataLines {
+		return nil, ErrJobSignedData
+	}
+
+	expiresAt, err := strconv.ParseInt(lines[8], 10, 64)
+	if err != nil {
+		return nil, ErrJobSignedData
+	}
+
+	split := func(line string) []string {
+		if line == "" {
+			return nil
+		}
+
+		return strings.Split(line, ",")
+	}
+
+	return &JobSignedData{
+		TenantID:    lines[0],
+		CreatedBy:   lines[1],
+		User:        lines[2],
+		Fingerprint: lines[3],
+		Selector: JobSelector{
+			UIDs:   split(lines[4]),
+			Tags:   split(lines[5]),
+			Filter: lines[6],
+		},
+		Cron:      lines[7],
+		ExpiresAt: expiresAt,
+		Command:   lines[9],
+	}, nil
+}
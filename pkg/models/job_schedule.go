@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// JobSchedule executes a command, periodically, on the devices selected at each scheduled time. Each execution is a
+// [Job] of the schedule.
+type JobSchedule struct {
+	ID       string `json:"id" bson:"_id"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	Name     string `json:"name" bson:"name"`
+	// Cron is the cron expression, evaluated in UTC, of the times the command is executed.
+	Cron     string      `json:"cron" bson:"cron"`
+	Command  string      `json:"command" bson:"command"`
+	Selector JobSelector `json:"selector" bson:"selector"`
+	// User is the device's user that executes the command.
+	User string `json:"user" bson:"user"`
+	// Fingerprint is the fingerprint of the namespace's public key used to authenticate the user on each device.
+	Fingerprint string `json:"fingerprint" bson:"fingerprint"`
+	// SignedData is the canonical form of the [JobSignedData] signed by the public key's private key, passed on to the
+	// jobs.
+	SignedData string `json:"-" bson:"signed_data"`
+	// Signature is the signed data signed by the public key's private key, encoded in base64, passed on to the jobs.
+	Signature string `json:"-" bson:"signature"`
+	// Concurrency is the maximum number of devices executing the command at the same time.
+	Concurrency int `json:"concurrency" bson:"concurrency"`
+	// Timeout is the number of seconds the command can run on each device.
+	Timeout int  `json:"timeout" bson:"timeout"`
+	Enabled bool `json:"enabled" bson:"enabled"`
+	// RunOnReconnect executes the command on the devices offline at a scheduled time when they reconnect, instead of
+	// failing on them.
+	RunOnReconnect bool `json:"run_on_reconnect" bson:"run_on_reconnect"`
+	// Missed are the devices offline at the last scheduled time, waiting to reconnect to execute the command.
+	Missed    []string   `json:"missed" bson:"missed"`
+	CreatedBy string     `json:"created_by" bson:"created_by"`
+	CreatedAt time.Time  `json:"created_at" bson:"created_at"`
+	LastRunAt *time.Time `json:"last_run_at,omitempty" bson:"last_run_at,omitempty"`
+}
+
+type JobScheduleChanges struct {
+	Name           *string `bson:"name,omitempty"`
+	Cron           *string `bson:"cron,omitempty"`
+	Enabled        *bool   `bson:"enabled,omitempty"`
+	RunOnReconnect *bool   `bson:"run_on_reconnect,omitempty"`
+}
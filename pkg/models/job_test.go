@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobDeadline(t *testing.T) {
+	cases := []struct {
+		description string
+		job         *Job
+		expected    time.Duration
+	}{
+		{
+			description: "executes the devices in a single batch",
+			job:         &Job{Devices: []string{"a", "b"}, Concurrency: 10, Timeout: 30},
+			expected:    90 * time.Second,
+		},
+		{
+			description: "executes the devices in batches limited by the concurrency",
+			job:         &Job{Devices: []string{"a", "b", "c"}, Concurrency: 2, Timeout: 30},
+			expected:    2 * 90 * time.Second,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.job.Deadline())
+		})
+	}
+}
+
+func TestJobSignedData(t *testing.T) {
+	data := &JobSignedData{
+		TenantID:    "tenant",
+		CreatedBy:   "john",
+		User:        "root",
+		Fingerprint: "fingerprint",
+		Selector:    JobSelector{Tags: []string{"web", "db"}},
+		ExpiresAt:   1700000000,
+		Command:     "uptime\nhostname",
+	}
+
+	assert.Equal(t, "tenant\njohn\nroot\nfingerprint\n\nweb,db\n\n\n1700000000\nuptime\nhostname", data.String())
+
+	parsed, err := ParseJobSignedData(data.String())
+	assert.NoError(t, err)
+	assert.Equal(t, data, parsed)
+
+	_, err = ParseJobSignedData("tenant\njohn")
+	assert.ErrorIs(t, err, ErrJobSignedData)
+}
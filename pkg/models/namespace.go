@@ -16,6 +16,8 @@ type Namespace struct {
 	DevicesCount int                `json:"devices_count" bson:"devices_count,omitempty"`
 	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
 	Billing      *Billing           `json:"billing" bson:"billing,omitempty"`
+	// CA is the SSH user certificate authority managed by ShellHub to issue certificates to the namespace's members.
+	CA *NamespaceCA `json:"ca,omitempty" bson:"ca,omitempty"`
 }
 
 // HasMaxDevices checks if the namespace has a maximum number of devices.
@@ -52,6 +54,27 @@ func (n *Namespace) FindMember(id string) (*Member, bool) {
 type NamespaceSettings struct {
 	SessionRecord          bool   `json:"session_record" bson:"session_record,omitempty"`
 	ConnectionAnnouncement string `json:"connection_announcement" bson:"connection_announcement"`
+	// SessionIdleTimeout is the number of minutes without client's input after which a session is disconnected. Zero
+	// disables the limit.
+	SessionIdleTimeout int `json:"session_idle_timeout" bson:"session_idle_timeout,omitempty"`
+	// SessionMaxDuration is the maximum number of minutes a session can last. Zero disables the limit.
+	SessionMaxDuration int `json:"session_max_duration" bson:"session_max_duration,omitempty"`
+	// ReversePortForwarding allows clients to request remote port forwarding, listening on the device and forwarding
+	// the connections back to the client.
+	ReversePortForwarding bool `json:"reverse_port_forwarding" bson:"reverse_port_forwarding,omitempty"`
+	// AgentForwarding allows clients to forward their SSH agent to the devices.
+	AgentForwarding bool `json:"agent_forwarding" bson:"agent_forwarding"`
+	// X11Forwarding allows clients to forward X11 connections from the devices to their displays.
+	X11Forwarding bool `json:"x11_forwarding" bson:"x11_forwarding,omitempty"`
+	// UserCA is the SSH user certificate authority trusted to sign the certificates used to connect to the devices.
+	UserCA *UserCA `json:"user_ca,omitempty" bson:"user_ca,omitempty"`
+	// PortForwarding is the policy for local port forwarding. When nil, any destination is allowed.
+	PortForwarding *PortForwardingPolicy `json:"port_forwarding,omitempty" bson:"port_forwarding,omitempty"`
+	// MFARequired requires a TOTP code, from the ShellHub's user mapped to the client's key, before connecting to the
+	// devices.
+	MFARequired bool `json:"mfa_required" bson:"mfa_required,omitempty"`
+	// JobRetention is the number of days the finished jobs, and their results, are kept. Zero keeps them forever.
+	JobRetention int `json:"job_retention" bson:"job_retention,omitempty"`
 }
 
 type Member struct {
@@ -61,7 +84,16 @@ type Member struct {
 }
 
 type NamespaceChanges struct {
-	Name                   string  `bson:"name,omitempty"`
-	SessionRecord          *bool   `bson:"settings.session_record,omitempty"`
-	ConnectionAnnouncement *string `bson:"settings.connection_announcement,omitempty"`
+	Name                   string                `bson:"name,omitempty"`
+	SessionRecord          *bool                 `bson:"settings.session_record,omitempty"`
+	ConnectionAnnouncement *string               `bson:"settings.connection_announcement,omitempty"`
+	SessionIdleTimeout     *int                  `bson:"settings.session_idle_timeout,omitempty"`
+	SessionMaxDuration     *int                  `bson:"settings.session_max_duration,omitempty"`
+	ReversePortForwarding  *bool                 `bson:"settings.reverse_port_forwarding,omitempty"`
+	PortForwarding         *PortForwardingPolicy `bson:"settings.port_forwarding,omitempty"`
+	AgentForwarding        *bool                 `bson:"settings.agent_forwarding,omitempty"`
+	X11Forwarding          *bool                 `bson:"settings.x11_forwarding,omitempty"`
+	UserCA                 *UserCA               `bson:"settings.user_ca,omitempty"`
+	MFARequired            *bool                 `bson:"settings.mfa_required,omitempty"`
+	JobRetention           *int                  `bson:"settings.job_retention,omitempty"`
 }
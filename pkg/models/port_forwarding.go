@@ -0,0 +1,121 @@
+package models
+
+import (
+	"net"
+	"slices"
+	"strings"
+)
+
+const (
+	PortForwardingActionAllow = "allow"
+	PortForwardingActionDeny  = "deny"
+)
+
+// PortForwardingPolicy defines which destinations a client can reach through local port forwarding, and which addresses
+// it can bind through remote port forwarding, on the namespace's devices.
+type PortForwardingPolicy struct {
+	// Default is the action applied when no rule matches the destination. An empty default allows it.
+	Default string `json:"default" bson:"default" validate:"omitempty,oneof=allow deny"`
+	// Rules are evaluated in order, and the first one that matches decides the action.
+	Rules []PortForwardingRule `json:"rules" bson:"rules" validate:"max=100,dive"`
+}
+
+// PortForwardingPortRange is an inclusive range of ports. When To is zero, the range has only the From port.
+type PortForwardingPortRange struct {
+	From uint32 `json:"from" bson:"from" validate:"required,min=1,max=65535"`
+	To   uint32 `json:"to,omitempty" bson:"to,omitempty" validate:"omitempty,max=65535,gtefield=From"`
+}
+
+// Contains checks if the port is inside the range.
+func (r PortForwardingPortRange) Contains(port uint32) bool {
+	if r.To == 0 {
+		return port == r.From
+	}
+
+	return port >= r.From && port <= r.To
+}
+
+// PortForwardingRule is a rule of a [PortForwardingPolicy]. Each criterion left empty matches anything, and a rule
+// matches a port forwarding when all its criteria do.
+type PortForwardingRule struct {
+	Action string `json:"action" bson:"action" validate:"required,oneof=allow deny"`
+	// Roles are the roles of the namespace's member who opened the connection. As connections not bound to a member
+	// have no role, they never match a rule with roles.
+	Roles []string `json:"roles,omitempty" bson:"roles,omitempty" validate:"dive,oneof=owner administrator operator observer"`
+	// Usernames are the device's users used to log in.
+	Usernames []string `json:"usernames,omitempty" bson:"usernames,omitempty" validate:"dive,required"`
+	// Tags are the device's tags. The rule matches when the device has any of them.
+	Tags []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"dive,required"`
+	// Hosts are the destinations' hostnames, IPs or CIDRs. CIDRs only match destinations requested as an IP.
+	Hosts []string `json:"hosts,omitempty" bson:"hosts,omitempty" validate:"dive,required"`
+	// Ports are the destinations' port ranges.
+	Ports []PortForwardingPortRange `json:"ports,omitempty" bson:"ports,omitempty" validate:"dive"`
+}
+
+// PortForwarding is a port forwarding request evaluated by a [PortForwardingPolicy].
+type PortForwarding struct {
+	// Role is the role of the namespace's member who opened the connection, if any.
+	Role     string
+	Username string
+	// Tags are the device's tags.
+	Tags []string
+	Host string
+	Port uint32
+}
+
+// Match checks if the port forwarding matches all the rule's criteria.
+func (r *PortForwardingRule) Match(forwarding PortForwarding) bool {
+	if len(r.Roles) > 0 && !slices.Contains(r.Roles, forwarding.Role) {
+		return false
+	}
+
+	if len(r.Usernames) > 0 && !slices.Contains(r.Usernames, forwarding.Username) {
+		return false
+	}
+
+	if len(r.Tags) > 0 && !slices.ContainsFunc(r.Tags, func(tag string) bool {
+		return slices.Contains(forwarding.Tags, tag)
+	}) {
+		return false
+	}
+
+	if len(r.Hosts) > 0 && !slices.ContainsFunc(r.Hosts, func(host string) bool {
+		return matchHost(host, forwarding.Host)
+	}) {
+		return false
+	}
+
+	if len(r.Ports) > 0 && !slices.ContainsFunc(r.Ports, func(ports PortForwardingPortRange) bool {
+		return ports.Contains(forwarding.Port)
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// Allow evaluates the policy's rules against the port forwarding, returning if it is allowed.
+func (p *PortForwardingPolicy) Allow(forwarding PortForwarding) bool {
+	for _, rule := range p.Rules {
+		if rule.Match(forwarding) {
+			return rule.Action == PortForwardingActionAllow
+		}
+	}
+
+	return p.Default != PortForwardingActionDeny
+}
+
+// matchHost checks if the destination host matches the rule's host, what can be a hostname, an IP or a CIDR.
+func matchHost(rule, host string) bool {
+	if _, network, err := net.ParseCIDR(rule); err == nil {
+		ip := net.ParseIP(host)
+
+		return ip != nil && network.Contains(ip)
+	}
+
+	if ip := net.ParseIP(rule); ip != nil {
+		return ip.Equal(net.ParseIP(host))
+	}
+
+	return strings.EqualFold(rule, host)
+}
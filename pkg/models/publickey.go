@@ -39,6 +39,9 @@ type PublicKey struct {
 	CreatedAt       time.Time `json:"created_at" bson:"created_at"`
 	TenantID        string    `json:"tenant_id" bson:"tenant_id"`
 	PublicKeyFields `bson:",inline"`
+	// CreatedBy is the ID of the ShellHub's user who created the public key, whose MFA is used when the namespace
+	// requires it.
+	CreatedBy string `json:"created_by" bson:"created_by,omitempty"`
 }
 
 type PublicKeyUpdate struct {
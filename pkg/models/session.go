@@ -9,6 +9,35 @@ type SessionPosition struct {
 	Latitude  float64 `json:"latitude" bson:"latitude"`
 }
 
+const (
+	// SessionDisconnectReasonIdleTimeout is the reason for a session disconnected after the namespace's idle timeout
+	// without client's input.
+	SessionDisconnectReasonIdleTimeout = "idle_timeout"
+	// SessionDisconnectReasonMaxDuration is the reason for a session disconnected after reaching the namespace's
+	// maximum session duration.
+	SessionDisconnectReasonMaxDuration = "max_duration"
+	// SessionDisconnectReasonClosed is the reason for a session closed by a namespace's member.
+	SessionDisconnectReasonClosed = "closed"
+	// SessionDisconnectReasonShutdown is the reason for a session disconnected because the SSH server was shutting
+	// down.
+	SessionDisconnectReasonShutdown = "shutdown"
+)
+
+const (
+	// SessionEventTypePortForwardingDenied is the event's type for a port forwarding denied by the namespace's policy.
+	SessionEventTypePortForwardingDenied = "port_forwarding_denied"
+	// SessionEventTypeHostKeyMismatch is the event's type for a connection rejected because the device's host key
+	// doesn't match the pinned one.
+	SessionEventTypeHostKeyMismatch = "host_key_mismatch"
+)
+
+// SessionEvent is something relevant what happened during a session.
+type SessionEvent struct {
+	Type      string            `json:"type" bson:"type"`
+	Timestamp time.Time         `json:"timestamp" bson:"timestamp"`
+	Data      map[string]string `json:"data,omitempty" bson:"data,omitempty"`
+}
+
 type Session struct {
 	UID           string          `json:"uid"`
 	DeviceUID     UID             `json:"device_uid,omitempty" bson:"device_uid"`
@@ -25,6 +54,10 @@ type Session struct {
 	Type          string          `json:"type" bson:"type"`
 	Term          string          `json:"term" bson:"term"`
 	Position      SessionPosition `json:"position" bson:"position"`
+	// DisconnectReason is the reason why the session was disconnected by ShellHub, if it was.
+	DisconnectReason string `json:"disconnect_reason,omitempty" bson:"disconnect_reason,omitempty"`
+	// Events are the relevant events what happened during the session.
+	Events []SessionEvent `json:"events,omitempty" bson:"events,omitempty"`
 }
 
 type ActiveSession struct {
@@ -20,7 +20,14 @@ type User struct {
 	Secret         string    `json:"secret" bson:"secret"`
 	Codes          []string  `json:"codes" bson:"codes"`
 	UserData       `bson:",inline"`
-	Password       UserPassword `bson:",inline"`
+	Password       UserPassword    `bson:",inline"`
+	Preferences    UserPreferences `json:"preferences" bson:"preferences,omitempty"`
+}
+
+// UserPreferences contains the user's choices about how ShellHub behaves for them.
+type UserPreferences struct {
+	// DefaultNamespace is the tenant ID of the namespace used to resolve devices referenced without a namespace.
+	DefaultNamespace string `json:"default_namespace" bson:"default_namespace,omitempty"`
 }
 
 type UserData struct {
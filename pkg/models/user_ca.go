@@ -0,0 +1,50 @@
+package models
+
+import "slices"
+
+// CertificateExtensionTenant is the extension of OpenSSH user certificates with the tenant of the namespace where the
+// certificate was issued, used to authenticate the connections using ShellHub as a jump host.
+const CertificateExtensionTenant = "tenant@shellhub.io"
+
+// UserCA is the SSH user certificate authority trusted by a namespace. OpenSSH user certificates signed by it are
+// accepted to connect to the namespace's devices, without registering each client's public key.
+type UserCA struct {
+	// PublicKey is the certificate authority's public key, in the authorized_keys format. When empty, only the
+	// certificates issued by the namespace's [NamespaceCA] are accepted.
+	PublicKey string `json:"public_key" bson:"public_key"`
+	// Principals maps a certificate's principal to the device's usernames it grants access to. A principal not mapped
+	// grants access only to the username equal to itself.
+	Principals map[string][]string `json:"principals,omitempty" bson:"principals,omitempty"`
+	// RolePrincipals maps a member's role to the principals of the certificates issued to that member by the
+	// namespace's [NamespaceCA].
+	RolePrincipals map[string][]string `json:"role_principals,omitempty" bson:"role_principals,omitempty"`
+	// RevokedSerials are the serials of certificates not accepted anymore.
+	RevokedSerials []uint64 `json:"revoked_serials,omitempty" bson:"revoked_serials,omitempty"`
+}
+
+// Principal returns the first principal of the list what grants access to the username.
+func (ca *UserCA) Principal(principals []string, username string) (string, bool) {
+	for _, principal := range principals {
+		usernames, ok := ca.Principals[principal]
+		if !ok && principal == username || ok && slices.Contains(usernames, username) {
+			return principal, true
+		}
+	}
+
+	return "", false
+}
+
+// Revoked checks if the certificate's serial was revoked.
+func (ca *UserCA) Revoked(serial uint64) bool {
+	return slices.Contains(ca.RevokedSerials, serial)
+}
+
+// NamespaceCA is the SSH user certificate authority managed by ShellHub for a namespace. It is created on the first
+// certificate issued to one of the namespace's members.
+type NamespaceCA struct {
+	// PublicKey is the certificate authority's public key, in the authorized_keys format.
+	PublicKey string `json:"public_key" bson:"public_key"`
+	// PrivateKey is the certificate authority's private key, PEM encoded in the OpenSSH format and encrypted with a key
+	// derived from the API's private key. It is never exposed.
+	PrivateKey []byte `json:"-" bson:"private_key"`
+}
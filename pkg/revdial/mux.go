@@ -0,0 +1,99 @@
+package revdial
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/yamux"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// VersionHeader is the header where the reverse tunnel's version is negotiated. The Listener's side sends the
+	// highest version it supports, when connecting, and the Dialer's side replies the one to be used. When the reply
+	// doesn't have it, the first version, where each connection is picked up through a new request, is used.
+	VersionHeader = "X-Tunnel-Version"
+	// VersionMux is the version where the connections are streams multiplexed over the control connection.
+	VersionMux = 2
+)
+
+// Version returns the reverse tunnel's version in the header, or the first one when it isn't there.
+func Version(header http.Header) int {
+	version, err := strconv.Atoi(header.Get(VersionHeader))
+	if err != nil {
+		return 1
+	}
+
+	return version
+}
+
+// muxLogger writes the multiplexer's logs as debug messages.
+type muxLogger struct{}
+
+func (muxLogger) Write(p []byte) (int, error) {
+	logrus.Debug(strings.TrimSpace(string(p)))
+
+	return len(p), nil
+}
+
+func muxConfig() *yamux.Config {
+	config := yamux.DefaultConfig()
+	config.LogOutput = muxLogger{}
+
+	return config
+}
+
+// MuxDialer is the side of the connection which initiates new connections, as streams multiplexed over the control
+// connection, instead of asking the listener to pick each one up through a new request.
+type MuxDialer struct {
+	session *yamux.Session
+}
+
+// NewMuxDialer returns the dialer's side of the multiplexed control connection.
+func NewMuxDialer(c net.Conn) (*MuxDialer, error) {
+	session, err := yamux.Client(c, muxConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	return &MuxDialer{session: session}, nil
+}
+
+// Done returns a channel which is closed when d is closed, either on purpose or by an error of the control
+// connection.
+func (d *MuxDialer) Done() <-chan struct{} {
+	return d.session.CloseChan()
+}
+
+// Close closes the MuxDialer and every connection created by it.
+func (d *MuxDialer) Close() error {
+	return d.session.Close()
+}
+
+// Dial creates a new connection back to the listener.
+func (d *MuxDialer) Dial(ctx context.Context) (net.Conn, error) {
+	select {
+	case <-d.session.CloseChan():
+		return nil, ErrDialerClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	conn, err := d.session.Open()
+	if errors.Is(err, yamux.ErrSessionShutdown) {
+		return nil, ErrDialerClosed
+	}
+
+	return conn, err
+}
+
+// NewMuxListener returns the listener's side of the multiplexed control connection, accepting the connections
+// created by a [MuxDialer].
+func NewMuxListener(serverConn net.Conn) (net.Listener, error) {
+	return yamux.Server(serverConn, muxConfig())
+}
@@ -442,6 +442,13 @@ type fakeAddr struct{}
 func (fakeAddr) Network() string { return "revdial" }
 func (fakeAddr) String() string  { return "revdialconn" }
 
+// Registered reports whether the pick up request was issued by a Dialer of this process.
+func Registered(r *http.Request) bool {
+	_, ok := dialers.Load(r.FormValue(dialerUniqParam))
+
+	return ok
+}
+
 // ConnHandler returns the HTTP handler that needs to be mounted somewhere
 // that the Listeners can dial out and get to. A dialer to connect to it
 // is given to NewListener and the path to reach it is given to NewDialer
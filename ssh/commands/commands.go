@@ -0,0 +1,187 @@
+// Package commands executes commands on the devices, through a SSH connection to the server itself, authenticated
+// with the credentials of a device's user. Its routes are internal, used by the API's workers to execute the jobs.
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/dialer"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+)
+
+const ExecURL = "/devices/:uid/exec"
+
+// MaxOutputSize is the maximum number of bytes kept from each of the command's outputs.
+const MaxOutputSize = 64 * 1024
+
+var (
+	ErrRequest    = errors.New("the user, its public key's fingerprint, the command, its signed data and its signature are required")
+	ErrSignedData = errors.New("the signed data isn't of the command")
+)
+
+// Dialer connects to the device of the request, authenticated as its user.
+type Dialer func(req *requests.DeviceCommand) (*ssh.Client, error)
+
+// NewDialer creates a [Dialer] connecting to the SSH server listening on the address.
+func NewDialer(address string, api internalclient.Client) Dialer {
+	return func(req *requests.DeviceCommand) (*ssh.Client, error) {
+		return dialer.Dial(address, api, &dialer.Credentials{
+			Device:      req.UID,
+			User:        req.User,
+			Fingerprint: req.Fingerprint,
+			Challenge:   req.SignedData,
+			Signature:   req.Signature,
+		})
+	}
+}
+
+type handler struct {
+	dial Dialer
+}
+
+// Register registers the commands' routes on the router.
+func Register(router *echo.Echo, dial Dialer) {
+	h := &handler{dial: dial}
+
+	router.POST(ExecURL, h.exec)
+}
+
+// output keeps the first [MaxOutputSize] bytes written to it, discarding the rest.
+type output struct {
+	buffer    bytes.Buffer
+	truncated bool
+}
+
+func (o *output) Write(data []byte) (int, error) {
+	written := len(data)
+
+	if room := MaxOutputSize - o.buffer.Len(); len(data) > room {
+		o.truncated = true
+		data = data[:max(room, 0)]
+	}
+
+	o.buffer.Write(data)
+
+	return written, nil
+}
+
+// logger returns the logger of the command's request.
+func logger(req *requests.DeviceCommand) *log.Entry {
+	return log.WithFields(log.Fields{
+		"device": req.UID,
+		"user":   req.User,
+	})
+}
+
+// reply replies the error to the client, with the status related to it.
+func reply(c echo.Context, req *requests.DeviceCommand, err error) error {
+	status := http.StatusInternalServerError
+
+	var dial *dialer.DialError
+	switch {
+	case errors.Is(err, ErrRequest), errors.Is(err, ErrSignedData), errors.Is(err, models.ErrJobSignedData):
+		status = http.StatusBadRequest
+	case errors.As(err, &dial):
+		status = http.StatusForbidden
+	}
+
+	if req != nil {
+		logger(req).WithError(err).WithField("status", status).Warn("failed to execute the command")
+	}
+
+	return c.JSON(status, err.Error())
+}
+
+func (h *handler) exec(c echo.Context) error {
+	req := new(requests.DeviceCommand)
+	if err := c.Bind(req); err != nil {
+		return reply(c, nil, err)
+	}
+
+	if req.User == "" || req.Fingerprint == "" || req.Command == "" || req.SignedData == "" || req.Signature == "" {
+		return reply(c, nil, ErrRequest)
+	}
+
+	// NOTICE: The signature is verified against the signed data when dialing, so the signed data must be of the
+	// command, the user and the public key of the request.
+	data, err := models.ParseJobSignedData(req.SignedData)
+	if err != nil {
+		return reply(c, req, err)
+	}
+
+	if data.Command != req.Command || data.User != req.User || data.Fingerprint != req.Fingerprint {
+		return reply(c, req, ErrSignedData)
+	}
+
+	client, err := h.dial(req)
+	if err != nil {
+		return reply(c, req, err)
+	}
+
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return reply(c, req, err)
+	}
+
+	defer session.Close()
+
+	stdout, stderr := new(output), new(output)
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	ctx := c.Request().Context()
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(req.Timeout)*time.Second)
+		defer cancel()
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	// NOTICE: The command is killed when it times out or when the client gives up on it, like when a job is
+	// cancelled. Closing the connection ensures the session ends even when the signal isn't supported by the device.
+	go func() {
+		select {
+		case <-ctx.Done():
+			session.Signal(ssh.SIGKILL) //nolint:errcheck
+			client.Close()
+		case <-done:
+		}
+	}()
+
+	result := models.CommandOutput{}
+
+	var exit *ssh.ExitError
+	switch err := session.Run(req.Command); {
+	case ctx.Err() != nil:
+		result.ExitCode = -1
+		result.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+	case errors.As(err, &exit):
+		result.ExitCode = exit.ExitStatus()
+	case err != nil:
+		return reply(c, req, err)
+	}
+
+	result.Stdout = stdout.buffer.String()
+	result.Stderr = stderr.buffer.String()
+	result.Truncated = stdout.truncated || stderr.truncated
+
+	logger(req).WithFields(log.Fields{
+		"exit_code": result.ExitCode,
+		"timed_out": result.TimedOut,
+	}).Info("command executed")
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/dialer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// device starts a SSH server that executes fake commands, returning a [Dialer] to it.
+func device(t *testing.T) Dialer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &gliderssh.Server{
+		Handler: func(session gliderssh.Session) {
+			switch session.RawCommand() {
+			case "hostname":
+				io.WriteString(session, "device\n") //nolint:errcheck
+				session.Exit(0)                     //nolint:errcheck
+			case "false":
+				io.WriteString(session.Stderr(), "failure\n") //nolint:errcheck
+				session.Exit(3)                               //nolint:errcheck
+			case "yes":
+				io.WriteString(session, strings.Repeat("y", MaxOutputSize+1)) //nolint:errcheck
+				session.Exit(0)                                               //nolint:errcheck
+			case "sleep":
+				<-session.Context().Done()
+			}
+		},
+	}
+
+	go server.Serve(listener) //nolint:errcheck
+
+	t.Cleanup(func() {
+		server.Close()
+	})
+
+	return func(req *requests.DeviceCommand) (*ssh.Client, error) {
+		if req.Fingerprint != "fingerprint" {
+			return nil, &dialer.DialError{Err: dialer.ErrForbiddenKey}
+		}
+
+		return ssh.Dial("tcp", listener.Addr().String(), &ssh.ClientConfig{ //nolint:exhaustruct
+			User:            req.User,
+			HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+		})
+	}
+}
+
+// execute sends the command's request to the commands' routes.
+func execute(router *echo.Echo, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/devices/device/exec", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestExec(t *testing.T) {
+	router := echo.New()
+	Register(router, device(t))
+
+	result := func(t *testing.T, rec *httptest.ResponseRecorder) models.CommandOutput {
+		t.Helper()
+
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var output models.CommandOutput
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&output))
+
+		return output
+	}
+
+	signed := func(user, fingerprint, command string) string {
+		return (&models.JobSignedData{TenantID: "tenant", User: user, Fingerprint: fingerprint, ExpiresAt: 1, Command: command}).String()
+	}
+
+	request := func(command string, timeout int) string {
+		return fmt.Sprintf(`{"user":"root","fingerprint":"fingerprint","signed_data":%q,"signature":"c2lnbmF0dXJl","command":%q,"timeout":%d}`, signed("root", "fingerprint", command), command, timeout)
+	}
+
+	t.Run("returns the command's output", func(t *testing.T) {
+		output := result(t, execute(router, request("hostname", 0)))
+		assert.Equal(t, models.CommandOutput{ExitCode: 0, Stdout: "device\n"}, output)
+	})
+
+	t.Run("returns the command's exit code", func(t *testing.T) {
+		output := result(t, execute(router, request("false", 0)))
+		assert.Equal(t, models.CommandOutput{ExitCode: 3, Stderr: "failure\n"}, output)
+	})
+
+	t.Run("truncates the command's output", func(t *testing.T) {
+		output := result(t, execute(router, request("yes", 0)))
+		assert.Len(t, output.Stdout, MaxOutputSize)
+		assert.True(t, output.Truncated)
+	})
+
+	t.Run("kills the command when it times out", func(t *testing.T) {
+		output := result(t, execute(router, request("sleep", 1)))
+		assert.Equal(t, -1, output.ExitCode)
+		assert.True(t, output.TimedOut)
+	})
+
+	t.Run("fails when the command is missing", func(t *testing.T) {
+		rec := execute(router, `{"user":"root","fingerprint":"fingerprint","signature":"c2lnbmF0dXJl"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("fails when the command's signature is missing", func(t *testing.T) {
+		rec := execute(router, fmt.Sprintf(`{"user":"root","fingerprint":"fingerprint","signed_data":%q,"command":"hostname"}`, signed("root", "fingerprint", "hostname")))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("fails when the signed data is missing", func(t *testing.T) {
+		rec := execute(router, `{"user":"root","fingerprint":"fingerprint","signature":"c2lnbmF0dXJl","command":"hostname"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("fails when the signed data is of other command", func(t *testing.T) {
+		rec := execute(router, fmt.Sprintf(`{"user":"root","fingerprint":"fingerprint","signed_data":%q,"signature":"c2lnbmF0dXJl","command":"hostname"}`, signed("root", "fingerprint", "reboot")))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("fails when the signed data is of other user", func(t *testing.T) {
+		rec := execute(router, fmt.Sprintf(`{"user":"root","fingerprint":"fingerprint","signed_data":%q,"signature":"c2lnbmF0dXJl","command":"hostname"}`, signed("admin", "fingerprint", "hostname")))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("fails when the user cannot be authenticated", func(t *testing.T) {
+		rec := execute(router, fmt.Sprintf(`{"user":"root","fingerprint":"unknown","signed_data":%q,"signature":"c2lnbmF0dXJl","command":"hostname"}`, signed("root", "unknown", "hostname")))
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
@@ -0,0 +1,45 @@
+package files
+
+import (
+	"errors"
+
+	"github.com/pkg/sftp"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/ssh/pkg/dialer"
+)
+
+var ErrSubsystemFailed = errors.New("failed to open the SFTP subsystem on the device")
+
+// NewDialer creates a [Dialer] connecting to the SSH server listening on the address.
+func NewDialer(address string, api internalclient.Client) Dialer {
+	return func(req *requests.DeviceFile) (*sftp.Client, error) {
+		conn, err := dialer.Dial(address, api, &dialer.Credentials{
+			Device:      req.UID,
+			User:        req.User,
+			Fingerprint: req.Fingerprint,
+			Challenge:   req.Challenge,
+			Signature:   req.Signature,
+			MFA:         req.MFA,
+			Password:    req.Password,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		client, err := sftp.NewClient(conn)
+		if err != nil {
+			conn.Close()
+
+			return nil, errors.Join(ErrSubsystemFailed, err)
+		}
+
+		// NOTICE: The connection to the device is closed when the SFTP client is.
+		go func() {
+			client.Wait() //nolint:errcheck
+			conn.Close()
+		}()
+
+		return client, nil
+	}
+}
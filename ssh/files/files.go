@@ -0,0 +1,254 @@
+// Package files transfers files to and from the devices, through the SFTP subsystem opened on a SSH connection to the
+// server itself, authenticated with the credentials of a device's user. Its routes are internal, reached through the
+// API, what checks the ShellHub's user permissions before forwarding the requests.
+package files
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/sftp"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/dialer"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	FilesURL     = "/devices/:uid/files"
+	ListFilesURL = "/devices/:uid/files/list"
+)
+
+var (
+	ErrRequest   = errors.New("the path, the user and its credentials are required")
+	ErrDirectory = errors.New("the path is a directory")
+)
+
+// Dialer opens the SFTP subsystem on the device of the request, authenticated as its user. Closing the client must
+// close the connection to the device too.
+type Dialer func(req *requests.DeviceFile) (*sftp.Client, error)
+
+type handler struct {
+	dial Dialer
+}
+
+// Register registers the files' routes on the router.
+func Register(router *echo.Echo, dial Dialer) {
+	h := &handler{dial: dial}
+
+	router.GET(ListFilesURL, h.list)
+	router.GET(FilesURL, h.download)
+	router.PUT(FilesURL, h.upload)
+	router.DELETE(FilesURL, h.remove)
+}
+
+// bind binds the request from the path, the query and the headers, leaving the body, what is the file's content on
+// uploads, untouched.
+func bind(c echo.Context) (*requests.DeviceFile, error) {
+	req := new(requests.DeviceFile)
+
+	binder := new(echo.DefaultBinder)
+	if err := binder.BindPathParams(c, req); err != nil {
+		return nil, err
+	}
+
+	if err := binder.BindQueryParams(c, req); err != nil {
+		return nil, err
+	}
+
+	if err := binder.BindHeaders(c, req); err != nil {
+		return nil, err
+	}
+
+	if req.Path == "" || req.User == "" || ((req.Fingerprint == "" || req.Challenge == "" || req.Signature == "") && req.Password == "") {
+		return nil, ErrRequest
+	}
+
+	return req, nil
+}
+
+// logger returns the logger of the transfer's request.
+func logger(req *requests.DeviceFile) *log.Entry {
+	return log.WithFields(log.Fields{
+		"device":   req.UID,
+		"user":     req.User,
+		"path":     req.Path,
+		"username": req.Username,
+	})
+}
+
+// reply replies the error to the client, with the status related to it.
+func reply(c echo.Context, req *requests.DeviceFile, err error) error {
+	status := http.StatusInternalServerError
+
+	var dial *dialer.DialError
+	switch {
+	case errors.Is(err, ErrRequest), errors.Is(err, ErrDirectory):
+		status = http.StatusBadRequest
+	case errors.As(err, &dial), errors.Is(err, fs.ErrPermission):
+		status = http.StatusForbidden
+	case errors.Is(err, fs.ErrNotExist):
+		status = http.StatusNotFound
+	}
+
+	if req != nil {
+		logger(req).WithError(err).WithField("status", status).Warn("failed to transfer the file")
+	}
+
+	return c.JSON(status, err.Error())
+}
+
+// file converts the file's information to its model.
+func file(info fs.FileInfo) models.File {
+	return models.File{
+		Name:       info.Name(),
+		Size:       info.Size(),
+		Mode:       info.Mode().String(),
+		Dir:        info.IsDir(),
+		ModifiedAt: info.ModTime(),
+	}
+}
+
+func (h *handler) list(c echo.Context) error {
+	req, err := bind(c)
+	if err != nil {
+		return reply(c, nil, err)
+	}
+
+	client, err := h.dial(req)
+	if err != nil {
+		return reply(c, req, err)
+	}
+
+	defer client.Close()
+
+	infos, err := client.ReadDir(req.Path)
+	if err != nil {
+		return reply(c, req, err)
+	}
+
+	files := make([]models.File, len(infos))
+	for i, info := range infos {
+		files[i] = file(info)
+	}
+
+	logger(req).WithField("files", len(files)).Info("directory listed")
+
+	return c.JSON(http.StatusOK, files)
+}
+
+func (h *handler) download(c echo.Context) error {
+	req, err := bind(c)
+	if err != nil {
+		return reply(c, nil, err)
+	}
+
+	client, err := h.dial(req)
+	if err != nil {
+		return reply(c, req, err)
+	}
+
+	defer client.Close()
+
+	info, err := client.Stat(req.Path)
+	if err != nil {
+		return reply(c, req, err)
+	}
+
+	if info.IsDir() {
+		return reply(c, req, ErrDirectory)
+	}
+
+	content, err := client.Open(req.Path)
+	if err != nil {
+		return reply(c, req, err)
+	}
+
+	defer content.Close()
+
+	c.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(info.Size(), 10))
+	c.Response().Header().Set(echo.HeaderContentType, echo.MIMEOctetStream)
+	c.Response().WriteHeader(http.StatusOK)
+
+	written, err := io.Copy(c.Response(), content)
+	if err != nil {
+		// NOTICE: The response's status was already sent, so the client only notices the failure by the content's
+		// length.
+		logger(req).WithError(err).WithField("bytes", written).Warn("failed to download the file")
+
+		return nil
+	}
+
+	logger(req).WithField("bytes", written).Info("file downloaded")
+
+	return nil
+}
+
+func (h *handler) upload(c echo.Context) error {
+	req, err := bind(c)
+	if err != nil {
+		return reply(c, nil, err)
+	}
+
+	client, err := h.dial(req)
+	if err != nil {
+		return reply(c, req, err)
+	}
+
+	defer client.Close()
+
+	if info, err := client.Stat(req.Path); err == nil && info.IsDir() {
+		return reply(c, req, ErrDirectory)
+	}
+
+	content, err := client.Create(req.Path)
+	if err != nil {
+		return reply(c, req, err)
+	}
+
+	written, err := content.ReadFrom(c.Request().Body)
+	if err != nil {
+		content.Close()
+
+		return reply(c, req, err)
+	}
+
+	if err := content.Close(); err != nil {
+		return reply(c, req, err)
+	}
+
+	info, err := client.Stat(req.Path)
+	if err != nil {
+		return reply(c, req, err)
+	}
+
+	logger(req).WithField("bytes", written).Info("file uploaded")
+
+	return c.JSON(http.StatusOK, file(info))
+}
+
+func (h *handler) remove(c echo.Context) error {
+	req, err := bind(c)
+	if err != nil {
+		return reply(c, nil, err)
+	}
+
+	client, err := h.dial(req)
+	if err != nil {
+		return reply(c, req, err)
+	}
+
+	defer client.Close()
+
+	if err := client.Remove(req.Path); err != nil {
+		return reply(c, req, err)
+	}
+
+	logger(req).Info("file removed")
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,112 @@
+package files
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/sftp"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/dialer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memory creates a [Dialer] to an in-memory filesystem shared among its connections.
+func memory(t *testing.T) Dialer {
+	t.Helper()
+
+	handlers := sftp.InMemHandler()
+
+	return func(req *requests.DeviceFile) (*sftp.Client, error) {
+		if req.Password != "secret" {
+			return nil, &dialer.DialError{Err: dialer.ErrAuthentication}
+		}
+
+		server, client := net.Pipe()
+
+		go sftp.NewRequestServer(server, handlers).Serve() //nolint:errcheck
+
+		return sftp.NewClientPipe(client, client)
+	}
+}
+
+// request sends a request to the files' routes, authenticated with the password.
+func request(router *echo.Echo, method, target, password string, body io.Reader) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set("X-Device-Password", password)
+	req.Header.Set("X-Username", "john")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestFiles(t *testing.T) {
+	router := echo.New()
+	Register(router, memory(t))
+
+	t.Run("uploads a file", func(t *testing.T) {
+		rec := request(router, http.MethodPut, "/devices/device/files?path=/app.conf&user=root", "secret", strings.NewReader("debug = true"))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var file models.File
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&file))
+		assert.Equal(t, "app.conf", file.Name)
+		assert.Equal(t, int64(12), file.Size)
+		assert.False(t, file.Dir)
+	})
+
+	t.Run("lists a directory", func(t *testing.T) {
+		rec := request(router, http.MethodGet, "/devices/device/files/list?path=/&user=root", "secret", nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var files []models.File
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&files))
+		require.Len(t, files, 1)
+		assert.Equal(t, "app.conf", files[0].Name)
+	})
+
+	t.Run("downloads a file", func(t *testing.T) {
+		rec := request(router, http.MethodGet, "/devices/device/files?path=/app.conf&user=root", "secret", nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		assert.Equal(t, "12", rec.Header().Get(echo.HeaderContentLength))
+		assert.Equal(t, "debug = true", rec.Body.String())
+	})
+
+	t.Run("fails to download a directory", func(t *testing.T) {
+		rec := request(router, http.MethodGet, "/devices/device/files?path=/&user=root", "secret", nil)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("removes a file", func(t *testing.T) {
+		rec := request(router, http.MethodDelete, "/devices/device/files?path=/app.conf&user=root", "secret", nil)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		rec = request(router, http.MethodGet, "/devices/device/files?path=/app.conf&user=root", "secret", nil)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("fails when the credentials are missing", func(t *testing.T) {
+		rec := request(router, http.MethodGet, "/devices/device/files?path=/app.conf&user=root", "", nil)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("fails when the public key's signature is missing", func(t *testing.T) {
+		rec := request(router, http.MethodGet, "/devices/device/files?path=/app.conf&user=root&fingerprint=fingerprint", "", nil)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("fails when the user cannot be authenticated", func(t *testing.T) {
+		rec := request(router, http.MethodGet, "/devices/device/files?path=/app.conf&user=root", "wrong", nil)
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
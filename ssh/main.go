@@ -5,17 +5,25 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"os"
 	"runtime"
 
 	"github.com/labstack/echo-contrib/pprof"
 	"github.com/labstack/echo/v4"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/connman"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
+	"github.com/shellhub-io/shellhub/ssh/commands"
+	"github.com/shellhub-io/shellhub/ssh/files"
 	sshTunnel "github.com/shellhub-io/shellhub/ssh/pkg/tunnel"
 	"github.com/shellhub-io/shellhub/ssh/server"
+	"github.com/shellhub-io/shellhub/ssh/session"
 	"github.com/shellhub-io/shellhub/ssh/web"
+	"github.com/shellhub-io/shellhub/ssh/web/pkg/crypter"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -38,6 +46,26 @@ func main() {
 		log.Fatal("failed to create internal client")
 	}
 
+	registry, err := connman.NewRedisRegistry(env.RedisURI)
+	if err != nil {
+		log.WithError(err).Fatal("failed to create the connections registry")
+	}
+
+	instance := env.InstanceAddress
+	if instance == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			log.WithError(err).Fatal("failed to get the host name")
+		}
+
+		instance = net.JoinHostPort(hostname, "8080")
+	}
+
+	// NOTICE: The devices' connections are shared among the instances of the service, so a device connected to any of
+	// them can be reached from all the others.
+	tunnel.Tunnel.SetRegistry(registry, instance)
+	session.SetRegistry(registry, instance)
+
 	router := tunnel.GetRouter()
 	router.POST("/sessions/:uid/close", func(c echo.Context) error {
 		exit := func(status int, err error) error {
@@ -59,6 +87,8 @@ func main() {
 			return exit(http.StatusInternalServerError, err)
 		}
 
+		defer conn.Close()
+
 		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/ssh/close/%s", uid), nil)
 		if err != nil {
 			return exit(http.StatusInternalServerError, err)
@@ -68,6 +98,26 @@ func main() {
 			return exit(http.StatusInternalServerError, err)
 		}
 
+		// NOTICE: The agent closes its side of the session, but the client's connection is held by the instance that
+		// handles the session, what may not be this one, so we drop it there too.
+		if err := session.DisconnectSession(c.Request().Context(), uid); err != nil && !errors.Is(err, connman.ErrNoConnection) {
+			log.WithError(err).WithField("uid", uid).Warn("failed to disconnect the session's client")
+		}
+
+		return c.NoContent(http.StatusOK)
+	})
+
+	router.POST(session.DisconnectURL, func(c echo.Context) error {
+		// NOTICE: Only the sessions handled by this instance are disconnected, avoiding loops between instances.
+		sess, ok := session.Lookup(c.Param("uid"))
+		if !ok {
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		if err := sess.Disconnect(); err != nil {
+			return c.JSON(http.StatusInternalServerError, err.Error())
+		}
+
 		return c.NoContent(http.StatusOK)
 	})
 
@@ -116,11 +166,40 @@ func main() {
 		return nil
 	})
 
+	// NOTICE: Files are transferred through the SFTP subsystem of a connection to this server, as the user of the
+	// device.
+	files.Register(router, files.NewDialer(env.LocalAddress(), tunnel.API))
+	commands.Register(router, commands.NewDialer(env.LocalAddress(), tunnel.API))
+
 	router.GET("/healthcheck", func(c echo.Context) error {
 		return c.String(http.StatusOK, "OK")
 	})
 
-	web.NewSSHServerBridge(router.Router())
+	storage, err := cache.NewRedisCache(env.RedisURI)
+	if err != nil {
+		log.WithError(err).Fatal("failed to create the web terminal's cache")
+	}
+
+	var keys *crypter.Crypter
+	if env.WebKeys != "" {
+		keys, err = crypter.Parse(env.WebKeys)
+	} else {
+		log.Warn("no web terminal key was defined, so a random one is used and the web terminal only works with a single instance")
+
+		keys, err = crypter.Generate()
+	}
+
+	if err != nil {
+		log.WithError(err).Fatal("failed to load the web terminal's keys")
+	}
+
+	web.NewSSHServerBridge(router.Router(), &web.BridgeOptions{
+		Address:  env.LocalAddress(),
+		Cache:    storage,
+		Crypter:  keys,
+		Instance: instance,
+		Grace:    env.WebGracePeriod,
+	})
 
 	if envs.IsDevelopment() {
 		runtime.SetBlockProfileRate(1)
@@ -131,5 +210,7 @@ func main() {
 
 	go http.ListenAndServe(":8080", router) // nolint:errcheck
 
-	log.Fatal(server.NewServer(env, tunnel.Tunnel).ListenAndServe())
+	if err := server.NewServer(env, tunnel.Tunnel).ListenAndServe(); err != nil {
+		log.Fatal(err)
+	}
 }
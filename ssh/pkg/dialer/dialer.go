@@ -0,0 +1,158 @@
+// Package dialer connects the ssh service to its own SSH server, as a device's user, to reach the devices on behalf of
+// the ShellHub's services, like the file transfers and the commands executed by the jobs.
+package dialer
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/magickey"
+	"golang.org/x/crypto/ssh"
+)
+
+var (
+	ErrFindDevice     = errors.New("failed to find the device")
+	ErrFindPublicKey  = errors.New("failed to find the public key on the namespace")
+	ErrForbiddenKey   = errors.New("the public key cannot be used by the user on the device")
+	ErrSignature      = errors.New("the signature doesn't match the public key")
+	ErrFindNamespace  = errors.New("failed to find the device's namespace")
+	ErrMFARequired    = errors.New("the device's namespace requires the MFA's code of the public key's creator")
+	ErrMFACode        = errors.New("the MFA's code is invalid")
+	ErrAuthentication = errors.New("failed to authenticate the user on the device")
+)
+
+// Credentials are the credentials of the device's user. Either the password or the fingerprint of a public key stored
+// on the namespace, with the challenge signed by its private key, must be informed. When the device's namespace
+// requires MFA, the public key also needs the TOTP code of the ShellHub's user who created it.
+type Credentials struct {
+	Device      string
+	User        string
+	Fingerprint string
+	// Challenge is the data signed by the public key's private key.
+	Challenge string
+	// Signature is the challenge's signature, encoded in base64, what proves the caller holds the private key.
+	Signature string
+	// MFA is the TOTP code of the ShellHub's user who created the public key.
+	MFA      string
+	Password string
+}
+
+// DialError is the error of a connection to the device that couldn't be established, what happens when the user
+// couldn't be authenticated or the device couldn't be reached.
+type DialError struct {
+	Err error
+}
+
+func (e *DialError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DialError) Unwrap() error {
+	return e.Err
+}
+
+// auth returns the authentication methods of the user. A public key stored on the namespace authenticates the user
+// through the magic key, after the challenge's signature is verified, the key is evaluated to the device and the user,
+// and the MFA's code of its creator is checked when the namespace requires it, as the SSH server doesn't ask the magic
+// key for it.
+func auth(api internalclient.Client, creds *Credentials) ([]ssh.AuthMethod, error) {
+	if creds.Password != "" {
+		return []ssh.AuthMethod{ssh.Password(creds.Password)}, nil
+	}
+
+	device, err := api.GetDevice(creds.Device)
+	if err != nil {
+		return nil, errors.Join(ErrFindDevice, err)
+	}
+
+	key, err := api.GetPublicKey(creds.Fingerprint, device.TenantID)
+	if err != nil {
+		return nil, errors.Join(ErrFindPublicKey, err)
+	}
+
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey(key.Data) //nolint:dogsled
+	if err != nil {
+		return nil, errors.Join(ErrFindPublicKey, err)
+	}
+
+	digest, err := base64.StdEncoding.DecodeString(creds.Signature)
+	if err != nil || creds.Signature == "" {
+		return nil, ErrSignature
+	}
+
+	if err := pubKey.Verify([]byte(creds.Challenge), &ssh.Signature{ //nolint:exhaustruct
+		Format: pubKey.Type(),
+		Blob:   digest,
+	}); err != nil {
+		return nil, ErrSignature
+	}
+
+	if ok, err := api.EvaluateKey(creds.Fingerprint, device, creds.User); err != nil || !ok {
+		return nil, ErrForbiddenKey
+	}
+
+	if err := evaluateMFA(api, device, key, creds.MFA); err != nil {
+		return nil, err
+	}
+
+	signer, err := ssh.NewSignerFromKey(magickey.GetRerefence())
+	if err != nil {
+		return nil, err
+	}
+
+	return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil
+}
+
+// evaluateMFA checks the TOTP code of the ShellHub's user who created the public key when the device's namespace
+// requires MFA.
+func evaluateMFA(api internalclient.Client, device *models.Device, key *models.PublicKey, code string) error {
+	namespace, errs := api.NamespaceLookup(device.TenantID)
+	if len(errs) > 0 || namespace == nil {
+		return ErrFindNamespace
+	}
+
+	if namespace.Settings == nil || !namespace.Settings.MFARequired {
+		return nil
+	}
+
+	if key.CreatedBy == "" || code == "" {
+		return ErrMFARequired
+	}
+
+	if err := api.AuthMFACode(key.CreatedBy, code); err != nil {
+		return errors.Join(ErrMFACode, err)
+	}
+
+	return nil
+}
+
+// Dial connects to the SSH server listening on the address, authenticated as the device's user. Failures to
+// authenticate the user or to reach the device are returned as [DialError].
+func Dial(address string, api internalclient.Client, creds *Credentials) (*ssh.Client, error) {
+	methods, err := auth(api, creds)
+	if err != nil {
+		return nil, &DialError{Err: err}
+	}
+
+	conn, err := ssh.Dial("tcp", address, &ssh.ClientConfig{ //nolint:exhaustruct
+		User:            fmt.Sprintf("%s@%s", creds.User, creds.Device),
+		Auth:            methods,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
+		BannerCallback: func(message string) error {
+			// NOTICE: The server sends a banner when it refuses the connection, like when the device is offline.
+			if message != "" {
+				return errors.New(message)
+			}
+
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, &DialError{Err: errors.Join(ErrAuthentication, err)}
+	}
+
+	return conn, nil
+}
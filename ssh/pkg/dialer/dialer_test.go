@@ -0,0 +1,156 @@
+package dialer
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestAuth(t *testing.T) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := ssh.NewSignerFromKey(private)
+	require.NoError(t, err)
+
+	sign := func(data string) string {
+		signature, err := signer.Sign(rand.Reader, []byte(data))
+		require.NoError(t, err)
+
+		return base64.StdEncoding.EncodeToString(signature.Blob)
+	}
+
+	device := &models.Device{UID: "uid", TenantID: "tenant"}
+	key := &models.PublicKey{Data: ssh.MarshalAuthorizedKey(signer.PublicKey()), Fingerprint: "fingerprint", CreatedBy: "user"}
+	mfa := &models.Namespace{Settings: &models.NamespaceSettings{MFARequired: true}}
+
+	cases := []struct {
+		description   string
+		creds         *Credentials
+		requiredMocks func(api *mocks.Client)
+		expected      error
+	}{
+		{
+			description:   "succeeds with the password",
+			creds:         &Credentials{Device: "uid", User: "root", Password: "secret"},
+			requiredMocks: func(api *mocks.Client) {},
+			expected:      nil,
+		},
+		{
+			description: "fails when the device isn't found",
+			creds:       &Credentials{Device: "uid", User: "root", Fingerprint: "fingerprint", Challenge: "root", Signature: sign("root")},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "uid").Return(nil, errors.New("error")).Once()
+			},
+			expected: ErrFindDevice,
+		},
+		{
+			description: "fails when the public key isn't found",
+			creds:       &Credentials{Device: "uid", User: "root", Fingerprint: "fingerprint", Challenge: "root", Signature: sign("root")},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "uid").Return(device, nil).Once()
+				api.On("GetPublicKey", "fingerprint", "tenant").Return(nil, errors.New("error")).Once()
+			},
+			expected: ErrFindPublicKey,
+		},
+		{
+			description: "fails without the signature",
+			creds:       &Credentials{Device: "uid", User: "root", Fingerprint: "fingerprint", Challenge: "root"},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "uid").Return(device, nil).Once()
+				api.On("GetPublicKey", "fingerprint", "tenant").Return(key, nil).Once()
+			},
+			expected: ErrSignature,
+		},
+		{
+			description: "fails when the signature is of other challenge",
+			creds:       &Credentials{Device: "uid", User: "root", Fingerprint: "fingerprint", Challenge: "root", Signature: sign("admin")},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "uid").Return(device, nil).Once()
+				api.On("GetPublicKey", "fingerprint", "tenant").Return(key, nil).Once()
+			},
+			expected: ErrSignature,
+		},
+		{
+			description: "fails when the public key cannot be used by the user",
+			creds:       &Credentials{Device: "uid", User: "root", Fingerprint: "fingerprint", Challenge: "root", Signature: sign("root")},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "uid").Return(device, nil).Once()
+				api.On("GetPublicKey", "fingerprint", "tenant").Return(key, nil).Once()
+				api.On("EvaluateKey", "fingerprint", device, "root").Return(false, nil).Once()
+			},
+			expected: ErrForbiddenKey,
+		},
+		{
+			description: "fails when the namespace requires MFA and its code is missing",
+			creds:       &Credentials{Device: "uid", User: "root", Fingerprint: "fingerprint", Challenge: "root", Signature: sign("root")},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "uid").Return(device, nil).Once()
+				api.On("GetPublicKey", "fingerprint", "tenant").Return(key, nil).Once()
+				api.On("EvaluateKey", "fingerprint", device, "root").Return(true, nil).Once()
+				api.On("NamespaceLookup", "tenant").Return(mfa, nil).Once()
+			},
+			expected: ErrMFARequired,
+		},
+		{
+			description: "fails when the namespace requires MFA and its code is invalid",
+			creds:       &Credentials{Device: "uid", User: "root", Fingerprint: "fingerprint", Challenge: "root", Signature: sign("root"), MFA: "000000"},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "uid").Return(device, nil).Once()
+				api.On("GetPublicKey", "fingerprint", "tenant").Return(key, nil).Once()
+				api.On("EvaluateKey", "fingerprint", device, "root").Return(true, nil).Once()
+				api.On("NamespaceLookup", "tenant").Return(mfa, nil).Once()
+				api.On("AuthMFACode", "user", "000000").Return(errors.New("error")).Once()
+			},
+			expected: ErrMFACode,
+		},
+		{
+			description: "succeeds when the namespace requires MFA and its code is valid",
+			creds:       &Credentials{Device: "uid", User: "root", Fingerprint: "fingerprint", Challenge: "root", Signature: sign("root"), MFA: "123456"},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "uid").Return(device, nil).Once()
+				api.On("GetPublicKey", "fingerprint", "tenant").Return(key, nil).Once()
+				api.On("EvaluateKey", "fingerprint", device, "root").Return(true, nil).Once()
+				api.On("NamespaceLookup", "tenant").Return(mfa, nil).Once()
+				api.On("AuthMFACode", "user", "123456").Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the challenge is signed by the public key",
+			creds:       &Credentials{Device: "uid", User: "root", Fingerprint: "fingerprint", Challenge: "root", Signature: sign("root")},
+			requiredMocks: func(api *mocks.Client) {
+				api.On("GetDevice", "uid").Return(device, nil).Once()
+				api.On("GetPublicKey", "fingerprint", "tenant").Return(key, nil).Once()
+				api.On("EvaluateKey", "fingerprint", device, "root").Return(true, nil).Once()
+				api.On("NamespaceLookup", "tenant").Return(&models.Namespace{}, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			methods, err := auth(api, tc.creds)
+			if tc.expected != nil {
+				assert.ErrorIs(t, err, tc.expected)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, methods, 1)
+			}
+
+			api.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,239 @@
+// Package hostkeys manages the SSH host keys of the server, loaded from a directory, and their rotation through the
+// OpenSSH's host keys update extension.
+//
+// A SSH server can only use one host key of each type on handshakes, so, when a directory has more than one key of the
+// same type, the oldest one, by modification time, is the active one and the others are only published to the clients
+// through [AnnounceRequest]. To rotate a key, a new one is added to the directory and published, letting the clients
+// learn it, and, later, the old one is removed, making the new one active.
+//
+// https://cvsweb.openbsd.org/src/usr.bin/ssh/PROTOCOL
+package hostkeys
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	// AnnounceRequest is the global request sent to the client, after the authentication, with every host key of the
+	// server.
+	AnnounceRequest = "hostkeys-00@openssh.com"
+	// ProveRequest is the global request sent by the client to ask the server to prove the ownership of the host keys
+	// it didn't know yet.
+	ProveRequest = "hostkeys-prove-00@openssh.com"
+)
+
+var (
+	ErrNoHostKeys   = errors.New("no host key was found")
+	ErrUnknownKey   = errors.New("the host key isn't owned by the server")
+	ErrInvalidProof = errors.New("failed to parse the host keys to prove")
+)
+
+// Keyring is the set of host keys loaded from a directory, or from a single file, safe to be reloaded while used.
+type Keyring struct {
+	path string
+
+	mu sync.RWMutex
+	// keys are sorted from the oldest to the newest one.
+	keys []gossh.Signer
+}
+
+// NewKeyring creates a [Keyring] loading the host keys from the path, what can be a directory or a single key file.
+func NewKeyring(path string) (*Keyring, error) {
+	keyring := &Keyring{path: path}
+	if err := keyring.Load(); err != nil {
+		return nil, err
+	}
+
+	return keyring, nil
+}
+
+// Load loads, again, the host keys from the keyring's path. Files that aren't private keys are ignored and, when no key
+// is found, the previous ones are kept.
+func (k *Keyring) Load() error {
+	info, err := os.Stat(k.path)
+	if err != nil {
+		return err
+	}
+
+	files := []string{k.path}
+	if info.IsDir() {
+		entries, err := os.ReadDir(k.path)
+		if err != nil {
+			return err
+		}
+
+		files = files[:0]
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(k.path, entry.Name()))
+			}
+		}
+	}
+
+	type key struct {
+		signer  gossh.Signer
+		modtime time.Time
+	}
+
+	keys := make([]key, 0, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		signer, err := gossh.ParsePrivateKey(data)
+		if err != nil {
+			log.WithError(err).WithField("file", file).Debug("ignoring a file that isn't a host key")
+
+			continue
+		}
+
+		keys = append(keys, key{signer: signer, modtime: info.ModTime()})
+	}
+
+	if len(keys) == 0 {
+		return ErrNoHostKeys
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].modtime.Before(keys[j].modtime)
+	})
+
+	signers := make([]gossh.Signer, len(keys))
+	for i, key := range keys {
+		signers[i] = key.signer
+	}
+
+	k.mu.Lock()
+	k.keys = signers
+	k.mu.Unlock()
+
+	return nil
+}
+
+// Active returns the host keys used on handshakes, the oldest one of each type.
+func (k *Keyring) Active() []gossh.Signer {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	types := make(map[string]bool)
+	active := make([]gossh.Signer, 0, len(k.keys))
+	for _, signer := range k.keys {
+		if kind := signer.PublicKey().Type(); !types[kind] {
+			types[kind] = true
+			active = append(active, signer)
+		}
+	}
+
+	return active
+}
+
+// Signers returns every host key, the active ones and the ones only published to the clients.
+func (k *Keyring) Signers() []gossh.Signer {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return append([]gossh.Signer(nil), k.keys...)
+}
+
+// Announce publishes every host key to the client, through the [AnnounceRequest], letting it learn the new keys before
+// the old ones are retired.
+func (k *Keyring) Announce(conn gossh.Conn) error {
+	var payload []byte
+	for _, signer := range k.Signers() {
+		payload = append(payload, marshalString(signer.PublicKey().Marshal())...)
+	}
+
+	_, _, err := conn.SendRequest(AnnounceRequest, false, payload)
+
+	return err
+}
+
+// Prove signs each host key requested by the client, through the [ProveRequest], with the connection's session ID,
+// proving that the server owns them. It returns the payload of the request's reply.
+func (k *Keyring) Prove(sessionID []byte, payload []byte) ([]byte, error) {
+	signers := k.Signers()
+
+	var reply []byte
+	for len(payload) > 0 {
+		var blob []byte
+		var ok bool
+		if blob, payload, ok = parseString(payload); !ok {
+			return nil, ErrInvalidProof
+		}
+
+		var signer gossh.Signer
+		for _, s := range signers {
+			if bytes.Equal(s.PublicKey().Marshal(), blob) {
+				signer = s
+
+				break
+			}
+		}
+
+		if signer == nil {
+			return nil, ErrUnknownKey
+		}
+
+		data := gossh.Marshal(struct {
+			Request   string
+			SessionID []byte
+			Key       []byte
+		}{ProveRequest, sessionID, blob})
+
+		var signature *gossh.Signature
+		var err error
+		// NOTICE: OpenSSH only accepts the legacy SHA-1 signatures of RSA keys when they are explicitly allowed, so
+		// the RSA keys are proved using SHA-512 instead.
+		if algorithmSigner, ok := signer.(gossh.AlgorithmSigner); ok && signer.PublicKey().Type() == gossh.KeyAlgoRSA {
+			signature, err = algorithmSigner.SignWithAlgorithm(rand.Reader, data, gossh.KeyAlgoRSASHA512)
+		} else {
+			signature, err = signer.Sign(rand.Reader, data)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		reply = append(reply, marshalString(gossh.Marshal(signature))...)
+	}
+
+	return reply, nil
+}
+
+// marshalString encodes the data as a SSH's string, prefixed by its length.
+func marshalString(data []byte) []byte {
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(data))), data...)
+}
+
+// parseString decodes a SSH's string, returning it and the rest of the data.
+func parseString(data []byte) ([]byte, []byte, bool) {
+	if len(data) < 4 {
+		return nil, nil, false
+	}
+
+	length := binary.BigEndian.Uint32(data)
+	data = data[4:]
+	if uint32(len(data)) < length {
+		return nil, nil, false
+	}
+
+	return data[:length], data[length:], true
+}
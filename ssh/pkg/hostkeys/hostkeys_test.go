@@ -0,0 +1,129 @@
+package hostkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func writeKey(t *testing.T, dir, name string, key crypto.PrivateKey, modtime time.Time) gossh.Signer {
+	t.Helper()
+
+	block, err := gossh.MarshalPrivateKey(key, "")
+	require.NoError(t, err)
+
+	file := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(block), 0o600))
+	require.NoError(t, os.Chtimes(file, modtime, modtime))
+
+	signer, err := gossh.NewSignerFromKey(key)
+	require.NoError(t, err)
+
+	return signer
+}
+
+func TestKeyring(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	_, oldKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	newer := writeKey(t, dir, "ssh_host_ed25519_key.new", newKey, now)
+	older := writeKey(t, dir, "ssh_host_ed25519_key", oldKey, now.Add(-time.Hour))
+	rsaSigner := writeKey(t, dir, "ssh_host_rsa_key", rsaKey, now.Add(-time.Minute))
+
+	// NOTICE: Files that aren't private keys, like the public ones, are ignored.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ssh_host_rsa_key.pub"), gossh.MarshalAuthorizedKey(rsaSigner.PublicKey()), 0o600))
+
+	keyring, err := NewKeyring(dir)
+	require.NoError(t, err)
+
+	marshal := func(signers []gossh.Signer) [][]byte {
+		keys := make([][]byte, len(signers))
+		for i, signer := range signers {
+			keys[i] = signer.PublicKey().Marshal()
+		}
+
+		return keys
+	}
+
+	t.Run("keeps the oldest key of each type as active", func(t *testing.T) {
+		assert.Equal(t, marshal([]gossh.Signer{older, rsaSigner}), marshal(keyring.Active()))
+		assert.Equal(t, marshal([]gossh.Signer{older, rsaSigner, newer}), marshal(keyring.Signers()))
+	})
+
+	t.Run("proves the ownership of the host keys", func(t *testing.T) {
+		sessionID := []byte("session")
+
+		payload := append(marshalString(newer.PublicKey().Marshal()), marshalString(rsaSigner.PublicKey().Marshal())...)
+
+		reply, err := keyring.Prove(sessionID, payload)
+		require.NoError(t, err)
+
+		for _, signer := range []gossh.Signer{newer, rsaSigner} {
+			blob, rest, ok := parseString(reply)
+			require.True(t, ok)
+			reply = rest
+
+			signature := new(gossh.Signature)
+			require.NoError(t, gossh.Unmarshal(blob, signature))
+
+			data := gossh.Marshal(struct {
+				Request   string
+				SessionID []byte
+				Key       []byte
+			}{ProveRequest, sessionID, signer.PublicKey().Marshal()})
+
+			assert.NoError(t, signer.PublicKey().Verify(data, signature))
+		}
+
+		assert.Empty(t, reply)
+	})
+
+	t.Run("fails to prove an unknown host key", func(t *testing.T) {
+		unknown, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		key, err := gossh.NewPublicKey(unknown)
+		require.NoError(t, err)
+
+		_, err = keyring.Prove([]byte("session"), marshalString(key.Marshal()))
+		assert.ErrorIs(t, err, ErrUnknownKey)
+	})
+
+	t.Run("fails to prove a malformed request", func(t *testing.T) {
+		_, err := keyring.Prove([]byte("session"), []byte{0, 0, 1})
+		assert.ErrorIs(t, err, ErrInvalidProof)
+	})
+
+	t.Run("activates the newer key when the older one is retired", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(dir, "ssh_host_ed25519_key")))
+		require.NoError(t, keyring.Load())
+
+		assert.Equal(t, marshal([]gossh.Signer{rsaSigner, newer}), marshal(keyring.Active()))
+	})
+
+	t.Run("keeps the keys when none is found", func(t *testing.T) {
+		empty := &Keyring{path: t.TempDir(), keys: keyring.Signers()}
+
+		assert.ErrorIs(t, empty.Load(), ErrNoHostKeys)
+		assert.Equal(t, marshal(keyring.Signers()), marshal(empty.Signers()))
+	})
+}
@@ -1,6 +1,8 @@
 package target
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"strings"
 )
@@ -39,6 +41,23 @@ func (t *Target) IsSSHID() bool {
 	return strings.Contains(t.Data, ".")
 }
 
+// IsUID checks if target is a device's UID, a SHA-256 hash encoded as hexadecimal.
+func (t *Target) IsUID() bool {
+	if len(t.Data) != hex.EncodedLen(sha256.Size) {
+		return false
+	}
+
+	_, err := hex.DecodeString(t.Data)
+
+	return err == nil
+}
+
+// IsAlias checks if target is only a device's name, or alias, without its namespace. It's resolved on the ShellHub's
+// user default namespace, what requires the user to be known.
+func (t *Target) IsAlias() bool {
+	return !t.IsSSHID() && !t.IsUID()
+}
+
 // SplitSSHID splits the SSHID into namespace and hostname as lower strings.
 // Namespace is the device's namespace and hostname is the device's name.
 func (t *Target) SplitSSHID() (string, string, error) {
@@ -78,6 +78,45 @@ func TestIsSSHID(t *testing.T) {
 	}
 }
 
+func TestIsAlias(t *testing.T) {
+	cases := []struct {
+		description string
+		target      *Target
+		expected    bool
+	}{
+		{
+			description: "returns false when Data is a SSHID",
+			target: &Target{
+				Username: "username",
+				Data:     "namespace.00-00-00-00-00-00",
+			},
+			expected: false,
+		},
+		{
+			description: "returns false when Data is a device's UID",
+			target: &Target{
+				Username: "username",
+				Data:     "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+			},
+			expected: false,
+		},
+		{
+			description: "returns true when Data is only a device's name",
+			target: &Target{
+				Username: "username",
+				Data:     "web01",
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.target.IsAlias())
+		})
+	}
+}
+
 func TestSplitSSHID(t *testing.T) {
 	type Expected struct {
 		namespace string
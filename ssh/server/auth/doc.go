@@ -1,6 +1,7 @@
 // Package auth provides authentication handlers for client connections.
 //
-// This package includes two authentication methods: [PasswordHandler] and [PublicKeyHandler].
+// This package includes three authentication methods: [PasswordHandler], [PublicKeyHandler] and [MFAHandler].
 // [PasswordHandler] is the second authentication method tried by the server to connect the client to the agent,
-// while [PublicKeyHandler] is the first authentication method attempted.
+// while [PublicKeyHandler] is the first authentication method attempted. [MFAHandler] is the keyboard-interactive
+// method offered after a public key when the device's namespace requires MFA.
 package auth
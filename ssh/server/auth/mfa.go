@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"strings"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	// MFAInstruction is shown to the client when asking for the TOTP code.
+	MFAInstruction = "This device's namespace requires multi-factor authentication."
+	// MFAQuestion is the prompt of the TOTP code.
+	MFAQuestion = "Verification code: "
+)
+
+// MFAHandler handles the keyboard-interactive authentication method used as the second factor of a public key
+// authentication, asking the client for the TOTP code of the ShellHub's user mapped to its key.
+//
+// The public key is the one already verified by [PublicKeyHandler], as the keyboard-interactive method is only offered
+// to the client after it.
+func MFAHandler(ctx gliderssh.Context, publicKey gossh.PublicKey) func(gossh.ConnMetadata, gossh.KeyboardInteractiveChallenge) (*gossh.Permissions, error) {
+	return func(_ gossh.ConnMetadata, challenge gossh.KeyboardInteractiveChallenge) (*gossh.Permissions, error) {
+		logger := log.WithFields(
+			log.Fields{
+				"uid":   ctx.SessionID(),
+				"sshid": session.SSHID(ctx),
+			})
+
+		logger.Trace("trying to use keyboard-interactive authentication for MFA")
+
+		sess, state := session.ObtainSession(ctx)
+		if state != session.StateMFA {
+			logger.Trace("failed to get the session waiting for the MFA from context on keyboard-interactive handler")
+
+			return ctx.Permissions().Permissions, ErrPermissionDenied
+		}
+
+		answers, err := challenge("", MFAInstruction, []string{MFAQuestion}, []bool{false})
+		if err != nil || len(answers) != 1 {
+			logger.WithError(err).Warn("failed to get the verification code from the client")
+
+			return ctx.Permissions().Permissions, ErrPermissionDenied
+		}
+
+		if err := sess.AuthMFA(ctx, session.AuthPublicKey(publicKey), strings.TrimSpace(answers[0])); err != nil {
+			logger.WithError(err).Warn("failed to authenticate on device using the MFA")
+
+			return ctx.Permissions().Permissions, ErrPermissionDenied
+		}
+
+		logger.Info("succeeded to use MFA authentication.")
+
+		ctx.SetValue(gliderssh.ContextKeyPublicKey, publicKey)
+
+		return ctx.Permissions().Permissions, nil
+	}
+}
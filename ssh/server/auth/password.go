@@ -13,11 +13,16 @@ func PasswordHandler(ctx gliderssh.Context, passwd string) bool {
 	logger := log.WithFields(
 		log.Fields{
 			"uid":   ctx.SessionID(),
-			"sshid": ctx.User(),
+			"sshid": session.SSHID(ctx),
 		})
 
 	logger.Trace("trying to use password authentication")
 
+	// NOTICE: Connections using ShellHub as a jump host are authenticated only by the ShellHub's certificates.
+	if session.IsJump(ctx) {
+		return false
+	}
+
 	sess, state := session.ObtainSession(ctx)
 	if state < session.StateEvaluated {
 		logger.Trace("failed to get the session from context on password handler")
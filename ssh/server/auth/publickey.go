@@ -1,42 +1,107 @@
 package auth
 
 import (
+	"errors"
 	"net"
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
 )
 
+// ErrPermissionDenied is returned to the client when an authentication method fails.
+var ErrPermissionDenied = errors.New("permission denied")
+
+// SessionOpener creates the connection's session on behalf of the ShellHub's user identity, returning the message
+// shown to the client when it fails.
+type SessionOpener func(ctx gliderssh.Context, identity string) (string, error)
+
 // PublicKeyHandler handles ShellHub client's connection using the public key authentication method.
-func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool {
-	logger := log.WithFields(
-		log.Fields{
-			"uid":   ctx.SessionID(),
-			"sshid": ctx.User(),
-		})
-
-	logger.Trace("trying to use public key authentication")
-
-	sess, state := session.ObtainSession(ctx)
-	if state < session.StateEvaluated {
-		logger.Trace("failed to get the session from context on public key handler")
-
-		conn, ok := ctx.Value("conn").(net.Conn)
-		if ok {
-			conn.Close()
+//
+// Unlike the [gliderssh.PublicKeyHandler], it's a [gossh.ServerConfig]'s callback, as the authentication partially
+// succeeds when the device's namespace requires MFA, asking the client for the TOTP code through [MFAHandler].
+//
+// Connections to a device without its namespace don't have a session until the client offers a certificate, as the
+// device is resolved on the default namespace of the certificate's user, through open, once the certificate is trusted
+// by the namespace where it was issued.
+func PublicKeyHandler(ctx gliderssh.Context, open SessionOpener) func(gossh.ConnMetadata, gossh.PublicKey) (*gossh.Permissions, error) {
+	return func(_ gossh.ConnMetadata, publicKey gossh.PublicKey) (*gossh.Permissions, error) {
+		logger := log.WithFields(
+			log.Fields{
+				"uid":   ctx.SessionID(),
+				"sshid": session.SSHID(ctx),
+			})
+
+		logger.Trace("trying to use public key authentication")
+
+		if session.IsJump(ctx) {
+			if err := session.AuthJump(ctx, publicKey); err != nil {
+				logger.WithError(err).Warn("failed to authenticate the jump connection")
+
+				return ctx.Permissions().Permissions, ErrPermissionDenied
+			}
+
+			logger.Info("succeeded to authenticate the jump connection")
+
+			ctx.SetValue(gliderssh.ContextKeyPublicKey, publicKey)
+
+			return ctx.Permissions().Permissions, nil
 		}
 
-		return false
-	}
+		sess, state := session.ObtainSession(ctx)
+		if state < session.StateCreated && session.IsAlias(ctx) {
+			cert, ok := publicKey.(*gossh.Certificate)
+			if !ok || cert.KeyId == "" {
+				logger.WithError(session.ErrAliasIdentity).Warn("failed to resolve the device without its namespace")
 
-	if err := sess.Auth(ctx, session.AuthPublicKey(publicKey)); err != nil {
-		logger.Warn("failed to authenticate on device using public key")
+				return ctx.Permissions().Permissions, ErrPermissionDenied
+			}
 
-		return false
-	}
+			if err := session.AuthAlias(ctx, cert); err != nil {
+				logger.WithError(err).Warn("failed to authenticate the certificate to resolve the device without its namespace")
 
-	logger.Info("succeeded to use public key authentication.")
+				return ctx.Permissions().Permissions, ErrPermissionDenied
+			}
+
+			if _, err := open(ctx, cert.KeyId); err != nil {
+				return ctx.Permissions().Permissions, ErrPermissionDenied
+			}
+
+			sess, state = session.ObtainSession(ctx)
+		}
 
-	return true
+		if state < session.StateEvaluated {
+			logger.Trace("failed to get the session from context on public key handler")
+
+			conn, ok := ctx.Value("conn").(net.Conn)
+			if ok {
+				conn.Close()
+			}
+
+			return ctx.Permissions().Permissions, ErrPermissionDenied
+		}
+
+		if err := sess.Auth(ctx, session.AuthPublicKey(publicKey)); err != nil {
+			if errors.Is(err, session.ErrMFARequired) {
+				logger.Info("public key authentication requires the MFA")
+
+				return ctx.Permissions().Permissions, &gossh.PartialSuccessError{
+					Next: gossh.ServerAuthCallbacks{
+						KeyboardInteractiveCallback: MFAHandler(ctx, publicKey),
+					},
+				}
+			}
+
+			logger.Warn("failed to authenticate on device using public key")
+
+			return ctx.Permissions().Permissions, ErrPermissionDenied
+		}
+
+		logger.Info("succeeded to use public key authentication.")
+
+		ctx.SetValue(gliderssh.ContextKeyPublicKey, publicKey)
+
+		return ctx.Permissions().Permissions, nil
+	}
 }
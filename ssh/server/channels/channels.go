@@ -12,5 +12,31 @@ const (
 	//
 	// Example of dynamic application-level port forwarding: `ssh -D 1080 user@sshid`.
 	DirectTCPIPChannel = "direct-tcpip"
-	SessionChannel     = "session"
+	// ForwardedTCPIPChannel is the channel type opened by the agent for each connection accepted on a port requested
+	// through "remote port forwarding".
+	//
+	// Example of remote port forwarding: `ssh -R 8080:localhost:80 user@sshid`.
+	ForwardedTCPIPChannel = "forwarded-tcpip"
+	// AgentForwardingChannel is the channel type opened by the agent for each connection to the client's SSH agent
+	// forwarded to the session.
+	//
+	// Example of agent forwarding: `ssh -A user@sshid`.
+	AgentForwardingChannel = "auth-agent@openssh.com"
+	// X11Channel is the channel type opened by the agent for each X11 connection accepted on the session's display.
+	//
+	// Example of X11 forwarding: `ssh -X user@sshid`.
+	X11Channel     = "x11"
+	SessionChannel = "session"
+)
+
+const (
+	// TCPIPForwardRequest is the global request type used by the client to ask for a port be listened on the device,
+	// forwarding its connections back to the client.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-7.1
+	TCPIPForwardRequest = "tcpip-forward"
+	// CancelTCPIPForwardRequest is the global request type used by the client to cancel a "tcpip-forward".
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-7.1
+	CancelTCPIPForwardRequest = "cancel-tcpip-forward"
 )
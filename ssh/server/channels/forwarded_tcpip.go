@@ -0,0 +1,86 @@
+package channels
+
+import (
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// DefaultTCPIPForwardHandler is the global request's handler for "tcpip-forward" and "cancel-tcpip-forward" requests,
+// used by "remote port forwarding".
+//
+// The request is relayed to the agent, what listens on the device and opens a "forwarded-tcpip" channel for each
+// connection accepted there. Those channels are relayed back to the client by [relayChannels].
+//
+// Example of remote port forwarding: `ssh -R 8080:localhost:80 user@sshid`.
+func DefaultTCPIPForwardHandler(ctx gliderssh.Context, server *gliderssh.Server, req *gossh.Request) (bool, []byte) {
+	sess, _ := session.ObtainSession(ctx)
+	if sess == nil || sess.AgentClient == nil {
+		return false, nil
+	}
+
+	type requestData struct {
+		BindAddr string
+		BindPort uint32
+	}
+
+	data := new(requestData)
+	if err := gossh.Unmarshal(req.Payload, data); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"username": sess.Target.Username,
+			"sshid":    sess.Target.Data,
+			"request":  req.Type,
+		}).Error("failed to parse forward request data")
+
+		return false, nil
+	}
+
+	logger := log.WithFields(log.Fields{
+		"username":  sess.Target.Username,
+		"sshid":     sess.Target.Data,
+		"request":   req.Type,
+		"bind_addr": data.BindAddr,
+		"bind_port": data.BindPort,
+	})
+
+	if req.Type == TCPIPForwardRequest {
+		if server.ReversePortForwardingCallback == nil || !server.ReversePortForwardingCallback(ctx, data.BindAddr, data.BindPort) {
+			logger.Info("remote port forwarding is disabled")
+
+			return false, []byte("port forwarding is disabled")
+		}
+
+		conn, ok := ctx.Value(gliderssh.ContextKeyConn).(*gossh.ServerConn)
+		if !ok {
+			logger.Error("failed to get the client's connection")
+
+			return false, nil
+		}
+
+		// NOTICE: The "forwarded-tcpip" channels can be handled only once per agent's connection, so the first
+		// request starts relaying them for all the next ones.
+		if chans := sess.AgentClient.HandleChannelOpen(ForwardedTCPIPChannel); chans != nil {
+			go relayChannels(sess, conn, chans)
+
+			go func() {
+				// NOTICE: A connection used only to remote port forwarding, like when the "-N" flag is set, has no
+				// session's channel to finish the session, so we wait for the connection be closed to finish it.
+				conn.Wait() //nolint:errcheck
+
+				sess.Finish() //nolint:errcheck
+			}()
+		}
+	}
+
+	ok, payload, err := sess.AgentClient.SendRequest(req.Type, true, req.Payload)
+	if err != nil {
+		logger.WithError(err).Error("failed to relay the forward request to the agent")
+
+		return false, nil
+	}
+
+	logger.WithField("ok", ok).Info("forward request relayed to the agent")
+
+	return ok, payload
+}
@@ -0,0 +1,68 @@
+package channels
+
+import (
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// jumpDirectTCPIPHandler handles the direct-tcpip channels of connections using ShellHub as a jump host. Each channel
+// to a device's SSH port is served as a new connection to that device, what goes through the same evaluation,
+// authentication and recording of a connection made through a SSHID.
+//
+// Example of a jump connection: `ssh -J shellhub user@device.namespace`.
+func jumpDirectTCPIPHandler(server *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, jump *session.Jump) {
+	type channelData struct {
+		DestAddr   string
+		DestPort   uint32
+		OriginAddr string
+		OriginPort uint32
+	}
+
+	logger := log.WithFields(log.Fields{
+		"identity": jump.Identity,
+		"ip":       jump.IPAddress,
+	})
+
+	data := new(channelData)
+	if err := gossh.Unmarshal(newChan.ExtraData(), data); err != nil {
+		newChan.Reject(gossh.ConnectionFailed, "faild to parse forward data: "+err.Error()) //nolint:errcheck
+		logger.WithError(err).Error("failed to parse jump data")
+
+		return
+	}
+
+	logger = logger.WithFields(log.Fields{
+		"dest_addr": data.DestAddr,
+		"dest_port": data.DestPort,
+	})
+
+	if !jump.PortForwarding() {
+		newChan.Reject(gossh.Prohibited, "port forwarding is not permitted by the certificate") //nolint:errcheck
+		logger.Info("port forwarding is not permitted by the certificate")
+
+		return
+	}
+
+	sshid, err := jump.Resolve(data.DestAddr, data.DestPort)
+	if err != nil {
+		newChan.Reject(gossh.Prohibited, "jump to this destination is not allowed") //nolint:errcheck
+		logger.WithError(err).Info("failed to resolve the jump's destination")
+
+		return
+	}
+
+	channel, reqs, err := newChan.Accept()
+	if err != nil {
+		logger.WithError(err).Error("failed accepting the jump channel")
+
+		return
+	}
+
+	go gossh.DiscardRequests(reqs)
+
+	logger.WithField("sshid", sshid).Info("jumping to the device")
+
+	server.HandleConn(session.NewJumpedConn(channel, sshid, conn.LocalAddr(), conn.RemoteAddr()))
+}
@@ -0,0 +1,82 @@
+package channels
+
+import (
+	"fmt"
+	"time"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/session"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+const (
+	// LimitsWarningPeriod is how long before a disconnection due to the namespace's limits the client is warned. When
+	// the limit itself is shorter than twice this period, the client is warned at the half of the limit.
+	LimitsWarningPeriod = time.Minute
+	// LimitsCheckInterval is the interval between each evaluation of the namespace's limits.
+	LimitsCheckInterval = time.Second
+)
+
+// limitsReasons maps a disconnect reason to a message readable by the client.
+var limitsReasons = map[string]string{
+	models.SessionDisconnectReasonIdleTimeout: "inactivity",
+	models.SessionDisconnectReasonMaxDuration: "the maximum session duration",
+}
+
+// enforceLimits watches the session against its namespace's idle timeout and maximum duration, warning the client
+// through the channel before the connection is closed. It returns when the limit is reached or the context is done.
+func enforceLimits(ctx gliderssh.Context, sess *session.Session, client gossh.Channel, conn *gossh.ServerConn, limits session.Limits) {
+	logger := log.WithFields(log.Fields{
+		"uid":          sess.UID,
+		"sshid":        sess.SSHID,
+		"idle_timeout": limits.IdleTimeout,
+		"max_duration": limits.MaxDuration,
+	})
+
+	ticker := time.NewTicker(LimitsCheckInterval)
+	defer ticker.Stop()
+
+	// warned is the deadline the client was already warned about. As client's input moves the idle deadline forward,
+	// a new warning is sent when the deadline changes.
+	var warned time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deadline, limit, reason := limits.Deadline(sess.StartedAt, sess.LastInput())
+		if deadline.IsZero() {
+			return
+		}
+
+		warning := LimitsWarningPeriod
+		if limit < 2*LimitsWarningPeriod {
+			warning = limit / 2
+		}
+
+		now := clock.Now()
+
+		switch {
+		case !now.Before(deadline):
+			logger.WithField("reason", reason).Info("session disconnected due to the namespace's limits")
+
+			fmt.Fprintf(client.Stderr(), "\r\nShellHub: this session was disconnected due to %s.\r\n", limitsReasons[reason]) //nolint:errcheck
+
+			sess.SetDisconnectReason(reason)
+
+			conn.Close() //nolint:errcheck
+
+			return
+		case !now.Before(deadline.Add(-warning)) && !warned.Equal(deadline):
+			warned = deadline
+
+			fmt.Fprintf(client.Stderr(), "\r\nShellHub: this session will be disconnected in %s due to %s.\r\n", deadline.Sub(now).Round(time.Second), limitsReasons[reason]) //nolint:errcheck
+		}
+	}
+}
@@ -51,6 +51,20 @@ const (
 	// In a defined interval, the Agent sends a keepalive request to maintain the session apoint, even when no data is
 	// send.
 	KeepAliveRequestType = KeepAliveRequestTypePrefix + "@shellhub.io"
+	// AgentForwardingRequestType is the request sent by the client to ask for its SSH agent be forwarded to the
+	// session. Each connection to the forwarded agent is opened as a [AgentForwardingChannel] channel.
+	//
+	// https://datatracker.ietf.org/doc/html/draft-miller-ssh-agent#section-5.1
+	AgentForwardingRequestType = "auth-agent-req@openssh.com"
+	// X11ForwardingRequestType is the request sent by the client to ask for X11 connections on the session's display
+	// be forwarded to it. Each X11 connection is opened as a [X11Channel] channel.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-6.3.1
+	X11ForwardingRequestType = "x11-req"
+	// EnvRequestType is the request used to pass an environment variable to the shell or command to be started.
+	//
+	// https://www.rfc-editor.org/rfc/rfc4254#section-6.4
+	EnvRequestType = "env"
 )
 
 type DefaultSessionHandlerOptions struct {
@@ -65,6 +79,12 @@ type DefaultSessionHandlerOptions struct {
 // https://www.rfc-editor.org/rfc/rfc4254#section-6
 func DefaultSessionHandler(opts DefaultSessionHandlerOptions) gliderssh.ChannelHandler {
 	return func(_ *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
+		if _, ok := session.ObtainJump(ctx); ok {
+			newChan.Reject(gossh.Prohibited, "ShellHub is being used as a jump host, connect to a device through it") //nolint:errcheck
+
+			return
+		}
+
 		sess, _ := session.ObtainSession(ctx)
 
 		go func() {
@@ -102,6 +122,9 @@ func DefaultSessionHandler(opts DefaultSessionHandlerOptions) gliderssh.ChannelH
 
 		defer client.Close()
 
+		unwatch := sess.Watch(client)
+		defer unwatch()
+
 		agent, agentReqs, err := sess.AgentClient.OpenChannel(SessionChannel, nil)
 		if err != nil {
 			reject(err, "failed to open the 'session' channel on agent")
@@ -166,6 +189,67 @@ func DefaultSessionHandler(opts DefaultSessionHandlerOptions) gliderssh.ChannelH
 
 				logger.Debugf("request from client to agent: %s", req.Type)
 
+				if req.Type == AgentForwardingRequestType {
+					if allowed, err := sess.AgentForwarding(); err != nil || !allowed {
+						logger.WithError(err).Info("agent forwarding is disabled")
+
+						if req.WantReply {
+							if err := req.Reply(false, nil); err != nil {
+								logger.WithError(err).Error("failed to reply the agent forwarding request")
+							}
+						}
+
+						continue
+					}
+
+					// NOTICE: The "auth-agent@openssh.com" channels can be handled only once per agent's connection,
+					// so the first request starts relaying them for all the session's channels.
+					if chans := sess.AgentClient.HandleChannelOpen(AgentForwardingChannel); chans != nil {
+						go relayChannels(sess, conn, chans)
+					}
+				}
+
+				if req.Type == X11ForwardingRequestType {
+					if allowed, err := sess.X11Forwarding(); err != nil || !allowed {
+						logger.WithError(err).Info("x11 forwarding is disabled")
+
+						if req.WantReply {
+							if err := req.Reply(false, nil); err != nil {
+								logger.WithError(err).Error("failed to reply the x11 forwarding request")
+							}
+						}
+
+						continue
+					}
+
+					// NOTICE: The "x11" channels can be handled only once per agent's connection, so the first request
+					// starts relaying them for all the session's channels.
+					if chans := sess.AgentClient.HandleChannelOpen(X11Channel); chans != nil {
+						go relayChannels(sess, conn, chans)
+					}
+				}
+
+				if req.Type == PtyRequestType && !sess.PtyAllowed() {
+					logger.Info("pty is not permitted by the certificate")
+
+					if req.WantReply {
+						if err := req.Reply(false, nil); err != nil {
+							logger.WithError(err).Error("failed to reply the pty request")
+						}
+					}
+
+					continue
+				}
+
+				if sess.ForceCommand != "" {
+					switch req.Type {
+					case ShellRequestType, ExecRequestType, SubsystemRequestType:
+						logger.WithField("request", req.Type).Info("forcing the certificate's command")
+
+						forceCommand(sess, agent, req)
+					}
+				}
+
 				ok, err := agent.SendRequest(req.Type, req.WantReply, req.Payload)
 				if err != nil {
 					logger.WithError(err).Error("failed to send the request from client to agent")
@@ -204,6 +288,19 @@ func DefaultSessionHandler(opts DefaultSessionHandlerOptions) gliderssh.ChannelH
 						}
 					}
 
+					sess.EnforceLimitsOnce(func() {
+						limits, err := sess.Limits()
+						if err != nil {
+							logger.WithError(err).Warn("failed to get the namespace's session limits")
+
+							return
+						}
+
+						if limits.Enabled() {
+							go enforceLimits(ctx, sess, client, conn, limits)
+						}
+					})
+
 					// The server SHOULD NOT halt the execution of the protocol stack when starting a shell or a
 					// program.  All input and output from these SHOULD be redirected to the channel or to the
 					// encrypted tunnel.
@@ -280,3 +377,30 @@ func DefaultSessionHandler(opts DefaultSessionHandlerOptions) gliderssh.ChannelH
 		}
 	}
 }
+
+// forceCommand replaces a "shell", "exec" or "subsystem" request by an "exec" of the command forced by the client's
+// certificate. The command originally requested, if any, is exposed to the forced one through the
+// SSH_ORIGINAL_COMMAND environment variable, like OpenSSH does.
+func forceCommand(sess *session.Session, agent gossh.Channel, req *gossh.Request) {
+	type command struct {
+		Command string
+	}
+
+	if req.Type == ExecRequestType {
+		original := new(command)
+		if err := gossh.Unmarshal(req.Payload, original); err == nil {
+			env := struct {
+				Name  string
+				Value string
+			}{
+				Name:  "SSH_ORIGINAL_COMMAND",
+				Value: original.Command,
+			}
+
+			agent.SendRequest(EnvRequestType, false, gossh.Marshal(&env)) //nolint:errcheck
+		}
+	}
+
+	req.Type = ExecRequestType
+	req.Payload = gossh.Marshal(&command{Command: sess.ForceCommand})
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
@@ -14,6 +15,12 @@ import (
 // DefaultDirectTCPIPHandler is the channel's handler for direct-tcpip channels like "local port forwarding" and "dynamic
 // application-level port forwarding".
 func DefaultDirectTCPIPHandler(server *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
+	if jump, ok := session.ObtainJump(ctx); ok {
+		jumpDirectTCPIPHandler(server, conn, newChan, jump)
+
+		return
+	}
+
 	defer conn.Close()
 
 	sess, _ := session.ObtainSession(ctx)
@@ -60,6 +67,45 @@ func DefaultDirectTCPIPHandler(server *gliderssh.Server, conn *gossh.ServerConn,
 		return
 	}
 
+	allowed, err := sess.LocalPortForwarding(data.DestAddr, data.DestPort)
+	if err != nil {
+		newChan.Reject(gossh.ConnectionFailed, "failed to check if port forwarding is allowed") //nolint:errcheck
+		log.WithError(err).WithFields(log.Fields{
+			"username":    sess.Target.Username,
+			"sshid":       sess.Target.Data,
+			"origin_port": data.OriginAddr,
+			"origin_addr": data.OriginPort,
+			"dest_port":   data.DestPort,
+			"dest_addr":   data.DestAddr,
+		}).Error("failed to check if port forwarding is allowed")
+
+		return
+	}
+
+	if !allowed {
+		newChan.Reject(gossh.Prohibited, "port forwarding to this destination is not allowed") //nolint:errcheck
+		log.WithFields(log.Fields{
+			"username":    sess.Target.Username,
+			"sshid":       sess.Target.Data,
+			"origin_port": data.OriginAddr,
+			"origin_addr": data.OriginPort,
+			"dest_port":   data.DestPort,
+			"dest_addr":   data.DestAddr,
+		}).Info("port forwarding denied by the namespace's policy")
+
+		if err := sess.Event(models.SessionEventTypePortForwardingDenied, map[string]string{
+			"host": data.DestAddr,
+			"port": strconv.FormatUint(uint64(data.DestPort), 10),
+		}); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"username": sess.Target.Username,
+				"sshid":    sess.Target.Data,
+			}).Warn("failed to register the denied port forwarding on the session")
+		}
+
+		return
+	}
+
 	dest := net.JoinHostPort(data.DestAddr, strconv.FormatInt(int64(data.DestPort), 10))
 
 	// NOTE: Certain SSH connections may not necessitate a dedicated handler, such as an SSH handler.
@@ -32,7 +32,8 @@ func pipe(ctx gliderssh.Context, sess *session.Session, client gossh.Channel, ag
 	wg := new(sync.WaitGroup)
 	wg.Add(2)
 
-	c := io.MultiReader(client, client.Stderr())
+	// NOTICE: Every data received from the client is considered an input to restart the session's idle period.
+	c := &inputReader{Reader: io.MultiReader(client, client.Stderr()), sess: sess}
 	a := io.MultiReader(agent, agent.Stderr())
 
 	go func() {
@@ -101,3 +102,75 @@ func pipe(ctx gliderssh.Context, sess *session.Session, client gossh.Channel, ag
 
 	wg.Wait()
 }
+
+// inputReader is an [io.Reader] that registers on the session each data read from the client.
+type inputReader struct {
+	io.Reader
+	sess *session.Session
+}
+
+func (r *inputReader) Read(p []byte) (int, error) {
+	read, err := r.Reader.Read(p)
+	if read > 0 {
+		r.sess.Touch()
+	}
+
+	return read, err
+}
+
+// relayChannels relays the channels opened by the agent, like "forwarded-tcpip" and "auth-agent@openssh.com", to the
+// client, opening a channel of the same type on the client's connection for each one.
+func relayChannels(sess *session.Session, conn *gossh.ServerConn, chans <-chan gossh.NewChannel) {
+	for newChan := range chans {
+		go func(newChan gossh.NewChannel) {
+			logger := log.WithFields(log.Fields{
+				"username": sess.Target.Username,
+				"sshid":    sess.Target.Data,
+				"channel":  newChan.ChannelType(),
+			})
+
+			client, clientReqs, err := conn.OpenChannel(newChan.ChannelType(), newChan.ExtraData())
+			if err != nil {
+				newChan.Reject(gossh.ConnectionFailed, "failed to open the channel on client: "+err.Error()) //nolint:errcheck
+				logger.WithError(err).Error("failed to open the channel on client")
+
+				return
+			}
+
+			agent, agentReqs, err := newChan.Accept()
+			if err != nil {
+				client.Close()
+				logger.WithError(err).Error("failed accepting the channel from agent")
+
+				return
+			}
+
+			go gossh.DiscardRequests(clientReqs)
+			go gossh.DiscardRequests(agentReqs)
+
+			logger.Info("piping data between agent and client")
+
+			wg := new(sync.WaitGroup)
+			wg.Add(2)
+
+			go func() {
+				defer wg.Done()
+
+				io.Copy(client, agent) //nolint:errcheck
+				client.CloseWrite()    //nolint:errcheck
+			}()
+
+			go func() {
+				defer wg.Done()
+
+				io.Copy(agent, client) //nolint:errcheck
+				agent.CloseWrite()     //nolint:errcheck
+			}()
+
+			wg.Wait()
+
+			client.Close()
+			agent.Close()
+		}(newChan)
+	}
+}
@@ -1,19 +1,26 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/pires/go-proxyproto"
 	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/hostkeys"
 	"github.com/shellhub-io/shellhub/ssh/pkg/target"
 	"github.com/shellhub-io/shellhub/ssh/server/auth"
 	"github.com/shellhub-io/shellhub/ssh/server/channels"
 	"github.com/shellhub-io/shellhub/ssh/session"
 	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
 )
 
 type Options struct {
@@ -25,12 +32,57 @@ type Options struct {
 	// Agents 0.5.x or earlier do not validate the public key request and may panic.
 	// Please refer to: https://github.com/shellhub-io/shellhub/issues/3453
 	AllowPublickeyAccessBelow060 bool `env:"ALLOW_PUBLIC_KEY_ACCESS_BELLOW_0_6_0,default=false"`
+	// ListenAddresses are the addresses, separated by commas, where the SSH server listens, like ":2222" or
+	// "[::1]:2222".
+	ListenAddresses []string `env:"LISTEN_ADDRESSES,default=:2222"`
+	// ShutdownTimeout is how long the server waits for the active sessions to finish when it's shutting down, before
+	// disconnecting them.
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT,default=5m"`
+	// HostKeys is the directory where the SSH host keys are loaded from. When empty, the single host key from the
+	// PRIVATE_KEY environment variable is used.
+	HostKeys string `env:"HOST_KEYS"`
+	// InstanceAddress is the address where the other instances of the service reach this one's HTTP server, to
+	// forward the connections of the devices connected to it. When empty, the host name on port 8080 is used.
+	InstanceAddress string `env:"INSTANCE_ADDRESS"`
+	// WebKeys are the keys, encoded as base64 and separated by commas, used to encrypt the web terminal's credentials
+	// while they are stored. The first one encrypts and all of them decrypt, so a key is rotated adding a new one
+	// before it. When empty, a random key is used, and the web terminal only works with a single instance.
+	WebKeys string `env:"WEB_KEYS"`
+	// WebGracePeriod is how long a web terminal is kept after its WebSocket is lost, waiting for the browser to resume
+	// it.
+	WebGracePeriod time.Duration `env:"WEB_GRACE_PERIOD,default=2m"`
+}
+
+// LocalAddress returns the address where the services of this instance, like the web terminal, connect to the SSH
+// server, what is the first of the [Options.ListenAddresses] with an unspecified host replaced by the loopback.
+func (o *Options) LocalAddress() string {
+	if len(o.ListenAddresses) == 0 {
+		return "localhost:2222"
+	}
+
+	host, port, err := net.SplitHostPort(o.ListenAddresses[0])
+	if err != nil {
+		return o.ListenAddresses[0]
+	}
+
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+
+	return net.JoinHostPort(host, port)
 }
 
 type Server struct {
-	sshd   *gliderssh.Server
-	opts   *Options
-	tunnel *httptunnel.Tunnel
+	sshd     *gliderssh.Server
+	opts     *Options
+	tunnel   *httptunnel.Tunnel
+	hostkeys *hostkeys.Keyring
+
+	// mu guards served.
+	mu sync.Mutex
+	// served are the types of the host keys added to the SSH server. They are tracked here, as the server's host
+	// signers are guarded by its own unexported lock.
+	served map[string]bool
 }
 
 func NewServer(opts *Options, tunnel *httptunnel.Tunnel) *Server {
@@ -40,96 +92,338 @@ func NewServer(opts *Options, tunnel *httptunnel.Tunnel) *Server {
 	}
 
 	server.sshd = &gliderssh.Server{ // nolint: exhaustruct
-		Addr: ":2222",
 		ConnCallback: func(ctx gliderssh.Context, conn net.Conn) net.Conn {
 			ctx.SetValue("conn", conn)
 
 			return conn
 		},
 		BannerHandler: func(ctx gliderssh.Context) string {
+			sshid := session.SSHID(ctx)
+
 			logger := log.WithFields(
 				log.Fields{
 					"uid":   ctx.SessionID(),
-					"sshid": ctx.User(),
+					"sshid": sshid,
 				})
 
 			logger.Info("new connection established")
 
-			target, err := target.NewTarget(ctx.User())
-			if err != nil {
-				logger.WithError(err).Error("invalid SSHID")
-
-				return fmt.Sprintf("%s is not a valid SSHID\n", ctx.User())
+			// NOTICE: Connections using ShellHub as a jump host don't target a device until they open a "direct-tcpip"
+			// channel, when the device is evaluated by the jumped connection.
+			if session.IsJump(ctx) {
+				return ""
 			}
 
-			sess, err := session.NewSession(ctx, tunnel)
+			target, err := target.NewTarget(sshid)
 			if err != nil {
-				logger.WithError(err).Error("failed to create the session")
+				logger.WithError(err).Error("invalid SSHID")
 
-				return fmt.Sprintf("%s is offline or cannot be reached\n", target.Data)
+				return fmt.Sprintf("%s is not a valid SSHID\n", sshid)
 			}
 
-			if err := sess.Dial(ctx); err != nil {
-				logger.WithError(err).Error("destination device is offline or connot be reached")
-
-				return fmt.Sprintf("%s is offline or cannot be reached\n", target.Data)
+			// NOTICE: A device without its namespace is resolved on the user's default namespace, so its session is
+			// only created when the client authenticates with a certificate that identifies the user.
+			if target.IsAlias() {
+				return ""
 			}
 
-			if err := sess.Evaluate(ctx); err != nil {
-				logger.WithError(err).Error("destination device has a firewall to blocked it or a billing issue")
-
-				return fmt.Sprintf("you cannot access %s due a policy rule\n", target.Data)
+			if message, err := server.openSession(ctx, ""); err != nil {
+				return message
 			}
 
 			return ""
 		},
-		PasswordHandler:  auth.PasswordHandler,
-		PublicKeyHandler: auth.PublicKeyHandler,
+		PasswordHandler: auth.PasswordHandler,
+		// NOTICE: The public key authentication is set on the server's configuration, instead of through a
+		// [gliderssh.PublicKeyHandler], as it can partially succeed when the device's namespace requires MFA.
+		ServerConfigCallback: func(ctx gliderssh.Context) *gossh.ServerConfig {
+			return &gossh.ServerConfig{ // nolint: exhaustruct
+				PublicKeyCallback: auth.PublicKeyHandler(ctx, server.openSession),
+			}
+		},
 		// Channels form the foundation of secure communication between clients and servers in SSH connections. A
 		// channel, in the context of SSH, is a logical conduit through which data travels securely between the client
 		// and the server. SSH channels serve as the infrastructure for executing commands, establishing shell sessions,
 		// and securely forwarding network services.
 		ChannelHandlers: map[string]gliderssh.ChannelHandler{
-			channels.SessionChannel: channels.DefaultSessionHandler(
+			channels.SessionChannel: server.announceHostKeys(channels.DefaultSessionHandler(
 				channels.DefaultSessionHandlerOptions{
 					RecordURL: opts.RecordURL,
 				},
-			),
-			channels.DirectTCPIPChannel: channels.DefaultDirectTCPIPHandler,
+			)),
+			channels.DirectTCPIPChannel: server.announceHostKeys(channels.DefaultDirectTCPIPHandler),
 		},
 		LocalPortForwardingCallback: func(ctx gliderssh.Context, dhost string, dport uint32) bool {
 			return true
 		},
 		ReversePortForwardingCallback: func(ctx gliderssh.Context, bindHost string, bindPort uint32) bool {
-			return false
+			sess, _ := session.ObtainSession(ctx)
+			if sess == nil {
+				return false
+			}
+
+			allowed, err := sess.ReversePortForwarding(bindHost, bindPort)
+			if err != nil {
+				log.WithError(err).
+					WithFields(log.Fields{"uid": sess.UID, "sshid": sess.SSHID}).
+					Error("failed to check if remote port forwarding is allowed")
+
+				return false
+			}
+
+			return allowed
+		},
+		// Global requests are requests that affect the connection as a whole, not a specific channel, like the ones
+		// used by "remote port forwarding".
+		RequestHandlers: map[string]gliderssh.RequestHandler{
+			channels.TCPIPForwardRequest:       channels.DefaultTCPIPForwardHandler,
+			channels.CancelTCPIPForwardRequest: channels.DefaultTCPIPForwardHandler,
+			hostkeys.ProveRequest:              server.proveHostKeys,
 		},
 	}
 
-	if _, err := os.Stat(os.Getenv("PRIVATE_KEY")); os.IsNotExist(err) {
-		log.WithError(err).Fatal("private key not found!")
+	path := opts.HostKeys
+	if path == "" {
+		path = os.Getenv("PRIVATE_KEY")
 	}
 
-	if err := server.sshd.SetOption(gliderssh.HostKeyFile(os.Getenv("PRIVATE_KEY"))); err != nil {
-		log.WithError(err).Fatal("host key not found!")
+	keyring, err := hostkeys.NewKeyring(path)
+	if err != nil {
+		log.WithError(err).WithField("path", path).Fatal("host key not found!")
 	}
 
+	server.hostkeys = keyring
+	server.addHostKeys(keyring.Active())
+
 	return server
 }
 
-func (s *Server) ListenAndServe() error {
-	log.WithFields(log.Fields{
-		"addr": s.sshd.Addr,
-	}).Info("ssh server listening")
+// openSession creates the connection's session, dialing and evaluating its device, on behalf of the ShellHub's user
+// identity, if known. When it fails, it returns the message shown to the client with the error.
+func (s *Server) openSession(ctx gliderssh.Context, identity string) (string, error) {
+	sshid := session.SSHID(ctx)
+
+	logger := log.WithFields(log.Fields{"uid": ctx.SessionID(), "sshid": sshid})
+
+	target, err := target.NewTarget(sshid)
+	if err != nil {
+		logger.WithError(err).Error("invalid SSHID")
+
+		return fmt.Sprintf("%s is not a valid SSHID\n", sshid), err
+	}
 
-	list, err := net.Listen("tcp", s.sshd.Addr)
+	sess, err := session.NewSession(ctx, s.tunnel, identity)
 	if err != nil {
-		log.WithError(err).Error("failed to listen an serve the TCP server")
+		logger.WithError(err).Error("failed to create the session")
 
+		return fmt.Sprintf("%s is offline or cannot be reached\n", target.Data), err
+	}
+
+	if err := sess.Dial(ctx); err != nil {
+		logger.WithError(err).Error("destination device is offline or connot be reached")
+
+		return fmt.Sprintf("%s is offline or cannot be reached\n", target.Data), err
+	}
+
+	if err := sess.Evaluate(ctx); err != nil {
+		logger.WithError(err).Error("destination device has a firewall to blocked it or a billing issue")
+
+		return fmt.Sprintf("you cannot access %s due a policy rule\n", target.Data), err
+	}
+
+	return "", nil
+}
+
+// ReloadHostKeys loads the host keys again, publishing the new ones to the next connections and activating the keys of
+// the types whose previous active key was removed.
+func (s *Server) ReloadHostKeys() error {
+	if err := s.hostkeys.Load(); err != nil {
 		return err
 	}
 
-	proxy := &proxyproto.Listener{Listener: list} // nolint: exhaustruct
-	defer proxy.Close()
+	active := s.hostkeys.Active()
+	s.addHostKeys(active)
+
+	types := make(map[string]bool, len(active))
+	for _, signer := range active {
+		types[signer.PublicKey().Type()] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// NOTICE: The SSH server only replaces its host keys by type, so a type without any key left keeps being served
+	// until the server is restarted.
+	for kind := range s.served {
+		if !types[kind] {
+			log.WithField("type", kind).Warn("host key type removed from the keyring is kept until the server restarts")
+		}
+	}
+
+	return nil
+}
+
+// addHostKeys adds the host keys to the SSH server, replacing the ones of the same types, and records their types as
+// served.
+func (s *Server) addHostKeys(signers []gossh.Signer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.served == nil {
+		s.served = make(map[string]bool)
+	}
+
+	for _, signer := range signers {
+		s.sshd.AddHostKey(signer)
+		s.served[signer.PublicKey().Type()] = true
+	}
+}
+
+// announceHostKeys publishes the host keys to the client on its connection's first channel, as the update of host
+// keys can only be sent after the authentication.
+func (s *Server) announceHostKeys(handler gliderssh.ChannelHandler) gliderssh.ChannelHandler {
+	return func(srv *gliderssh.Server, conn *gossh.ServerConn, newChan gossh.NewChannel, ctx gliderssh.Context) {
+		ctx.Lock()
+		announced, _ := ctx.Value("hostkeys").(bool)
+		ctx.SetValue("hostkeys", true)
+		ctx.Unlock()
+
+		if !announced {
+			if err := s.hostkeys.Announce(conn); err != nil {
+				log.WithError(err).WithField("uid", ctx.SessionID()).Warn("failed to announce the host keys")
+			}
+		}
+
+		handler(srv, conn, newChan, ctx)
+	}
+}
+
+// proveHostKeys handles the client's request to prove the ownership of the announced host keys.
+func (s *Server) proveHostKeys(ctx gliderssh.Context, _ *gliderssh.Server, req *gossh.Request) (bool, []byte) {
+	conn, ok := ctx.Value(gliderssh.ContextKeyConn).(gossh.Conn)
+	if !ok {
+		return false, nil
+	}
+
+	proof, err := s.hostkeys.Prove(conn.SessionID(), req.Payload)
+	if err != nil {
+		log.WithError(err).WithField("uid", ctx.SessionID()).Warn("failed to prove the host keys")
+
+		return false, nil
+	}
+
+	return true, proof
+}
+
+// ListenAndServe listens on every configured address and serves the SSH connections until a listener fails or the
+// server receives a SIGTERM, when it's gracefully shut down through [Server.Shutdown].
+func (s *Server) ListenAndServe() error {
+	listeners := make([]net.Listener, 0, len(s.opts.ListenAddresses))
+	for _, addr := range s.opts.ListenAddresses {
+		list, err := net.Listen("tcp", addr)
+		if err != nil {
+			log.WithError(err).WithField("addr", addr).Error("failed to listen an serve the TCP server")
+
+			for _, l := range listeners {
+				l.Close() //nolint:errcheck
+			}
+
+			return err
+		}
+
+		log.WithFields(log.Fields{
+			"addr": list.Addr().String(),
+		}).Info("ssh server listening")
+
+		listeners = append(listeners, &proxyproto.Listener{Listener: list}) // nolint: exhaustruct
+	}
+
+	// NOTICE: The host keys are reloaded on SIGHUP, so new keys can be published, and old ones retired, without
+	// restarting the server.
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	defer func() {
+		signal.Stop(reload)
+		close(reload)
+	}()
+
+	go func() {
+		for range reload {
+			if err := s.ReloadHostKeys(); err != nil {
+				log.WithError(err).Error("failed to reload the host keys")
+
+				continue
+			}
+
+			log.Info("host keys reloaded")
+		}
+	}()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGTERM, os.Interrupt)
+	defer signal.Stop(stop)
+
+	errs := make(chan error, len(listeners))
+	for _, list := range listeners {
+		go func(list net.Listener) {
+			errs <- s.sshd.Serve(list)
+		}(list)
+	}
+
+	select {
+	case err := <-errs:
+		s.sshd.Close() //nolint:errcheck
+
+		return err
+	case sig := <-stop:
+		log.WithField("signal", sig.String()).Info("shutting down the ssh server")
+
+		ctx, cancel := context.WithTimeout(context.Background(), s.opts.ShutdownTimeout)
+		defer cancel()
+
+		return s.Shutdown(ctx)
+	}
+}
+
+// Shutdown gracefully shuts down the server. It stops accepting new connections, notifies the clients of the active
+// sessions and waits for them to finish until the context is done, when the remaining sessions are disconnected and
+// finished on the API.
+func (s *Server) Shutdown(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- s.sshd.Shutdown(ctx)
+	}()
+
+	notice := "this server is shutting down, please finish your work and reconnect."
+	if deadline, ok := ctx.Deadline(); ok {
+		notice = fmt.Sprintf("this server is shutting down, this session will be disconnected in %s. Please finish your work and reconnect.", time.Until(deadline).Round(time.Second))
+	}
+
+	for _, sess := range session.Sessions() {
+		sess.Notify(notice)
+	}
+
+	if err := <-done; err == nil {
+		log.Info("ssh server drained")
+
+		return nil
+	}
+
+	remaining := session.Sessions()
+	log.WithField("sessions", len(remaining)).Warn("disconnecting the sessions still active after the shutdown timeout")
+
+	for _, sess := range remaining {
+		sess.SetDisconnectReason(models.SessionDisconnectReasonShutdown)
+		sess.Notify("this session was disconnected because the server is shutting down.")
+
+		if err := sess.Disconnect(); err != nil {
+			log.WithError(err).WithField("uid", sess.UID).Warn("failed to disconnect the session's client")
+		}
+
+		// NOTICE: The session is finished here, instead of waiting for its connection's handler, as the process can
+		// exit before the handler runs.
+		sess.Finish() //nolint:errcheck
+	}
 
-	return s.sshd.Serve(proxy)
+	return s.sshd.Close()
 }
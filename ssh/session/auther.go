@@ -94,24 +94,46 @@ func (p *publicKeyAuth) Evaluate(session *Session) error {
 		}
 	}
 
+	// NOTICE: OpenSSH user certificates are trusted through the namespace's certificate authority, instead of the
+	// public keys registered on it.
+	if cert, ok := p.pk.(*gossh.Certificate); ok {
+		if err := session.evaluateCertificate(cert); err != nil {
+			return err
+		}
+
+		session.Identity = cert.KeyId
+
+		return nil
+	}
+
+	if p.magic() {
+		return nil
+	}
+
 	fingerprint := gossh.FingerprintLegacyMD5(p.pk)
 
-	magic, err := gossh.NewPublicKey(&magickey.GetRerefence().PublicKey)
+	key, err := session.api.GetPublicKey(fingerprint, session.Device.TenantID)
 	if err != nil {
 		return err
 	}
 
-	if gossh.FingerprintLegacyMD5(magic) != fingerprint {
-		if _, err = session.api.GetPublicKey(fingerprint, session.Device.TenantID); err != nil {
-			return err
-		}
+	if ok, err := session.api.EvaluateKey(fingerprint, session.Device, session.Data.Target.Username); !ok || err != nil {
+		return ErrEvaluatePublicKey
+	}
 
-		if ok, err := session.api.EvaluateKey(fingerprint, session.Device, session.Data.Target.Username); !ok || err != nil {
-			return ErrEvaluatePublicKey
-		}
+	session.Identity = key.CreatedBy
+
+	return nil
+}
+
+// magic checks if the public key is the ShellHub's magic key, used by its own services to connect to the devices.
+func (p *publicKeyAuth) magic() bool {
+	magic, err := gossh.NewPublicKey(&magickey.GetRerefence().PublicKey)
+	if err != nil {
+		return false
 	}
 
-	return err
+	return gossh.FingerprintLegacyMD5(magic) == gossh.FingerprintLegacyMD5(p.pk)
 }
 
 type passwordAuth struct {
@@ -0,0 +1,179 @@
+package session
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// Critical options of OpenSSH user certificates supported by ShellHub. A certificate with any other critical option is
+// refused.
+//
+// https://cvsweb.openbsd.org/src/usr.bin/ssh/PROTOCOL.certkeys
+const (
+	// CertificateOptionSourceAddress is a comma-separated list of addresses, in CIDR format, from where the certificate
+	// can be used.
+	CertificateOptionSourceAddress = "source-address"
+	// CertificateOptionForceCommand is the command executed instead of any shell, command or subsystem requested.
+	CertificateOptionForceCommand = "force-command"
+)
+
+// Extensions of OpenSSH user certificates that permit a feature of the session. A session authenticated by a
+// certificate without the extension cannot use its feature.
+//
+// https://cvsweb.openbsd.org/src/usr.bin/ssh/PROTOCOL.certkeys
+const (
+	CertificateExtensionPermitX11Forwarding   = "permit-X11-forwarding"
+	CertificateExtensionPermitAgentForwarding = "permit-agent-forwarding"
+	CertificateExtensionPermitPortForwarding  = "permit-port-forwarding"
+	CertificateExtensionPermitPty             = "permit-pty"
+)
+
+// evaluateCertificate checks if an OpenSSH user certificate is signed by the namespace's user certificate authority
+// and grants access to the target's username.
+func (s *Session) evaluateCertificate(cert *gossh.Certificate) error {
+	namespace, errs := s.api.NamespaceLookup(s.Device.TenantID)
+	if len(errs) > 0 || namespace == nil {
+		return ErrFindNamespace
+	}
+
+	ca, authorities, err := certificateAuthorities(namespace)
+	if err != nil {
+		return err
+	}
+
+	principal, ok := ca.Principal(cert.ValidPrincipals, s.Target.Username)
+	if !ok {
+		return ErrCertificatePrincipal
+	}
+
+	if !signedBy(cert, authorities) {
+		return ErrCertificateInvalid
+	}
+
+	// NOTICE: [gossh.CertChecker.CheckCert] checks the certificate's type, principal, validity window, critical
+	// options, revocation and signature, but neither the signer's authority nor the "source-address" option.
+	checker := &gossh.CertChecker{
+		IsRevoked: func(cert *gossh.Certificate) bool {
+			return ca.Revoked(cert.Serial)
+		},
+		SupportedCriticalOptions: []string{CertificateOptionSourceAddress, CertificateOptionForceCommand},
+		Clock:                    clock.Now,
+	}
+
+	if err := checker.CheckCert(principal, cert); err != nil {
+		return errors.Join(ErrCertificateInvalid, err)
+	}
+
+	if addresses, ok := cert.CriticalOptions[CertificateOptionSourceAddress]; ok {
+		if !allowedSourceAddress(addresses, s.IPAddress) {
+			return ErrCertificateSource
+		}
+	}
+
+	s.Certificate = cert
+	s.ForceCommand = cert.CriticalOptions[CertificateOptionForceCommand]
+
+	return nil
+}
+
+// certificatePermits checks if the client's certificate, when it authenticated with one, permits the extension's
+// feature. A certificate forcing a command permits no forwarding, as the forwarded channels would not run it.
+func (s *Session) certificatePermits(extension string) bool {
+	if s.Certificate == nil {
+		return true
+	}
+
+	if s.ForceCommand != "" && extension != CertificateExtensionPermitPty {
+		return false
+	}
+
+	_, ok := s.Certificate.Extensions[extension]
+
+	return ok
+}
+
+// PtyAllowed checks if the client's certificate, if any, permits the session to allocate a pseudo-terminal.
+func (s *Session) PtyAllowed() bool {
+	return s.certificatePermits(CertificateExtensionPermitPty)
+}
+
+// certificateAuthorities returns the namespace's user certificate authority settings and the public keys of the
+// certificate authorities it trusts.
+func certificateAuthorities(namespace *models.Namespace) (*models.UserCA, []gossh.PublicKey, error) {
+	ca := new(models.UserCA)
+	if namespace.Settings != nil && namespace.Settings.UserCA != nil {
+		ca = namespace.Settings.UserCA
+	}
+
+	// NOTICE: The namespace trusts both the certificate authority registered on its settings and the one managed by
+	// ShellHub to issue certificates to its members.
+	authorities := make([]gossh.PublicKey, 0, 2)
+	for _, key := range []string{ca.PublicKey, namespaceCAPublicKey(namespace)} {
+		if key == "" {
+			continue
+		}
+
+		authority, _, _, _, err := gossh.ParseAuthorizedKey([]byte(key)) //nolint:dogsled
+		if err != nil {
+			return nil, nil, errors.Join(ErrCertificateAuthority, err)
+		}
+
+		authorities = append(authorities, authority)
+	}
+
+	if len(authorities) == 0 {
+		return nil, nil, ErrCertificateAuthority
+	}
+
+	return ca, authorities, nil
+}
+
+// signedBy checks if the certificate was signed by any of the authorities.
+func signedBy(cert *gossh.Certificate, authorities []gossh.PublicKey) bool {
+	return slices.ContainsFunc(authorities, func(authority gossh.PublicKey) bool {
+		return bytes.Equal(cert.SignatureKey.Marshal(), authority.Marshal())
+	})
+}
+
+// namespaceCAPublicKey returns the public key of the certificate authority managed by ShellHub for the namespace, if
+// any.
+func namespaceCAPublicKey(namespace *models.Namespace) string {
+	if namespace.CA == nil {
+		return ""
+	}
+
+	return namespace.CA.PublicKey
+}
+
+// allowedSourceAddress checks if the address is inside any of the comma-separated list of addresses or CIDRs.
+func allowedSourceAddress(addresses string, address string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+
+	for _, allowed := range strings.Split(addresses, ",") {
+		allowed = strings.TrimSpace(allowed)
+
+		if _, network, err := net.ParseCIDR(allowed); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+
+			continue
+		}
+
+		if other := net.ParseIP(allowed); other != nil && other.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
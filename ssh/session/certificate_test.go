@@ -0,0 +1,277 @@
+package session
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/target"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestEvaluateCertificate(t *testing.T) {
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ca, err := gossh.NewSignerFromKey(caKey)
+	require.NoError(t, err)
+
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	other, err := gossh.NewSignerFromKey(otherKey)
+	require.NoError(t, err)
+
+	userKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	user, err := gossh.NewPublicKey(userKey)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	certificate := func(signer gossh.Signer, change func(cert *gossh.Certificate)) *gossh.Certificate {
+		cert := &gossh.Certificate{
+			Key:             user,
+			Serial:          1,
+			CertType:        gossh.UserCert,
+			ValidPrincipals: []string{"root"},
+			ValidAfter:      uint64(now.Add(-time.Hour).Unix()),
+			ValidBefore:     uint64(now.Add(time.Hour).Unix()),
+		}
+
+		if change != nil {
+			change(cert)
+		}
+
+		require.NoError(t, cert.SignCert(rand.Reader, signer))
+
+		return cert
+	}
+
+	userCA := &models.UserCA{
+		PublicKey:      string(gossh.MarshalAuthorizedKey(ca.PublicKey())),
+		Principals:     map[string][]string{"admins": {"root", "admin"}},
+		RevokedSerials: []uint64{42},
+	}
+
+	type Expected struct {
+		forceCommand string
+		err          error
+	}
+
+	cases := []struct {
+		description   string
+		cert          *gossh.Certificate
+		requiredMocks func(api *mocks.Client)
+		expected      Expected
+	}{
+		{
+			description: "fails when namespace cannot be retrieved",
+			cert:        certificate(ca, nil),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(nil, []error{errors.New("error")}).Once()
+			},
+			expected: Expected{err: ErrFindNamespace},
+		},
+		{
+			description: "fails when namespace has no certificate authority",
+			cert:        certificate(ca, nil),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{}}, nil).Once()
+			},
+			expected: Expected{err: ErrCertificateAuthority},
+		},
+		{
+			description: "fails when no principal grants access to the username",
+			cert: certificate(ca, func(cert *gossh.Certificate) {
+				cert.ValidPrincipals = []string{"operator"}
+			}),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{UserCA: userCA}}, nil).Once()
+			},
+			expected: Expected{err: ErrCertificatePrincipal},
+		},
+		{
+			description: "fails when certificate is signed by another authority",
+			cert:        certificate(other, nil),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{UserCA: userCA}}, nil).Once()
+			},
+			expected: Expected{err: ErrCertificateInvalid},
+		},
+		{
+			description: "fails when certificate is expired",
+			cert: certificate(ca, func(cert *gossh.Certificate) {
+				cert.ValidAfter = uint64(now.Add(-2 * time.Hour).Unix())
+				cert.ValidBefore = uint64(now.Add(-time.Hour).Unix())
+			}),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{UserCA: userCA}}, nil).Once()
+			},
+			expected: Expected{err: ErrCertificateInvalid},
+		},
+		{
+			description: "fails when certificate is revoked",
+			cert: certificate(ca, func(cert *gossh.Certificate) {
+				cert.Serial = 42
+			}),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{UserCA: userCA}}, nil).Once()
+			},
+			expected: Expected{err: ErrCertificateInvalid},
+		},
+		{
+			description: "fails when certificate has an unsupported critical option",
+			cert: certificate(ca, func(cert *gossh.Certificate) {
+				cert.CriticalOptions = map[string]string{"verify-required": ""}
+			}),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{UserCA: userCA}}, nil).Once()
+			},
+			expected: Expected{err: ErrCertificateInvalid},
+		},
+		{
+			description: "fails when client's address is not allowed",
+			cert: certificate(ca, func(cert *gossh.Certificate) {
+				cert.CriticalOptions = map[string]string{CertificateOptionSourceAddress: "10.0.0.0/8,192.168.0.2"}
+			}),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{UserCA: userCA}}, nil).Once()
+			},
+			expected: Expected{err: ErrCertificateSource},
+		},
+		{
+			description: "succeeds when principal is mapped to the username",
+			cert: certificate(ca, func(cert *gossh.Certificate) {
+				cert.ValidPrincipals = []string{"admins"}
+				cert.CriticalOptions = map[string]string{CertificateOptionSourceAddress: "10.0.0.0/8,192.168.0.1"}
+			}),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{UserCA: userCA}}, nil).Once()
+			},
+			expected: Expected{err: nil},
+		},
+		{
+			description: "succeeds when certificate is signed by the namespace's managed authority",
+			cert:        certificate(other, nil),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{CA: &models.NamespaceCA{PublicKey: string(gossh.MarshalAuthorizedKey(other.PublicKey()))}}, nil).Once()
+			},
+			expected: Expected{err: nil},
+		},
+		{
+			description: "succeeds forcing the certificate's command",
+			cert: certificate(ca, func(cert *gossh.Certificate) {
+				cert.CriticalOptions = map[string]string{CertificateOptionForceCommand: "uptime"}
+			}),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{UserCA: userCA}}, nil).Once()
+			},
+			expected: Expected{forceCommand: "uptime", err: nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			sess := &Session{
+				api: api,
+				Data: Data{
+					Target:    &target.Target{Username: "root"},
+					Device:    &models.Device{TenantID: "00000000-0000-4000-0000-000000000000"},
+					IPAddress: "192.168.0.1",
+				},
+			}
+
+			err := sess.evaluateCertificate(tc.cert)
+			if tc.expected.err != nil {
+				assert.ErrorIs(t, err, tc.expected.err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Equal(t, tc.expected.forceCommand, sess.ForceCommand)
+
+			api.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCertificatePermits(t *testing.T) {
+	permits := map[string]string{
+		CertificateExtensionPermitPty:            "",
+		CertificateExtensionPermitPortForwarding: "",
+	}
+
+	cases := []struct {
+		description string
+		data        Data
+		extension   string
+		expected    bool
+	}{
+		{
+			description: "permits when the client has no certificate",
+			data:        Data{},
+			extension:   CertificateExtensionPermitAgentForwarding,
+			expected:    true,
+		},
+		{
+			description: "refuses when the certificate has no extension",
+			data:        Data{Certificate: &gossh.Certificate{Permissions: gossh.Permissions{Extensions: permits}}},
+			extension:   CertificateExtensionPermitAgentForwarding,
+			expected:    false,
+		},
+		{
+			description: "permits when the certificate has the extension",
+			data:        Data{Certificate: &gossh.Certificate{Permissions: gossh.Permissions{Extensions: permits}}},
+			extension:   CertificateExtensionPermitPortForwarding,
+			expected:    true,
+		},
+		{
+			description: "refuses forwarding when the certificate forces a command",
+			data: Data{
+				Certificate:  &gossh.Certificate{Permissions: gossh.Permissions{Extensions: permits}},
+				ForceCommand: "uptime",
+			},
+			extension: CertificateExtensionPermitPortForwarding,
+			expected:  false,
+		},
+		{
+			description: "permits the pty when the certificate forces a command",
+			data: Data{
+				Certificate:  &gossh.Certificate{Permissions: gossh.Permissions{Extensions: permits}},
+				ForceCommand: "uptime",
+			},
+			extension: CertificateExtensionPermitPty,
+			expected:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			sess := &Session{Data: tc.data}
+
+			assert.Equal(t, tc.expected, sess.certificatePermits(tc.extension))
+		})
+	}
+}
@@ -15,4 +15,16 @@ var (
 	ErrUnsuportedPublicKeyAuth = fmt.Errorf("connections using public keys are not permitted when the agent version is 0.5.x or earlier")
 	ErrUnexpectedAuthMethod    = fmt.Errorf("failed to authenticate the session due to a unexpected method")
 	ErrEvaluatePublicKey       = fmt.Errorf("failed to evaluate the provided public key")
+	ErrFindNamespace           = fmt.Errorf("failed to find the namespace")
+	ErrCertificateAuthority    = fmt.Errorf("the namespace doesn't trust a valid certificate authority")
+	ErrCertificatePrincipal    = fmt.Errorf("the certificate's principals don't grant access to the username")
+	ErrCertificateInvalid      = fmt.Errorf("failed to validate the certificate")
+	ErrCertificateSource       = fmt.Errorf("the certificate cannot be used from this address")
+	ErrHostKeyMismatch         = fmt.Errorf("the device's host key doesn't match the registered one")
+	ErrJumpIdentity            = fmt.Errorf("connections using ShellHub as a jump host must authenticate with a ShellHub certificate")
+	ErrJumpTarget              = fmt.Errorf("the destination must be a device's SSH port, in the device.namespace format")
+	ErrAliasIdentity           = fmt.Errorf("connections to a device without its namespace must authenticate with a ShellHub certificate")
+	ErrMFARequired             = fmt.Errorf("the namespace requires a verification code to connect to this device")
+	ErrMFAIdentity             = fmt.Errorf("the namespace requires MFA, but the credential isn't mapped to a ShellHub's user")
+	ErrMFACode                 = fmt.Errorf("failed to validate the verification code")
 )
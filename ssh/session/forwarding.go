@@ -0,0 +1,100 @@
+package session
+
+import (
+	"errors"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+var ErrForwardingLookup = errors.New("failed to retrieve the namespace's port forwarding settings")
+
+// ReversePortForwarding checks if the client's certificate, if any, and the session's namespace allow remote port
+// forwarding and if the namespace's policy allows binding to host and port.
+func (s *Session) ReversePortForwarding(host string, port uint32) (bool, error) {
+	if !s.certificatePermits(CertificateExtensionPermitPortForwarding) {
+		return false, nil
+	}
+
+	namespace, errs := s.api.NamespaceLookup(s.Device.TenantID)
+	if len(errs) > 0 || namespace == nil {
+		return false, ErrForwardingLookup
+	}
+
+	if namespace.Settings == nil || !namespace.Settings.ReversePortForwarding {
+		return false, nil
+	}
+
+	return s.portForwarding(namespace, host, port), nil
+}
+
+// AgentForwarding checks if the client's certificate, if any, and the session's namespace allow SSH agent forwarding.
+func (s *Session) AgentForwarding() (bool, error) {
+	if !s.certificatePermits(CertificateExtensionPermitAgentForwarding) {
+		return false, nil
+	}
+
+	namespace, errs := s.api.NamespaceLookup(s.Device.TenantID)
+	if len(errs) > 0 || namespace == nil {
+		return false, ErrForwardingLookup
+	}
+
+	if namespace.Settings == nil {
+		return false, nil
+	}
+
+	return namespace.Settings.AgentForwarding, nil
+}
+
+// LocalPortForwarding checks if the client's certificate, if any, permits port forwarding and if the session's
+// namespace policy allows local port forwarding to host and port.
+func (s *Session) LocalPortForwarding(host string, port uint32) (bool, error) {
+	if !s.certificatePermits(CertificateExtensionPermitPortForwarding) {
+		return false, nil
+	}
+
+	namespace, errs := s.api.NamespaceLookup(s.Device.TenantID)
+	if len(errs) > 0 || namespace == nil {
+		return false, ErrForwardingLookup
+	}
+
+	return s.portForwarding(namespace, host, port), nil
+}
+
+// portForwarding evaluates the namespace's port forwarding policy to host and port, allowing it when the namespace
+// has no policy. The rules by role match the role of the member mapped to the client's credential, if any.
+func (s *Session) portForwarding(namespace *models.Namespace, host string, port uint32) bool {
+	if namespace.Settings == nil || namespace.Settings.PortForwarding == nil {
+		return true
+	}
+
+	var role string
+	if member, ok := namespace.FindMember(s.Identity); ok {
+		role = member.Role
+	}
+
+	return namespace.Settings.PortForwarding.Allow(models.PortForwarding{
+		Role:     role,
+		Username: s.Target.Username,
+		Tags:     s.Device.Tags,
+		Host:     host,
+		Port:     port,
+	})
+}
+
+// X11Forwarding checks if the client's certificate, if any, and the session's namespace allow X11 forwarding.
+func (s *Session) X11Forwarding() (bool, error) {
+	if !s.certificatePermits(CertificateExtensionPermitX11Forwarding) {
+		return false, nil
+	}
+
+	namespace, errs := s.api.NamespaceLookup(s.Device.TenantID)
+	if len(errs) > 0 || namespace == nil {
+		return false, ErrForwardingLookup
+	}
+
+	if namespace.Settings == nil {
+		return false, nil
+	}
+
+	return namespace.Settings.X11Forwarding, nil
+}
@@ -0,0 +1,407 @@
+package session
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/target"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReversePortForwarding(t *testing.T) {
+	type Expected struct {
+		allowed bool
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(api *mocks.Client)
+		expected      Expected
+	}{
+		{
+			description: "fails when namespace cannot be retrieved",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(nil, []error{errors.New("error")}).Once()
+			},
+			expected: Expected{allowed: false, err: ErrForwardingLookup},
+		},
+		{
+			description: "denies when namespace has no settings",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{}, nil).Once()
+			},
+			expected: Expected{allowed: false, err: nil},
+		},
+		{
+			description: "denies when namespace disables it",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{ReversePortForwarding: false}}, nil).Once()
+			},
+			expected: Expected{allowed: false, err: nil},
+		},
+		{
+			description: "allows when namespace enables it",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{ReversePortForwarding: true}}, nil).Once()
+			},
+			expected: Expected{allowed: true, err: nil},
+		},
+		{
+			description: "denies when namespace enables it but the policy denies the address",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{
+						ReversePortForwarding: true,
+						PortForwarding: &models.PortForwardingPolicy{
+							Default: models.PortForwardingActionAllow,
+							Rules: []models.PortForwardingRule{
+								{Action: models.PortForwardingActionDeny, Ports: []models.PortForwardingPortRange{{From: 8080}}},
+							},
+						},
+					}}, nil).Once()
+			},
+			expected: Expected{allowed: false, err: nil},
+		},
+		{
+			description: "allows when namespace enables it and the policy allows the address",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{
+						ReversePortForwarding: true,
+						PortForwarding: &models.PortForwardingPolicy{
+							Default: models.PortForwardingActionDeny,
+							Rules: []models.PortForwardingRule{
+								{Action: models.PortForwardingActionAllow, Hosts: []string{"localhost"}, Ports: []models.PortForwardingPortRange{{From: 8080}}},
+							},
+						},
+					}}, nil).Once()
+			},
+			expected: Expected{allowed: true, err: nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			sess := &Session{
+				api: api,
+				Data: Data{
+					Target: &target.Target{Username: "root"},
+					Device: &models.Device{TenantID: "00000000-0000-4000-0000-000000000000"},
+				},
+			}
+
+			allowed, err := sess.ReversePortForwarding("localhost", 8080)
+			assert.Equal(t, tc.expected, Expected{allowed, err})
+
+			api.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAgentForwarding(t *testing.T) {
+	type Expected struct {
+		allowed bool
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(api *mocks.Client)
+		expected      Expected
+	}{
+		{
+			description: "fails when namespace cannot be retrieved",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(nil, []error{errors.New("error")}).Once()
+			},
+			expected: Expected{allowed: false, err: ErrForwardingLookup},
+		},
+		{
+			description: "denies when namespace disables it",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{AgentForwarding: false}}, nil).Once()
+			},
+			expected: Expected{allowed: false, err: nil},
+		},
+		{
+			description: "allows when namespace enables it",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{AgentForwarding: true}}, nil).Once()
+			},
+			expected: Expected{allowed: true, err: nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			sess := &Session{
+				api: api,
+				Data: Data{
+					Device: &models.Device{TenantID: "00000000-0000-4000-0000-000000000000"},
+				},
+			}
+
+			allowed, err := sess.AgentForwarding()
+			assert.Equal(t, tc.expected, Expected{allowed, err})
+
+			api.AssertExpectations(t)
+		})
+	}
+}
+
+func TestX11Forwarding(t *testing.T) {
+	type Expected struct {
+		allowed bool
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func(api *mocks.Client)
+		expected      Expected
+	}{
+		{
+			description: "fails when namespace cannot be retrieved",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(nil, []error{errors.New("error")}).Once()
+			},
+			expected: Expected{allowed: false, err: ErrForwardingLookup},
+		},
+		{
+			description: "denies when namespace disables it",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{X11Forwarding: false}}, nil).Once()
+			},
+			expected: Expected{allowed: false, err: nil},
+		},
+		{
+			description: "allows when namespace enables it",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{X11Forwarding: true}}, nil).Once()
+			},
+			expected: Expected{allowed: true, err: nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			sess := &Session{
+				api: api,
+				Data: Data{
+					Device: &models.Device{TenantID: "00000000-0000-4000-0000-000000000000"},
+				},
+			}
+
+			allowed, err := sess.X11Forwarding()
+			assert.Equal(t, tc.expected, Expected{allowed, err})
+
+			api.AssertExpectations(t)
+		})
+	}
+}
+
+func TestLocalPortForwarding(t *testing.T) {
+	type Expected struct {
+		allowed bool
+		err     error
+	}
+
+	policy := &models.PortForwardingPolicy{
+		Default: models.PortForwardingActionDeny,
+		Rules: []models.PortForwardingRule{
+			{
+				Action: models.PortForwardingActionDeny,
+				Hosts:  []string{"10.0.0.1"},
+			},
+			{
+				Action: models.PortForwardingActionAllow,
+				Hosts:  []string{"10.0.0.0/24", "localhost"},
+				Ports:  []models.PortForwardingPortRange{{From: 80}, {From: 8000, To: 8999}},
+			},
+			{
+				Action:    models.PortForwardingActionAllow,
+				Usernames: []string{"admin"},
+				Tags:      []string{"lab"},
+			},
+			{
+				Action: models.PortForwardingActionAllow,
+				Roles:  []string{"owner"},
+			},
+		},
+	}
+
+	cases := []struct {
+		description   string
+		identity      string
+		username      string
+		tags          []string
+		host          string
+		port          uint32
+		requiredMocks func(api *mocks.Client)
+		expected      Expected
+	}{
+		{
+			description: "fails when namespace cannot be retrieved",
+			username:    "root",
+			host:        "localhost",
+			port:        80,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(nil, []error{errors.New("error")}).Once()
+			},
+			expected: Expected{allowed: false, err: ErrForwardingLookup},
+		},
+		{
+			description: "allows when namespace has no policy",
+			username:    "root",
+			host:        "192.168.0.1",
+			port:        22,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{}}, nil).Once()
+			},
+			expected: Expected{allowed: true, err: nil},
+		},
+		{
+			description: "denies when the first matching rule denies",
+			username:    "root",
+			host:        "10.0.0.1",
+			port:        80,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{PortForwarding: policy}}, nil).Once()
+			},
+			expected: Expected{allowed: false, err: nil},
+		},
+		{
+			description: "allows when destination is inside the CIDR and port range",
+			username:    "root",
+			host:        "10.0.0.2",
+			port:        8080,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{PortForwarding: policy}}, nil).Once()
+			},
+			expected: Expected{allowed: true, err: nil},
+		},
+		{
+			description: "allows when destination matches the hostname and port",
+			username:    "root",
+			host:        "LOCALHOST",
+			port:        80,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{PortForwarding: policy}}, nil).Once()
+			},
+			expected: Expected{allowed: true, err: nil},
+		},
+		{
+			description: "denies when destination port is outside the ranges",
+			username:    "root",
+			host:        "10.0.0.2",
+			port:        9000,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{PortForwarding: policy}}, nil).Once()
+			},
+			expected: Expected{allowed: false, err: nil},
+		},
+		{
+			description: "allows when username and device's tag match",
+			username:    "admin",
+			tags:        []string{"prod", "lab"},
+			host:        "192.168.0.1",
+			port:        22,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{PortForwarding: policy}}, nil).Once()
+			},
+			expected: Expected{allowed: true, err: nil},
+		},
+		{
+			description: "denies when only the username matches",
+			username:    "admin",
+			tags:        []string{"prod"},
+			host:        "192.168.0.1",
+			port:        22,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{PortForwarding: policy}}, nil).Once()
+			},
+			expected: Expected{allowed: false, err: nil},
+		},
+		{
+			description: "allows when the member's role matches",
+			identity:    "507f1f77bcf86cd799439011",
+			username:    "root",
+			host:        "192.168.0.1",
+			port:        22,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						Members:  []models.Member{{ID: "507f1f77bcf86cd799439011", Role: "owner"}},
+						Settings: &models.NamespaceSettings{PortForwarding: policy},
+					}, nil).Once()
+			},
+			expected: Expected{allowed: true, err: nil},
+		},
+		{
+			description: "denies when the member's role does not match",
+			identity:    "507f1f77bcf86cd799439011",
+			username:    "root",
+			host:        "192.168.0.1",
+			port:        22,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").
+					Return(&models.Namespace{
+						Members:  []models.Member{{ID: "507f1f77bcf86cd799439011", Role: "observer"}},
+						Settings: &models.NamespaceSettings{PortForwarding: policy},
+					}, nil).Once()
+			},
+			expected: Expected{allowed: false, err: nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			sess := &Session{
+				api: api,
+				Data: Data{
+					Identity: tc.identity,
+					Target:   &target.Target{Username: tc.username},
+					Device:   &models.Device{TenantID: "00000000-0000-4000-0000-000000000000", Tags: tc.tags},
+				},
+			}
+
+			allowed, err := sess.LocalPortForwarding(tc.host, tc.port)
+			assert.Equal(t, tc.expected, Expected{allowed, err})
+
+			api.AssertExpectations(t)
+		})
+	}
+}
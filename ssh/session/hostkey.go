@@ -0,0 +1,44 @@
+package session
+
+import (
+	"bytes"
+	"net"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// verifyHostKey checks the host key presented by the device's agent against the one pinned when the device
+// authenticated on the API. Devices with agents that don't report their host key have nothing to be verified against.
+func (s *Session) verifyHostKey(_ string, _ net.Addr, key gossh.PublicKey) error {
+	if s.Device == nil || s.Device.HostKey == "" {
+		return nil
+	}
+
+	pinned, _, _, _, err := gossh.ParseAuthorizedKey([]byte(s.Device.HostKey))
+	if err != nil {
+		return ErrHostKeyMismatch
+	}
+
+	if bytes.Equal(pinned.Marshal(), key.Marshal()) {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"session":  s.UID,
+		"device":   s.Device.UID,
+		"tenant":   s.Device.TenantID,
+		"expected": gossh.FingerprintSHA256(pinned),
+		"received": gossh.FingerprintSHA256(key),
+	}).Error("device's host key doesn't match the pinned one")
+
+	if err := s.Event(models.SessionEventTypeHostKeyMismatch, map[string]string{
+		"expected": gossh.FingerprintSHA256(pinned),
+		"received": gossh.FingerprintSHA256(key),
+	}); err != nil {
+		log.WithError(err).WithFields(log.Fields{"session": s.UID}).Warn("failed to record the host key mismatch")
+	}
+
+	return ErrHostKeyMismatch
+}
@@ -0,0 +1,84 @@
+package session
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestVerifyHostKey(t *testing.T) {
+	generate := func() gossh.PublicKey {
+		pub, _, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+
+		key, err := gossh.NewPublicKey(pub)
+		require.NoError(t, err)
+
+		return key
+	}
+
+	pinned := generate()
+	other := generate()
+
+	cases := []struct {
+		description   string
+		hostKey       string
+		key           gossh.PublicKey
+		requiredMocks func(api *mocks.Client)
+		expected      error
+	}{
+		{
+			description:   "succeeds when the device has no pinned host key",
+			hostKey:       "",
+			key:           other,
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      nil,
+		},
+		{
+			description:   "succeeds when the host key matches the pinned one",
+			hostKey:       string(gossh.MarshalAuthorizedKey(pinned)),
+			key:           pinned,
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      nil,
+		},
+		{
+			description: "fails and records an event when the host key doesn't match the pinned one",
+			hostKey:     string(gossh.MarshalAuthorizedKey(pinned)),
+			key:         other,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("SessionEvent", "session", mock.MatchedBy(func(event *models.SessionEvent) bool {
+					return event.Type == models.SessionEventTypeHostKeyMismatch &&
+						event.Data["expected"] == gossh.FingerprintSHA256(pinned) &&
+						event.Data["received"] == gossh.FingerprintSHA256(other)
+				})).Return(nil).Once()
+			},
+			expected: ErrHostKeyMismatch,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			sess := &Session{
+				UID: "session",
+				api: api,
+				Data: Data{
+					Device: &models.Device{HostKey: tc.hostKey},
+				},
+			}
+
+			assert.ErrorIs(t, sess.verifyHostKey("", nil, tc.key), tc.expected)
+
+			api.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package session
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/host"
+	"github.com/shellhub-io/shellhub/ssh/pkg/target"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// JumpPort is the only port a jump connection can open a "direct-tcpip" channel to, as it's the one where the device's
+// SSH server is expected to be.
+const JumpPort = 22
+
+// Jump is a connection that uses ShellHub as a jump host, like OpenSSH's ProxyJump, instead of targeting a device
+// through a SSHID. The client authenticates once, with an OpenSSH user certificate issued to its ShellHub's user, and
+// each "direct-tcpip" channel to a device's address, in the "device.namespace" format, is handled as a new connection
+// to that device.
+//
+// Example of a jump connection: `ssh -J shellhub user@device.namespace`.
+type Jump struct {
+	// Identity is the ShellHub's user that owns the certificate, from its key ID.
+	Identity  string
+	IPAddress string
+
+	cert *gossh.Certificate
+	api  internalclient.Client
+}
+
+// IsJump checks if the connection uses ShellHub as a jump host, what happens when its user isn't a SSHID.
+func IsJump(ctx gliderssh.Context) bool {
+	return !strings.Contains(SSHID(ctx), "@")
+}
+
+// SSHID returns the SSHID of the connection. Connections jumped from a [Jump] only carry the device's username, as the
+// device's address was already resolved by the jump.
+func SSHID(ctx gliderssh.Context) string {
+	if conn, ok := ctx.Value("conn").(*jumpedConn); ok {
+		return ctx.User() + "@" + conn.sshid
+	}
+
+	return ctx.User()
+}
+
+// IsAlias checks if the connection targets a device without its namespace, resolved on the user's default namespace.
+func IsAlias(ctx gliderssh.Context) bool {
+	target, err := target.NewTarget(SSHID(ctx))
+
+	return err == nil && target.IsAlias()
+}
+
+// AuthJump authenticates a jump connection with the client's OpenSSH user certificate, storing the [Jump] on the
+// context when it succeeds. The certificate must be trusted by the namespace where it was issued, and it's checked
+// again against the namespace of each device reached.
+func AuthJump(ctx gliderssh.Context, key gliderssh.PublicKey) error {
+	cert, ok := key.(*gossh.Certificate)
+	if !ok || cert.KeyId == "" {
+		return ErrJumpIdentity
+	}
+
+	hos, err := host.NewHost(ctx.RemoteAddr().String())
+	if err != nil {
+		return ErrHost
+	}
+
+	jump := &Jump{
+		Identity:  cert.KeyId,
+		IPAddress: hos.Host,
+		cert:      cert,
+		api:       internalclient.NewClient(),
+	}
+
+	if err := jump.authenticate(); err != nil {
+		return err
+	}
+
+	ctx.SetValue("jump", jump)
+
+	return nil
+}
+
+// AuthAlias checks if the certificate offered to connect to a device without its namespace was issued to a member of
+// the namespace on its [models.CertificateExtensionTenant] extension, and if that namespace trusts it. It must succeed
+// before the certificate's key ID is trusted to resolve the device on the user's default namespace.
+func AuthAlias(ctx gliderssh.Context, cert *gossh.Certificate) error {
+	hos, err := host.NewHost(ctx.RemoteAddr().String())
+	if err != nil {
+		return ErrHost
+	}
+
+	// NOTICE: The certificate is checked as a jump's one, as both identify the ShellHub's user before any device.
+	jump := &Jump{
+		Identity:  cert.KeyId,
+		IPAddress: hos.Host,
+		cert:      cert,
+		api:       internalclient.NewClient(),
+	}
+
+	return jump.authenticate()
+}
+
+// ObtainJump returns the [Jump] authenticated on the connection, if any.
+func ObtainJump(ctx gliderssh.Context) (*Jump, bool) {
+	jump, ok := ctx.Value("jump").(*Jump)
+
+	return jump, ok && jump != nil
+}
+
+// Resolve resolves the device's address, in the "device.namespace" format, checking if the device's namespace trusts
+// the jump's certificate. The device can be referenced by its name or any of its aliases and, when the namespace is
+// omitted, it's looked up on the user's default namespace. It returns the device's SSHID, without the username.
+func (j *Jump) Resolve(address string, port uint32) (string, error) {
+	if port != JumpPort {
+		return "", ErrJumpTarget
+	}
+
+	lookup := map[string]string{"name": address, "user": j.Identity}
+	if index := strings.LastIndex(address, "."); index >= 0 {
+		if index == 0 || index == len(address)-1 {
+			return "", ErrJumpTarget
+		}
+
+		lookup = map[string]string{"domain": address[index+1:], "name": address[:index]}
+	}
+
+	device, errs := j.api.DeviceLookup(lookup)
+	if len(errs) > 0 || device == nil {
+		return "", ErrFindDevice
+	}
+
+	namespace, errs := j.api.NamespaceLookup(device.TenantID)
+	if len(errs) > 0 || namespace == nil {
+		return "", ErrFindNamespace
+	}
+
+	if err := j.trusted(namespace); err != nil {
+		return "", err
+	}
+
+	return namespace.Name + "." + lookup["name"], nil
+}
+
+// authenticate checks if the certificate was issued to a member of the namespace on its
+// [models.CertificateExtensionTenant] extension, and if that namespace trusts it.
+func (j *Jump) authenticate() error {
+	tenant := j.cert.Extensions[models.CertificateExtensionTenant]
+	if tenant == "" {
+		return ErrJumpIdentity
+	}
+
+	namespace, errs := j.api.NamespaceLookup(tenant)
+	if len(errs) > 0 || namespace == nil {
+		return ErrFindNamespace
+	}
+
+	if _, ok := namespace.FindMember(j.Identity); !ok {
+		return ErrJumpIdentity
+	}
+
+	return j.trusted(namespace)
+}
+
+// trusted checks if the certificate is signed by any of the namespace's certificate authorities and is valid.
+func (j *Jump) trusted(namespace *models.Namespace) error {
+	ca, authorities, err := certificateAuthorities(namespace)
+	if err != nil {
+		return err
+	}
+
+	if !signedBy(j.cert, authorities) {
+		return ErrCertificateInvalid
+	}
+
+	return j.checkCertificate(ca)
+}
+
+// checkCertificate checks the certificate's principals, type, validity window, critical options, revocation and
+// signature.
+func (j *Jump) checkCertificate(ca *models.UserCA) error {
+	checker := &gossh.CertChecker{
+		IsRevoked: func(cert *gossh.Certificate) bool {
+			return ca.Revoked(cert.Serial)
+		},
+		SupportedCriticalOptions: []string{CertificateOptionSourceAddress, CertificateOptionForceCommand},
+		Clock:                    clock.Now,
+	}
+
+	// NOTICE: The principals are checked against the device's username when the jumped connection authenticates, so
+	// any principal of the certificate is enough here. A certificate without principals would be valid for any one.
+	if len(j.cert.ValidPrincipals) == 0 {
+		return ErrCertificatePrincipal
+	}
+
+	if err := checker.CheckCert(j.cert.ValidPrincipals[0], j.cert); err != nil {
+		return errors.Join(ErrCertificateInvalid, err)
+	}
+
+	if addresses, ok := j.cert.CriticalOptions[CertificateOptionSourceAddress]; ok {
+		if !allowedSourceAddress(addresses, j.IPAddress) {
+			return ErrCertificateSource
+		}
+	}
+
+	return nil
+}
+
+// PortForwarding checks if the certificate permits port forwarding, what each jump to a device is made of.
+func (j *Jump) PortForwarding() bool {
+	_, ok := j.cert.Extensions[CertificateExtensionPermitPortForwarding]
+
+	return ok
+}
+
+// jumpedConn is a "direct-tcpip" channel of a [Jump] used as the client's connection to a device.
+type jumpedConn struct {
+	gossh.Channel
+	sshid  string
+	local  net.Addr
+	remote net.Addr
+}
+
+// NewJumpedConn creates a connection to the device with the SSHID, without the username, over a "direct-tcpip"
+// channel of a [Jump]. The addresses are the ones from the jump connection, so the jumped connection is seen as coming
+// from the same client.
+func NewJumpedConn(channel gossh.Channel, sshid string, local, remote net.Addr) net.Conn {
+	return &jumpedConn{Channel: channel, sshid: sshid, local: local, remote: remote}
+}
+
+func (c *jumpedConn) LocalAddr() net.Addr {
+	return c.local
+}
+
+func (c *jumpedConn) RemoteAddr() net.Addr {
+	return c.remote
+}
+
+func (*jumpedConn) SetDeadline(time.Time) error {
+	return nil
+}
+
+func (*jumpedConn) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+func (*jumpedConn) SetWriteDeadline(time.Time) error {
+	return nil
+}
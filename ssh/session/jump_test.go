@@ -0,0 +1,322 @@
+package session
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestJumpResolve(t *testing.T) {
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ca, err := gossh.NewSignerFromKey(caKey)
+	require.NoError(t, err)
+
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	other, err := gossh.NewSignerFromKey(otherKey)
+	require.NoError(t, err)
+
+	userKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	user, err := gossh.NewPublicKey(userKey)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	certificate := func(signer gossh.Signer) *gossh.Certificate {
+		cert := &gossh.Certificate{
+			Key:             user,
+			Serial:          1,
+			CertType:        gossh.UserCert,
+			KeyId:           "507f1f77bcf86cd799439011",
+			ValidPrincipals: []string{"root"},
+			ValidAfter:      uint64(now.Add(-time.Hour).Unix()),
+			ValidBefore:     uint64(now.Add(time.Hour).Unix()),
+		}
+
+		require.NoError(t, cert.SignCert(rand.Reader, signer))
+
+		return cert
+	}
+
+	device := &models.Device{UID: "uid", Name: "device.local", TenantID: "00000000-0000-4000-0000-000000000000"}
+	lookup := map[string]string{"domain": "namespace", "name": "device.local"}
+
+	namespace := &models.Namespace{
+		Name:     "namespace",
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		CA:       &models.NamespaceCA{PublicKey: string(gossh.MarshalAuthorizedKey(ca.PublicKey()))},
+	}
+
+	type Expected struct {
+		sshid string
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		cert          *gossh.Certificate
+		address       string
+		port          uint32
+		requiredMocks func(api *mocks.Client)
+		expected      Expected
+	}{
+		{
+			description:   "fails when the port isn't the SSH port",
+			cert:          certificate(ca),
+			address:       "device.local.namespace",
+			port:          80,
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      Expected{sshid: "", err: ErrJumpTarget},
+		},
+		{
+			description:   "fails when the address has an empty namespace",
+			cert:          certificate(ca),
+			address:       "device.",
+			port:          22,
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      Expected{sshid: "", err: ErrJumpTarget},
+		},
+		{
+			description: "fails when the device is not found",
+			cert:        certificate(ca),
+			address:     "device.local.namespace",
+			port:        22,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("DeviceLookup", lookup).Return(nil, []error{errors.New("error")}).Once()
+			},
+			expected: Expected{sshid: "", err: ErrFindDevice},
+		},
+		{
+			description: "fails when the namespace is not found",
+			cert:        certificate(ca),
+			address:     "device.local.namespace",
+			port:        22,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("DeviceLookup", lookup).Return(device, nil).Once()
+				api.On("NamespaceLookup", device.TenantID).Return(nil, []error{errors.New("error")}).Once()
+			},
+			expected: Expected{sshid: "", err: ErrFindNamespace},
+		},
+		{
+			description: "fails when the certificate is signed by another authority",
+			cert:        certificate(other),
+			address:     "device.local.namespace",
+			port:        22,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("DeviceLookup", lookup).Return(device, nil).Once()
+				api.On("NamespaceLookup", device.TenantID).Return(namespace, nil).Once()
+			},
+			expected: Expected{sshid: "", err: ErrCertificateInvalid},
+		},
+		{
+			description: "fails when the certificate is revoked",
+			cert:        certificate(ca),
+			address:     "device.local.namespace",
+			port:        22,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("DeviceLookup", lookup).Return(device, nil).Once()
+				api.On("NamespaceLookup", device.TenantID).Return(&models.Namespace{
+					TenantID: namespace.TenantID,
+					CA:       namespace.CA,
+					Settings: &models.NamespaceSettings{UserCA: &models.UserCA{RevokedSerials: []uint64{1}}},
+				}, nil).Once()
+			},
+			expected: Expected{sshid: "", err: ErrCertificateInvalid},
+		},
+		{
+			description: "succeeds when the device's namespace trusts the certificate",
+			cert:        certificate(ca),
+			address:     "device.local.namespace",
+			port:        22,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("DeviceLookup", lookup).Return(device, nil).Once()
+				api.On("NamespaceLookup", device.TenantID).Return(namespace, nil).Once()
+			},
+			expected: Expected{sshid: "namespace.device.local", err: nil},
+		},
+		{
+			description: "succeeds when the device is on the user's default namespace",
+			cert:        certificate(ca),
+			address:     "web01",
+			port:        22,
+			requiredMocks: func(api *mocks.Client) {
+				api.On("DeviceLookup", map[string]string{"name": "web01", "user": "507f1f77bcf86cd799439011"}).Return(device, nil).Once()
+				api.On("NamespaceLookup", device.TenantID).Return(namespace, nil).Once()
+			},
+			expected: Expected{sshid: "namespace.web01", err: nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			jump := &Jump{
+				Identity:  tc.cert.KeyId,
+				IPAddress: "192.168.0.1",
+				cert:      tc.cert,
+				api:       api,
+			}
+
+			sshid, err := jump.Resolve(tc.address, tc.port)
+			assert.ErrorIs(t, err, tc.expected.err)
+			assert.Equal(t, tc.expected.sshid, sshid)
+
+			api.AssertExpectations(t)
+		})
+	}
+}
+
+func TestJumpAuthenticate(t *testing.T) {
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	ca, err := gossh.NewSignerFromKey(caKey)
+	require.NoError(t, err)
+
+	_, otherKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	other, err := gossh.NewSignerFromKey(otherKey)
+	require.NoError(t, err)
+
+	userKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	user, err := gossh.NewPublicKey(userKey)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	certificate := func(signer gossh.Signer, changes func(cert *gossh.Certificate)) *gossh.Certificate {
+		cert := &gossh.Certificate{
+			Key:             user,
+			Serial:          1,
+			CertType:        gossh.UserCert,
+			KeyId:           "507f1f77bcf86cd799439011",
+			ValidPrincipals: []string{"root"},
+			ValidAfter:      uint64(now.Add(-time.Hour).Unix()),
+			ValidBefore:     uint64(now.Add(time.Hour).Unix()),
+			Permissions: gossh.Permissions{
+				Extensions: map[string]string{models.CertificateExtensionTenant: "00000000-0000-4000-0000-000000000000"},
+			},
+		}
+
+		if changes != nil {
+			changes(cert)
+		}
+
+		require.NoError(t, cert.SignCert(rand.Reader, signer))
+
+		return cert
+	}
+
+	namespace := &models.Namespace{
+		Name:     "namespace",
+		TenantID: "00000000-0000-4000-0000-000000000000",
+		Members:  []models.Member{{ID: "507f1f77bcf86cd799439011", Role: "operator"}},
+		CA:       &models.NamespaceCA{PublicKey: string(gossh.MarshalAuthorizedKey(ca.PublicKey()))},
+	}
+
+	cases := []struct {
+		description   string
+		cert          *gossh.Certificate
+		requiredMocks func(api *mocks.Client)
+		expected      error
+	}{
+		{
+			description: "fails when the certificate has no namespace",
+			cert: certificate(ca, func(cert *gossh.Certificate) {
+				cert.Extensions = nil
+			}),
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      ErrJumpIdentity,
+		},
+		{
+			description: "fails when the namespace is not found",
+			cert:        certificate(ca, nil),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").Return(nil, []error{errors.New("error")}).Once()
+			},
+			expected: ErrFindNamespace,
+		},
+		{
+			description: "fails when the certificate's identity isn't a member of the namespace",
+			cert: certificate(ca, func(cert *gossh.Certificate) {
+				cert.KeyId = "507f191e810c19729de860ea"
+			}),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+			},
+			expected: ErrJumpIdentity,
+		},
+		{
+			description: "fails when the certificate is signed by another authority",
+			cert:        certificate(other, nil),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+			},
+			expected: ErrCertificateInvalid,
+		},
+		{
+			description: "fails when the certificate has no principals",
+			cert: certificate(ca, func(cert *gossh.Certificate) {
+				cert.ValidPrincipals = nil
+			}),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+			},
+			expected: ErrCertificatePrincipal,
+		},
+		{
+			description: "fails when the certificate is expired",
+			cert: certificate(ca, func(cert *gossh.Certificate) {
+				cert.ValidBefore = uint64(now.Add(-time.Minute).Unix())
+			}),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+			},
+			expected: ErrCertificateInvalid,
+		},
+		{
+			description: "succeeds when the namespace trusts the certificate",
+			cert:        certificate(ca, nil),
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", "00000000-0000-4000-0000-000000000000").Return(namespace, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			jump := &Jump{
+				Identity:  tc.cert.KeyId,
+				IPAddress: "192.168.0.1",
+				cert:      tc.cert,
+				api:       api,
+			}
+
+			assert.ErrorIs(t, jump.authenticate(), tc.expected)
+
+			api.AssertExpectations(t)
+		})
+	}
+}
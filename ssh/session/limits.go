@@ -0,0 +1,89 @@
+package session
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+var ErrLimitsLookup = errors.New("failed to retrieve the namespace's session limits")
+
+// Limits are the namespace's policies that bound how long a session can last.
+type Limits struct {
+	// IdleTimeout is the period without client's input after which the session is disconnected. Zero disables it.
+	IdleTimeout time.Duration
+	// MaxDuration is the maximum period a session can last. Zero disables it.
+	MaxDuration time.Duration
+}
+
+// Enabled checks if any limit is set.
+func (l Limits) Enabled() bool {
+	return l.IdleTimeout > 0 || l.MaxDuration > 0
+}
+
+// Deadline returns the moment when a session, started at startedAt and with the last client's input at lastInput, must
+// be disconnected, the limit what causes it and the reason of the disconnection. When no limit is set, it returns a
+// zero time.
+func (l Limits) Deadline(startedAt, lastInput time.Time) (time.Time, time.Duration, string) {
+	var deadline time.Time
+	var limit time.Duration
+	var reason string
+
+	if l.IdleTimeout > 0 {
+		deadline = lastInput.Add(l.IdleTimeout)
+		limit = l.IdleTimeout
+		reason = models.SessionDisconnectReasonIdleTimeout
+	}
+
+	if l.MaxDuration > 0 {
+		if end := startedAt.Add(l.MaxDuration); deadline.IsZero() || end.Before(deadline) {
+			deadline = end
+			limit = l.MaxDuration
+			reason = models.SessionDisconnectReasonMaxDuration
+		}
+	}
+
+	return deadline, limit, reason
+}
+
+// Limits retrieves the session's limits from its namespace's settings.
+func (s *Session) Limits() (Limits, error) {
+	namespace, errs := s.api.NamespaceLookup(s.Device.TenantID)
+	if len(errs) > 0 || namespace == nil {
+		return Limits{}, ErrLimitsLookup
+	}
+
+	if namespace.Settings == nil {
+		return Limits{}, nil
+	}
+
+	return Limits{
+		IdleTimeout: time.Duration(namespace.Settings.SessionIdleTimeout) * time.Minute,
+		MaxDuration: time.Duration(namespace.Settings.SessionMaxDuration) * time.Minute,
+	}, nil
+}
+
+// Touch registers the client's input on the session, restarting its idle period.
+func (s *Session) Touch() {
+	s.lastInput.Store(clock.Now().UnixNano())
+}
+
+// LastInput returns the moment of the last client's input on the session.
+func (s *Session) LastInput() time.Time {
+	return time.Unix(0, s.lastInput.Load())
+}
+
+// SetDisconnectReason defines the reason why ShellHub is disconnecting the session. It is sent to the API when the
+// session finishes.
+func (s *Session) SetDisconnectReason(reason string) {
+	s.disconnectReason.Store(reason)
+}
+
+// DisconnectReason returns the reason why ShellHub disconnected the session, or an empty string when it did not.
+func (s *Session) DisconnectReason() string {
+	reason, _ := s.disconnectReason.Load().(string)
+
+	return reason
+}
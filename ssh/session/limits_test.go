@@ -0,0 +1,80 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimitsDeadline(t *testing.T) {
+	type Expected struct {
+		deadline time.Time
+		limit    time.Duration
+		reason   string
+	}
+
+	startedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		description string
+		limits      Limits
+		lastInput   time.Time
+		expected    Expected
+	}{
+		{
+			description: "returns a zero deadline when no limit is set",
+			limits:      Limits{},
+			lastInput:   startedAt,
+			expected:    Expected{},
+		},
+		{
+			description: "returns the idle deadline when only the idle timeout is set",
+			limits:      Limits{IdleTimeout: 10 * time.Minute},
+			lastInput:   startedAt.Add(5 * time.Minute),
+			expected: Expected{
+				deadline: startedAt.Add(15 * time.Minute),
+				limit:    10 * time.Minute,
+				reason:   models.SessionDisconnectReasonIdleTimeout,
+			},
+		},
+		{
+			description: "returns the maximum duration deadline when only the maximum duration is set",
+			limits:      Limits{MaxDuration: time.Hour},
+			lastInput:   startedAt.Add(5 * time.Minute),
+			expected: Expected{
+				deadline: startedAt.Add(time.Hour),
+				limit:    time.Hour,
+				reason:   models.SessionDisconnectReasonMaxDuration,
+			},
+		},
+		{
+			description: "returns the idle deadline when it comes before the maximum duration",
+			limits:      Limits{IdleTimeout: 10 * time.Minute, MaxDuration: time.Hour},
+			lastInput:   startedAt.Add(5 * time.Minute),
+			expected: Expected{
+				deadline: startedAt.Add(15 * time.Minute),
+				limit:    10 * time.Minute,
+				reason:   models.SessionDisconnectReasonIdleTimeout,
+			},
+		},
+		{
+			description: "returns the maximum duration deadline when it comes before the idle deadline",
+			limits:      Limits{IdleTimeout: 10 * time.Minute, MaxDuration: time.Hour},
+			lastInput:   startedAt.Add(55 * time.Minute),
+			expected: Expected{
+				deadline: startedAt.Add(time.Hour),
+				limit:    time.Hour,
+				reason:   models.SessionDisconnectReasonMaxDuration,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			deadline, limit, reason := tc.limits.Deadline(startedAt, tc.lastInput)
+			assert.Equal(t, tc.expected, Expected{deadline, limit, reason})
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package session
+
+// evaluateMFA checks if the device's namespace requires MFA to connect, returning [ErrMFARequired] when the session must
+// wait for the TOTP code of the ShellHub's user mapped to the client's key, or [ErrMFAIdentity] when the credential
+// isn't mapped to any.
+func (s *Session) evaluateMFA(auth Auth) error {
+	// NOTICE: The magic key is only used by ShellHub's own services, like the file transfers, whose dialers check the
+	// MFA's code of the public key's creator themselves before using it.
+	if pk, ok := auth.(*publicKeyAuth); ok && pk.magic() {
+		return nil
+	}
+
+	namespace, errs := s.api.NamespaceLookup(s.Device.TenantID)
+	if len(errs) > 0 || namespace == nil {
+		return ErrFindNamespace
+	}
+
+	if namespace.Settings == nil || !namespace.Settings.MFARequired {
+		return nil
+	}
+
+	if s.Identity == "" {
+		return ErrMFAIdentity
+	}
+
+	return ErrMFARequired
+}
@@ -0,0 +1,102 @@
+package session
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/magickey"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestEvaluateMFA(t *testing.T) {
+	magic, err := gossh.NewPublicKey(&magickey.GetRerefence().PublicKey)
+	require.NoError(t, err)
+
+	userKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	user, err := gossh.NewPublicKey(userKey)
+	require.NoError(t, err)
+
+	const tenant = "00000000-0000-4000-0000-000000000000"
+
+	cases := []struct {
+		description   string
+		auth          Auth
+		identity      string
+		requiredMocks func(api *mocks.Client)
+		expected      error
+	}{
+		{
+			description:   "succeeds when the client uses the magic key",
+			auth:          AuthPublicKey(magic),
+			identity:      "",
+			requiredMocks: func(_ *mocks.Client) {},
+			expected:      nil,
+		},
+		{
+			description: "fails when namespace cannot be retrieved",
+			auth:        AuthPassword("password"),
+			identity:    "",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", tenant).Return(nil, []error{errors.New("error")}).Once()
+			},
+			expected: ErrFindNamespace,
+		},
+		{
+			description: "succeeds when namespace doesn't require MFA",
+			auth:        AuthPassword("password"),
+			identity:    "",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", tenant).
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{MFARequired: false}}, nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "fails when the credential isn't mapped to a user",
+			auth:        AuthPassword("password"),
+			identity:    "",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", tenant).
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{MFARequired: true}}, nil).Once()
+			},
+			expected: ErrMFAIdentity,
+		},
+		{
+			description: "requires MFA when the credential is mapped to a user",
+			auth:        AuthPublicKey(user),
+			identity:    "507f1f77bcf86cd799439011",
+			requiredMocks: func(api *mocks.Client) {
+				api.On("NamespaceLookup", tenant).
+					Return(&models.Namespace{Settings: &models.NamespaceSettings{MFARequired: true}}, nil).Once()
+			},
+			expected: ErrMFARequired,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			api := new(mocks.Client)
+			tc.requiredMocks(api)
+
+			sess := &Session{
+				api: api,
+				Data: Data{
+					Device:   &models.Device{TenantID: tenant},
+					Identity: tc.identity,
+				},
+			}
+
+			assert.ErrorIs(t, sess.evaluateMFA(tc.auth), tc.expected)
+
+			api.AssertExpectations(t)
+		})
+	}
+}
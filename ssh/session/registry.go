@@ -0,0 +1,195 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/connman"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// DisconnectURL is the path where the other instances of the server drop the client's connection of a session handled
+// by this one. It isn't meant to be exposed outside the instances' network.
+const DisconnectURL = "/internal/sessions/:uid/disconnect"
+
+// ownerTTL is how long a session is recorded on the registry without a keep alive from its device.
+const ownerTTL = 10 * time.Minute
+
+// ownerKeyPrefix is the prefix of the registry's keys where the sessions are recorded, apart from the devices'
+// connections.
+const ownerKeyPrefix = "session/"
+
+var ErrDisconnect = errors.New("failed to disconnect the session on the instance that handles it")
+
+// sessions keeps the sessions handled by this server, indexed by their UIDs. It allows actions that come from outside
+// the SSH connection, like closing a session from the API, to reach the client's connection.
+var sessions sync.Map
+
+// owners records, when set, which instance of the server handles each session, identified by instance.
+var (
+	owners   connman.Registry
+	instance string
+)
+
+// SetRegistry shares the sessions handled by this server with the other instances of a horizontally scaled service
+// through the registry, where this instance is known by its address, reachable by the other ones.
+func SetRegistry(registry connman.Registry, address string) {
+	owners = registry
+	instance = address
+}
+
+// track registers the session as handled by this server.
+func (s *Session) track() {
+	sessions.Store(s.UID, s)
+	s.own()
+}
+
+// untrack removes the session from the sessions handled by this server.
+func (s *Session) untrack() {
+	sessions.Delete(s.UID)
+
+	if owners == nil {
+		return
+	}
+
+	if err := owners.Delete(context.Background(), ownerKeyPrefix+s.UID, instance); err != nil {
+		log.WithError(err).WithField("uid", s.UID).Error("failed to unregister the session")
+	}
+}
+
+// own records, on the registry, that this instance handles the session.
+func (s *Session) own() {
+	if owners == nil {
+		return
+	}
+
+	if err := owners.Set(context.Background(), ownerKeyPrefix+s.UID, instance, ownerTTL); err != nil {
+		log.WithError(err).WithField("uid", s.UID).Error("failed to register the session")
+	}
+}
+
+// Owner returns the instance that handles the session when it isn't this one. It returns [connman.ErrNoConnection]
+// when there is no registry or no other instance handles the session.
+func Owner(ctx context.Context, uid string) (string, error) {
+	if owners == nil {
+		return "", connman.ErrNoConnection
+	}
+
+	owner, err := owners.Get(ctx, ownerKeyPrefix+uid)
+	if err != nil {
+		return "", err
+	}
+
+	// NOTICE: A record pointing to this instance, for a session that isn't here, is a leftover of a session that is
+	// gone.
+	if owner == instance {
+		return "", connman.ErrNoConnection
+	}
+
+	return owner, nil
+}
+
+// DisconnectSession drops the client's connection of the session. When the session is handled by another instance,
+// the disconnection is forwarded to it through its [DisconnectURL].
+func DisconnectSession(ctx context.Context, uid string) error {
+	if sess, ok := Lookup(uid); ok {
+		return sess.Disconnect()
+	}
+
+	owner, err := Owner(ctx, uid)
+	if err != nil {
+		return err
+	}
+
+	path := strings.Replace(DisconnectURL, ":uid", url.PathEscape(uid), 1)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+owner+path, nil)
+	if err != nil {
+		return errors.Join(ErrDisconnect, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Join(ErrDisconnect, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s", ErrDisconnect, resp.Status)
+	}
+
+	return nil
+}
+
+// Lookup returns the session with the specified UID when it is handled by this server.
+func Lookup(uid string) (*Session, bool) {
+	value, ok := sessions.Load(uid)
+	if !ok {
+		return nil, false
+	}
+
+	sess, ok := value.(*Session)
+
+	return sess, ok
+}
+
+// Sessions returns every session handled by this server.
+func Sessions() []*Session {
+	list := make([]*Session, 0)
+	sessions.Range(func(_, value any) bool {
+		if sess, ok := value.(*Session); ok {
+			list = append(list, sess)
+		}
+
+		return true
+	})
+
+	return list
+}
+
+// Watch registers a client's channel of the session to receive the notices sent by [Session.Notify]. It returns a
+// function to unregister the channel, what must be called when the channel is closed.
+func (s *Session) Watch(client gossh.Channel) func() {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+
+	if s.clients == nil {
+		s.clients = make(map[gossh.Channel]struct{})
+	}
+
+	s.clients[client] = struct{}{}
+
+	return func() {
+		s.clientsMu.Lock()
+		defer s.clientsMu.Unlock()
+
+		delete(s.clients, client)
+	}
+}
+
+// Notify writes a notice, from ShellHub, to the standard error of every client's channel watched on the session.
+func (s *Session) Notify(notice string) {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+
+	for client := range s.clients {
+		fmt.Fprintf(client.Stderr(), "\r\nShellHub: %s\r\n", notice) //nolint:errcheck
+	}
+}
+
+// Disconnect closes the client's connection of the session, what causes the session to be finished.
+func (s *Session) Disconnect() error {
+	if s.ClientConn == nil {
+		return nil
+	}
+
+	return s.ClientConn.Close()
+}
@@ -0,0 +1,134 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/connman"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// memoryRegistry is a [connman.Registry] kept in memory.
+type memoryRegistry map[string]string
+
+func (r memoryRegistry) Set(_ context.Context, key, instance string, _ time.Duration) error {
+	r[key] = instance
+
+	return nil
+}
+
+func (r memoryRegistry) Get(_ context.Context, key string) (string, error) {
+	instance, ok := r[key]
+	if !ok {
+		return "", connman.ErrNoConnection
+	}
+
+	return instance, nil
+}
+
+func (r memoryRegistry) Delete(_ context.Context, key, instance string) error {
+	if r[key] == instance {
+		delete(r, key)
+	}
+
+	return nil
+}
+
+// channel is a [gossh.Channel] that keeps what is written to its standard error.
+type channel struct {
+	gossh.Channel
+	stderr bytes.Buffer
+}
+
+func (c *channel) Stderr() io.ReadWriter {
+	return &c.stderr
+}
+
+func TestSessionsNotify(t *testing.T) {
+	first := &Session{UID: "first", once: new(sync.Once)}
+	second := &Session{UID: "second", once: new(sync.Once)}
+
+	first.track()
+	second.track()
+
+	t.Cleanup(func() {
+		first.untrack()
+		second.untrack()
+	})
+
+	assert.ElementsMatch(t, []*Session{first, second}, Sessions())
+
+	watched := new(channel)
+	unwatched := new(channel)
+
+	first.Watch(watched)
+	unwatch := first.Watch(unwatched)
+	unwatch()
+
+	first.Notify("this server is shutting down.")
+
+	assert.Equal(t, "\r\nShellHub: this server is shutting down.\r\n", watched.stderr.String())
+	assert.Empty(t, unwatched.stderr.String())
+
+	second.untrack()
+
+	assert.Equal(t, []*Session{first}, Sessions())
+}
+
+func TestDisconnectSession(t *testing.T) {
+	registry := memoryRegistry{}
+
+	SetRegistry(registry, "ssh-1:8080")
+	t.Cleanup(func() {
+		SetRegistry(nil, "")
+	})
+
+	t.Run("records the session handled by this instance", func(t *testing.T) {
+		sess := &Session{UID: "local", once: new(sync.Once)}
+
+		sess.track()
+		assert.Equal(t, "ssh-1:8080", registry["session/local"])
+
+		_, err := Owner(context.Background(), "local")
+		assert.ErrorIs(t, err, connman.ErrNoConnection)
+
+		sess.untrack()
+		assert.NotContains(t, registry, "session/local")
+	})
+
+	t.Run("forwards the disconnection to the instance that handles the session", func(t *testing.T) {
+		var path string
+		owner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(owner.Close)
+
+		registry["session/remote"] = strings.TrimPrefix(owner.URL, "http://")
+
+		require.NoError(t, DisconnectSession(context.Background(), "remote"))
+		assert.Equal(t, "/internal/sessions/remote/disconnect", path)
+	})
+
+	t.Run("fails when the instance doesn't handle the session anymore", func(t *testing.T) {
+		owner := httptest.NewServer(http.NotFoundHandler())
+		t.Cleanup(owner.Close)
+
+		registry["session/gone"] = strings.TrimPrefix(owner.URL, "http://")
+
+		assert.ErrorIs(t, DisconnectSession(context.Background(), "gone"), ErrDisconnect)
+	})
+
+	t.Run("fails when no instance handles the session", func(t *testing.T) {
+		assert.ErrorIs(t, DisconnectSession(context.Background(), "unknown"), connman.ErrNoConnection)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	gliderssh "github.com/gliderlabs/ssh"
@@ -56,6 +57,15 @@ type Data struct {
 	Pty Pty
 	// Handled check if the session is already handling a "shell", "exec" or a "subsystem".
 	Handled bool
+	// ForceCommand is the command forced by the client's certificate, executed instead of any "shell", "exec" or
+	// "subsystem" requested.
+	ForceCommand string
+	// Certificate is the OpenSSH user certificate the client authenticated with, if any, whose extensions restrict the
+	// session's features.
+	Certificate *gossh.Certificate
+	// Identity is the ShellHub's user mapped to the client's key, if any, used to ask for the MFA when the device's
+	// namespace requires it.
+	Identity string
 }
 
 // TODO: implement [io.Read] and [io.Write] on session to simplify the data piping.
@@ -63,6 +73,8 @@ type Session struct {
 	// UID is the session's UID.
 	UID string
 
+	// ClientConn is the connection between the Client and Server.
+	ClientConn net.Conn
 	// AgentConn is the connection between the Server and Agent.
 	AgentConn net.Conn
 	// AgentClient is a [gossh.Client] connected and authenticated to the agent, waiting for a open sesssion request.
@@ -70,10 +82,23 @@ type Session struct {
 	// AgentGlobalReqs is the channel to handle global request like "keepalive".
 	AgentGlobalReqs <-chan *gossh.Request
 
+	// StartedAt is the moment when the session was registered on the API.
+	StartedAt time.Time
+
 	api    internalclient.Client
 	tunnel *httptunnel.Tunnel
 
 	once *sync.Once
+	// limitsOnce ensures the namespace's limits are enforced only once per session, no matter how many channels it
+	// has.
+	limitsOnce *sync.Once
+	// lastInput is the Unix time, in nanoseconds, of the last client's input.
+	lastInput atomic.Int64
+	// disconnectReason is the reason why ShellHub disconnected the session.
+	disconnectReason atomic.Value
+	// clients are the client's channels watched to receive the notices sent to the session.
+	clients   map[gossh.Channel]struct{}
+	clientsMu sync.Mutex
 
 	Data
 }
@@ -81,12 +106,15 @@ type Session struct {
 // NewSession creates a new Session but differs from [New] as it only creates
 // the session without registering, connecting to the agent and etc.
 //
+// The identity is the ShellHub's user connecting, if known, used to resolve a target without namespace on the user's
+// default namespace.
+//
 // It's designed to be used within New.
-func NewSession(ctx gliderssh.Context, tunnel *httptunnel.Tunnel) (*Session, error) {
+func NewSession(ctx gliderssh.Context, tunnel *httptunnel.Tunnel, identity string) (*Session, error) {
 	snap := getSnapshot(ctx)
 
 	api := internalclient.NewClient()
-	sshid := ctx.User()
+	sshid := SSHID(ctx)
 
 	target, err := target.NewTarget(sshid)
 	if err != nil {
@@ -94,12 +122,13 @@ func NewSession(ctx gliderssh.Context, tunnel *httptunnel.Tunnel) (*Session, err
 	}
 
 	var namespace, hostname string
-	if target.IsSSHID() {
+	switch {
+	case target.IsSSHID():
 		namespace, hostname, err = target.SplitSSHID()
 		if err != nil {
 			return nil, err
 		}
-	} else {
+	case target.IsUID():
 		device, err := api.GetDevice(target.Data)
 		if err != nil {
 			return nil, err
@@ -107,6 +136,23 @@ func NewSession(ctx gliderssh.Context, tunnel *httptunnel.Tunnel) (*Session, err
 
 		namespace = device.Namespace
 		hostname = device.Name
+	default:
+		if identity == "" {
+			return nil, ErrAliasIdentity
+		}
+
+		device, errs := api.DeviceLookup(map[string]string{"name": target.Data, "user": identity})
+		if len(errs) > 0 || device == nil {
+			return nil, ErrFindDevice
+		}
+
+		ns, errs := api.NamespaceLookup(device.TenantID)
+		if len(errs) > 0 || ns == nil {
+			return nil, ErrFindNamespace
+		}
+
+		namespace = ns.Name
+		hostname = target.Data
 	}
 
 	lookup := map[string]string{
@@ -127,18 +173,22 @@ func NewSession(ctx gliderssh.Context, tunnel *httptunnel.Tunnel) (*Session, err
 		return nil, ErrHost
 	}
 
+	conn, _ := ctx.Value("conn").(net.Conn)
+
 	session := &Session{
-		UID:    ctx.SessionID(),
-		api:    api,
-		tunnel: tunnel,
+		UID:        ctx.SessionID(),
+		ClientConn: conn,
+		api:        api,
+		tunnel:     tunnel,
 		Data: Data{
 			IPAddress: hos.Host,
 			Target:    target,
 			Device:    device,
 			Lookup:    lookup,
-			SSHID:     ctx.User(),
+			SSHID:     sshid,
 		},
-		once: new(sync.Once),
+		once:       new(sync.Once),
+		limitsOnce: new(sync.Once),
 	}
 
 	session.Data.Lookup["username"] = target.Username
@@ -210,6 +260,9 @@ func (s *Session) register() error {
 		return err
 	}
 
+	s.StartedAt = clock.Now()
+	s.Touch()
+
 	return nil
 }
 
@@ -228,7 +281,7 @@ func (s *Session) authenticate() error {
 func (s *Session) connect(ctx gliderssh.Context, authOpt authFunc) error {
 	config := &gossh.ClientConfig{
 		User:            s.Target.Username,
-		HostKeyCallback: gossh.InsecureIgnoreHostKey(), // nolint: gosec
+		HostKeyCallback: s.verifyHostKey,
 	}
 
 	if err := authOpt(s, config); err != nil {
@@ -339,6 +392,9 @@ func (s *Session) Evaluate(ctx gliderssh.Context) error {
 //
 // Next steps can use the context's snapshot to retrieve the created session. An error is
 // returned if any occurs.
+//
+// When the device's namespace requires MFA, the session is saved as [StateMFA] and [ErrMFARequired] is returned, so
+// the authentication must be finished by [Session.AuthMFA].
 func (s *Session) Auth(ctx gliderssh.Context, auth Auth) error {
 	snap := getSnapshot(ctx)
 
@@ -347,11 +403,23 @@ func (s *Session) Auth(ctx gliderssh.Context, auth Auth) error {
 	// different states efficiently.
 	sess, state := snap.retrieve()
 	switch state {
-	case StateEvaluated:
+	case StateEvaluated, StateMFA:
+		// NOTICE: The client can try another credential after one that required the MFA, so the identity from the
+		// previous attempt must not be kept.
+		sess.Identity = ""
+
 		if err := auth.Evaluate(sess); err != nil {
 			return err
 		}
 
+		if err := sess.evaluateMFA(auth); err != nil {
+			if errors.Is(err, ErrMFARequired) {
+				snap.save(sess, StateMFA)
+			}
+
+			return err
+		}
+
 		if err := sess.register(); err != nil {
 			return err
 		}
@@ -374,6 +442,55 @@ func (s *Session) Auth(ctx gliderssh.Context, auth Auth) error {
 
 	snap.save(sess, StateFinished)
 
+	sess.track()
+
+	return nil
+}
+
+// AuthMFA finishes the authentication of a [Session] waiting for the MFA, validating the TOTP code of the ShellHub's
+// user mapped to the client's key before registering and connecting the session to the device.
+func (s *Session) AuthMFA(ctx gliderssh.Context, auth Auth, code string) error {
+	snap := getSnapshot(ctx)
+
+	sess, state := snap.retrieve()
+	if state != StateMFA {
+		return errors.New("invalid session state")
+	}
+
+	// NOTICE: The identity is evaluated again from the credential that asked for the MFA, as the client could have
+	// tried others in the meantime.
+	sess.Identity = ""
+
+	if err := auth.Evaluate(sess); err != nil {
+		return err
+	}
+
+	if sess.Identity == "" {
+		return ErrMFAIdentity
+	}
+
+	if err := sess.api.AuthMFACode(sess.Identity, code); err != nil {
+		return errors.Join(ErrMFACode, err)
+	}
+
+	if err := sess.register(); err != nil {
+		return err
+	}
+
+	snap.save(sess, StateRegistered)
+
+	if err := sess.connect(ctx, auth.Auth()); err != nil {
+		return err
+	}
+
+	if err := sess.authenticate(); err != nil {
+		return err
+	}
+
+	snap.save(sess, StateFinished)
+
+	sess.track()
+
 	return nil
 }
 
@@ -385,6 +502,10 @@ func (s *Session) Record(req *models.SessionRecorded, url string) error {
 }
 
 func (s *Session) KeepAlive() error {
+	// NOTICE: The session's record on the registry is renewed with its keep alive, so it doesn't expire while the
+	// session is active.
+	s.own()
+
 	if errs := s.api.KeepAliveSession(s.UID); len(errs) > 0 {
 		log.Error(errs[0])
 
@@ -435,9 +556,24 @@ func (s *Session) Announce(client gossh.Channel) error {
 	return nil
 }
 
+// Event registers an event what happened during the session on the API.
+func (s *Session) Event(kind string, data map[string]string) error {
+	return s.api.SessionEvent(s.UID, &models.SessionEvent{
+		Type: kind,
+		Data: data,
+	})
+}
+
+// EnforceLimitsOnce calls enforce only for the first time it is called on the session.
+func (s *Session) EnforceLimitsOnce(enforce func()) {
+	s.limitsOnce.Do(enforce)
+}
+
 // Finish terminate the session between Agent and Client, sending a request to Agent to closes it.
 func (s *Session) Finish() (err error) {
 	s.once.Do(func() {
+		s.untrack()
+
 		if s.AgentConn != nil {
 			request, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/ssh/close/%s", s.UID), nil)
 
@@ -448,7 +584,7 @@ func (s *Session) Finish() (err error) {
 			}
 		}
 
-		if errs := s.api.FinishSession(s.UID); len(errs) > 0 {
+		if errs := s.api.FinishSession(s.UID, s.DisconnectReason()); len(errs) > 0 {
 			log.WithError(errs[0]).
 				WithFields(log.Fields{"session": s.UID, "sshid": s.SSHID}).
 				Error("Error when trying to finish the session")
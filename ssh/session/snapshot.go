@@ -15,6 +15,7 @@ const (
 	StateCreated               // StateCreated represents a session that has been created but not yet registered with the API.
 	StateDialed                // StateDialed represents a session that has been connected to a device.
 	StateEvaluated             // StateEvaluated represents a evaluated session.
+	StateMFA                   // StateMFA represents a session waiting for the TOTP code of the ShellHub's user mapped to the client's key.
 	StateRegistered            // StateRegistered represents a session that has been registered with the API but not yet connected to an agent.
 	StateFinished              // StateFinished represents a session that has been completed.
 )
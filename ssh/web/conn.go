@@ -10,6 +10,9 @@ import (
 	"golang.org/x/net/websocket"
 )
 
+// maxMessageSize is the maximum size of a message read from the client, what limits the size of a file's chunk.
+const maxMessageSize = 1024 * 1024
+
 //go:generate mockery --name Socket --filename socket.go
 type Socket interface {
 	io.ReadWriteCloser
@@ -37,10 +40,32 @@ func (c *Conn) ReadMessage(message *Message) (int, error) {
 		return read, errors.Join(ErrConnReadMessageSocketRead, err)
 	}
 
+	payload := buffer[:read]
+
+	// NOTICE: A message bigger than the buffer, like a file's chunk, is read in parts until it's a complete JSON.
+	if incomplete(payload) {
+		payload = append([]byte(nil), payload...)
+		buffer = make([]byte, 32*1024)
+
+		for incomplete(payload) {
+			if len(payload) > maxMessageSize {
+				return 0, errors.Join(ErrConnReadMessageTooLarge)
+			}
+
+			n, err := c.Socket.Read(buffer)
+			if err != nil {
+				return read, errors.Join(ErrConnReadMessageSocketRead, err)
+			}
+
+			payload = append(payload, buffer[:n]...)
+			read += n
+		}
+	}
+
 	var data json.RawMessage
 	message.Data = &data
 
-	if err = json.Unmarshal(buffer[:read], &message); err != nil {
+	if err = json.Unmarshal(payload, &message); err != nil {
 		return 0, errors.Join(ErrConnReadMessageJSONInvalid)
 	}
 
@@ -61,6 +86,30 @@ func (c *Conn) ReadMessage(message *Message) (int, error) {
 		}
 
 		message.Data = dim
+	case messageKindSignature:
+		var signature []byte
+
+		if err = json.Unmarshal(data, &signature); err != nil {
+			return 0, errors.Join(ErrConnReadMessageJSONInvalid)
+		}
+
+		message.Data = signature
+	case messageKindPrompt:
+		var answers []string
+
+		if err = json.Unmarshal(data, &answers); err != nil {
+			return 0, errors.Join(ErrConnReadMessageJSONInvalid)
+		}
+
+		message.Data = answers
+	case messageKindUpload, messageKindDownload, messageKindChunk, messageKindCancel:
+		var transfer Transfer
+
+		if err = json.Unmarshal(data, &transfer); err != nil || transfer.ID == "" {
+			return 0, errors.Join(ErrConnReadMessageJSONInvalid)
+		}
+
+		message.Data = &transfer
 	default:
 		return 0, errors.Join(ErrConnReadMessageKindInvalid)
 	}
@@ -74,6 +123,15 @@ func (c *Conn) WriteMessage(message *Message) (int, error) {
 		return 0, errors.Join(ErrConnReadMessageJSONInvalid)
 	}
 
+	// NOTICE: Messages are sent as binary frames, so the client tells them apart from the terminal's output.
+	if socket, ok := c.Socket.(*websocket.Conn); ok {
+		if err := websocket.Message.Send(socket, buffer); err != nil {
+			return 0, errors.Join(ErrConnReadMessageSocketWrite, err)
+		}
+
+		return len(buffer), nil
+	}
+
 	wrote, err := c.Socket.Write(buffer)
 	if err != nil {
 		return wrote, errors.Join(ErrConnReadMessageSocketWrite, err)
@@ -82,6 +140,17 @@ func (c *Conn) WriteMessage(message *Message) (int, error) {
 	return wrote, nil
 }
 
+// incomplete checks if the data read is the beginning of a JSON that wasn't completely read yet.
+func incomplete(data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+
+	var syntax *json.SyntaxError
+
+	return errors.As(json.Unmarshal(data, new(json.RawMessage)), &syntax) && syntax.Offset == int64(len(data))
+}
+
 func (c *Conn) Read(buffer []byte) (int, error) {
 	return c.Socket.Read(buffer)
 }
@@ -1,6 +1,7 @@
 package web
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"testing"
@@ -171,3 +172,170 @@ func TestConnReadMessage_resize(t *testing.T) {
 		})
 	}
 }
+
+func TestConnReadMessage_signature(t *testing.T) {
+	socket := new(mocks.Socket)
+	conn := NewConn(socket)
+
+	type Expected struct {
+		message *Message
+		read    int
+		err     error
+	}
+
+	tests := []struct {
+		description   string
+		requiredMocks func()
+		expect        Expected
+	}{
+		{
+			description: "fail when the signature is not encoded as base64",
+			requiredMocks: func() {
+				buffer := make([]byte, 1024)
+
+				socket.On("Read", buffer).Return(23, nil).Run(func(args mock.Arguments) {
+					copy(args.Get(0).([]byte), `{"kind":3,"data":"$$$"}`)
+				}).Once()
+			},
+			expect: Expected{
+				message: func() *Message {
+					data := json.RawMessage(`"$$$"`)
+
+					return &Message{Kind: messageKindSignature, Data: &data}
+				}(),
+				read: 0,
+				err:  ErrConnReadMessageJSONInvalid,
+			},
+		},
+		{
+			description: "success to read the message",
+			requiredMocks: func() {
+				buffer := make([]byte, 1024)
+
+				socket.On("Read", buffer).Return(28, nil).Run(func(args mock.Arguments) {
+					b := args.Get(0).([]byte)
+
+					buf, _ := json.Marshal(Message{
+						Kind: messageKindSignature,
+						Data: []byte("signed"),
+					})
+
+					copy(b, buf)
+				}).Once()
+			},
+			expect: Expected{
+				message: &Message{
+					Kind: messageKindSignature,
+					Data: []byte("signed"),
+				},
+				read: 28,
+				err:  nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			test.requiredMocks()
+
+			var message Message
+			read, err := conn.ReadMessage(&message)
+
+			assert.Equal(t, test.expect.message, &message)
+			assert.Equal(t, test.expect.read, read)
+			assert.ErrorIs(t, err, test.expect.err)
+		})
+	}
+}
+
+func TestConnReadMessage_transfer(t *testing.T) {
+	socket := new(mocks.Socket)
+	conn := NewConn(socket)
+
+	type Expected struct {
+		message *Message
+		read    int
+		err     error
+	}
+
+	chunk, _ := json.Marshal(Message{
+		Kind: messageKindChunk,
+		Data: &Transfer{ID: "transfer", Data: bytes.Repeat([]byte("a"), 2048)},
+	})
+
+	tests := []struct {
+		description   string
+		requiredMocks func()
+		expect        Expected
+	}{
+		{
+			description: "fail when the transfer has no identifier",
+			requiredMocks: func() {
+				buffer := make([]byte, 1024)
+
+				socket.On("Read", buffer).Return(20, nil).Run(func(args mock.Arguments) {
+					copy(args.Get(0).([]byte), `{"kind":6,"data":{}}`)
+				}).Once()
+			},
+			expect: Expected{
+				message: func() *Message {
+					data := json.RawMessage(`{}`)
+
+					return &Message{Kind: messageKindChunk, Data: &data}
+				}(),
+				read: 0,
+				err:  ErrConnReadMessageJSONInvalid,
+			},
+		},
+		{
+			description: "success to read a message bigger than the buffer",
+			requiredMocks: func() {
+				socket.On("Read", mock.Anything).Return(1024, nil).Run(func(args mock.Arguments) {
+					copy(args.Get(0).([]byte), chunk[:1024])
+				}).Once()
+
+				socket.On("Read", mock.Anything).Return(len(chunk)-1024, nil).Run(func(args mock.Arguments) {
+					copy(args.Get(0).([]byte), chunk[1024:])
+				}).Once()
+			},
+			expect: Expected{
+				message: &Message{
+					Kind: messageKindChunk,
+					Data: &Transfer{ID: "transfer", Data: bytes.Repeat([]byte("a"), 2048)},
+				},
+				read: len(chunk),
+				err:  nil,
+			},
+		},
+		{
+			description: "fail when the message is too large",
+			requiredMocks: func() {
+				socket.On("Read", mock.Anything).Return(1024, nil).Run(func(args mock.Arguments) {
+					copy(args.Get(0).([]byte), chunk[:1024])
+				}).Once()
+
+				socket.On("Read", mock.Anything).Return(32*1024, nil).Run(func(args mock.Arguments) {
+					copy(args.Get(0).([]byte), bytes.Repeat([]byte("Y"), 32*1024))
+				})
+			},
+			expect: Expected{
+				message: new(Message),
+				read:    0,
+				err:     ErrConnReadMessageTooLarge,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			test.requiredMocks()
+
+			var message Message
+			read, err := conn.ReadMessage(&message)
+
+			assert.Equal(t, test.expect.message, &message)
+			assert.Equal(t, test.expect.read, read)
+			assert.ErrorIs(t, err, test.expect.err)
+		})
+	}
+}
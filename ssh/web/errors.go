@@ -18,9 +18,8 @@ var (
 	ErrEvaluatePublicKey       = fmt.Errorf("failed to evaluate the public key in the server")
 	ErrForbiddenPublicKey      = fmt.Errorf("failed to use the public key for this action")
 	ErrDataPublicKey           = fmt.Errorf("failed to parse the public key data")
-	ErrSignaturePublicKey      = fmt.Errorf("failed to decode the public key signature")
 	ErrVerifyPublicKey         = fmt.Errorf("failed to verify the public key")
-	ErrSignerPublicKey         = fmt.Errorf("failed to signer the public key")
+	ErrPromptAnswers           = fmt.Errorf("the number of answers doesn't match the questions")
 	ErrDialSSH                 = fmt.Errorf("failed to dial to connect to server")
 	ErrEnvIPAddress            = fmt.Errorf("failed to set the env virable of ip address from client")
 	ErrEnvWS                   = fmt.Errorf("failed to set the env virable of web socket from client")
@@ -40,20 +39,34 @@ var (
 	ErrConnReadMessageSocketWrite = errors.New("failed to write the message's data to socket")
 	ErrConnReadMessageJSONInvalid = errors.New("failed to parse the message from json")
 	ErrConnReadMessageKindInvalid = errors.New("this kind of message is invalid")
+	ErrConnReadMessageTooLarge    = errors.New("the message is too large")
 )
 
 var (
 	ErrWebSocketGetToken      = errors.New("failed to get the token from query")
 	ErrWebSocketGetDimensions = errors.New("failed to get terminal dimensions from query")
 	ErrWebSocketGetIP         = errors.New("failed to get IP from query")
+	ErrWebSocketGetOffset     = errors.New("failed to get the output's offset from query")
 )
 
-var ErrBridgeCredentialsNotFound = errors.New("failed to find the credentials")
+var (
+	ErrBridgeCredentialsNotFound = errors.New("failed to find the credentials")
+	ErrBridgeTerminalNotFound    = errors.New("failed to find the terminal to resume")
+)
 
 var (
 	ErrGetToken      = errors.New("token not found on request query")
+	ErrGetResume     = errors.New("resume not found on request query")
 	ErrGetIP         = errors.New("ip not found on request query")
 	ErrGetDimensions = errors.New("failed to get a terminal dimension")
+	ErrGetOffset     = errors.New("failed to get the output's offset")
+)
+
+var (
+	ErrTransferUnavailable = errors.New("file transfers are unavailable on this terminal")
+	ErrTransferExists      = errors.New("a transfer with this identifier already exists")
+	ErrTransferNotFound    = errors.New("failed to find the transfer")
+	ErrTransferDirectory   = errors.New("the path is a directory")
 )
 
 var ErrCreditialsNoPassword = errors.New("this creditials does not have a password defined")
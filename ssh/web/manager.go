@@ -1,42 +1,47 @@
 package web
 
 import (
-	"sync"
+	"context"
 	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/cache"
 )
 
+// managerPrefix is the prefix of the cache's keys where the credentials are stored.
+const managerPrefix = "web/credentials/"
+
 // manager is used to store credentials for a time period.
+//
+// The credentials are stored on a cache shared by every instance of the service, as the request that saves them and
+// the one that gets them can be received by different instances.
 type manager struct {
-	// ttl is the time that each credial live in the map.
-	ttl         time.Duration
-	credentials *sync.Map
+	// ttl is the time that each credial live in the cache.
+	ttl   time.Duration
+	cache cache.Cache
 }
 
 // newManager creates a new [Manager] to store the credentials for a time period.
-func newManager(ttl time.Duration) *manager {
+func newManager(cache cache.Cache, ttl time.Duration) *manager {
 	return &manager{
-		ttl:         ttl,
-		credentials: new(sync.Map),
+		ttl:   ttl,
+		cache: cache,
 	}
 }
 
 // save credentials for a time period. After this, the credentials are deleted.
-func (m *manager) save(id string, data *Credentials) {
-	m.credentials.Store(id, data)
-
-	go time.AfterFunc(m.ttl, func() {
-		m.credentials.Delete(id)
-	})
+func (m *manager) save(ctx context.Context, id string, data *Credentials) error {
+	return m.cache.Set(ctx, managerPrefix+id, data, m.ttl)
 }
 
-// get gets the credentials if it time period have not ended.
-func (m *manager) get(id string) (*Credentials, bool) {
-	l, ok := m.credentials.Load(id)
-	if !ok {
+// get gets the credentials if it time period have not ended. The credentials are deleted after got, so they can only
+// be used once.
+func (m *manager) get(ctx context.Context, id string) (*Credentials, bool) {
+	creds := new(Credentials)
+	if err := m.cache.Get(ctx, managerPrefix+id, creds); err != nil || *creds == (Credentials{}) {
 		return nil, false
 	}
 
-	v, ok := l.(*Credentials)
+	m.cache.Delete(ctx, managerPrefix+id) //nolint:errcheck
 
-	return v, ok
+	return creds, true
 }
@@ -1,12 +1,65 @@
 package web
 
 import (
+	"context"
+	"encoding/json"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// memoryCache is a [cache.Cache] kept in memory.
+type memoryCache struct {
+	mu     sync.Mutex
+	values sync.Map
+}
+
+func (c *memoryCache) Get(_ context.Context, key string, value interface{}) error {
+	data, ok := c.values.Load(key)
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal(data.([]byte), value)
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	c.values.Store(key, data)
+
+	time.AfterFunc(ttl, func() {
+		c.values.Delete(key)
+	})
+
+	return nil
+}
+
+func (c *memoryCache) Delete(_ context.Context, key string) error {
+	c.values.Delete(key)
+
+	return nil
+}
+
+func (c *memoryCache) Increment(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var count int64
+	if err := c.Get(ctx, key, &count); err != nil {
+		return 0, err
+	}
+
+	count++
+
+	return count, c.Set(ctx, key, count, ttl)
+}
+
 func TestManagerSave(t *testing.T) {
 	tests := []struct {
 		description string
@@ -18,13 +71,13 @@ func TestManagerSave(t *testing.T) {
 			description: "insert credential on manager and delete after 1 second",
 			id:          "foo",
 			waitFor:     1 * time.Second,
-			data:        nil,
+			data:        &Credentials{Device: "device", Username: "root", Password: "password"},
 		},
 		{
 			description: "insert credential on manager and delete after 2 seconds",
 			id:          "bar",
 			waitFor:     2 * time.Second,
-			data:        nil,
+			data:        &Credentials{Device: "device", Username: "root", Password: "password"},
 		},
 	}
 
@@ -34,11 +87,11 @@ func TestManagerSave(t *testing.T) {
 		t.Run(test.description, func(t *testing.T) {
 			t.Parallel()
 
-			manager := newManager(test.waitFor)
-			manager.save(test.id, nil)
+			manager := newManager(new(memoryCache), test.waitFor)
+			assert.NoError(t, manager.save(context.Background(), test.id, test.data))
 
 			assert.EventuallyWithT(t, func(tt *assert.CollectT) {
-				_, ok := manager.get(test.id)
+				_, ok := manager.get(context.Background(), test.id)
 				assert.False(tt, ok)
 
 				// NOTICE: we are waiting for two times the defined time, verifying each 10 Millisecond if the condition
@@ -48,3 +101,18 @@ func TestManagerSave(t *testing.T) {
 		})
 	}
 }
+
+func TestManagerGet(t *testing.T) {
+	manager := newManager(new(memoryCache), time.Minute)
+
+	data := &Credentials{Device: "device", Username: "root", Password: "password"}
+	assert.NoError(t, manager.save(context.Background(), "foo", data))
+
+	creds, ok := manager.get(context.Background(), "foo")
+	assert.True(t, ok)
+	assert.Equal(t, data, creds)
+
+	// NOTICE: The credentials can only be used once.
+	_, ok = manager.get(context.Background(), "foo")
+	assert.False(t, ok)
+}
@@ -8,8 +8,71 @@ const (
 	// messageKindResize is the identifier to a resize request message. This kind of message contains the number of
 	// columns and rows what the terminal should have.
 	messageKindResize
+	// messageKindSignature is the identifier to a signature message, used when the client authenticates with a private
+	// key held by it. The server sends a [Challenge] with the data to be signed, and the client replies, with a message
+	// of the same kind, the signature's blob encoded as base64.
+	messageKindSignature
+	// messageKindUpload is the identifier to a message sent by the client to start uploading a file, with the
+	// [Transfer]'s identifier, path and size. The server replies with a [messageKindProgress] message when the file is
+	// created, and the client sends its chunks through [messageKindChunk] messages.
+	messageKindUpload
+	// messageKindDownload is the identifier to a message sent by the client to start downloading a file, with the
+	// [Transfer]'s identifier and path. The server replies with a [messageKindProgress] message containing the file's
+	// size, and sends its chunks through [messageKindChunk] messages.
+	messageKindDownload
+	// messageKindChunk is the identifier to a message with a chunk of a file being transferred, sent by the client on
+	// uploads and by the server on downloads. The last chunk of a file is marked as done.
+	messageKindChunk
+	// messageKindProgress is the identifier to a message sent by the server with the number of bytes of a file already
+	// transferred, marked as done when an upload is complete.
+	messageKindProgress
+	// messageKindCancel is the identifier to a message sent by the client to cancel a transfer, or by the server, with
+	// the reason, when a transfer fails.
+	messageKindCancel
+	// messageKindPrompt is the identifier to a prompt message, used when the SSH server asks the client questions, like
+	// the MFA's code. The server sends a [Prompt], and the client replies, with a message of the same kind, its answers
+	// in the questions' order.
+	messageKindPrompt
 )
 
+// Challenge is the data sent to the client to be signed by its private key.
+type Challenge struct {
+	// Algorithm is the signature's algorithm, like "ssh-ed25519" or "rsa-sha2-512".
+	Algorithm string `json:"algorithm"`
+	// Data is the data to be signed, encoded as base64.
+	Data []byte `json:"data"`
+}
+
+// Prompt is the questions asked to the client by the SSH server.
+type Prompt struct {
+	// Instruction is the text shown to the client before the questions.
+	Instruction string `json:"instruction"`
+	// Questions are the questions to be answered.
+	Questions []string `json:"questions"`
+	// Echos indicates, for each question, if its answer can be shown while typed.
+	Echos []bool `json:"echos"`
+}
+
+// Transfer is the data of the messages about a file transfer, what are related by the transfer's identifier.
+type Transfer struct {
+	// ID is the transfer's identifier, chosen by the client.
+	ID string `json:"id"`
+	// Path is the file's path on the device, relative to the user's home directory.
+	Path string `json:"path,omitempty"`
+	// Size is the file's size, in bytes.
+	Size int64 `json:"size,omitempty"`
+	// Data is a chunk of the file, encoded as base64.
+	Data []byte `json:"data,omitempty"`
+	// Transferred is the number of bytes of the file already transferred.
+	Transferred int64 `json:"transferred,omitempty"`
+	// Done indicates that the file was completely transferred.
+	Done bool `json:"done,omitempty"`
+	// Error is the reason why the transfer failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Message is a message exchanged with the client. The client sends messages as text frames, while the server sends
+// them as binary frames, telling them apart from the terminal's output.
 type Message struct {
 	Kind messageKind `json:"kind"`
 	Data any         `json:"data"`
@@ -0,0 +1,143 @@
+// Package crypter encrypts data with a set of shared symmetric keys, letting the data encrypted by an instance of a
+// horizontally scaled service to be decrypted by any other.
+//
+// The keys are rotated adding a new key before the others, what makes it the one used to encrypt, and removing the old
+// ones when the data encrypted with them isn't needed anymore.
+package crypter
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+// KeySize is the size, in bytes, of each key.
+const KeySize = 32
+
+// idSize is the size, in bytes, of the identifier of the key used to encrypt, prefixed to the encrypted data.
+const idSize = 4
+
+var (
+	ErrNoKeys        = errors.New("no key to encrypt")
+	ErrKeySize       = errors.New("the key must have 32 bytes")
+	ErrKeyEncoding   = errors.New("the key must be encoded as base64")
+	ErrUnknownKey    = errors.New("the data was encrypted with an unknown key")
+	ErrInvalidData   = errors.New("the encrypted data is invalid")
+	ErrDecryptFailed = errors.New("failed to decrypt the data")
+)
+
+type key struct {
+	id   []byte
+	aead cipher.AEAD
+}
+
+// Crypter encrypts data with its first key and decrypts the data encrypted with any of them.
+type Crypter struct {
+	keys []key
+}
+
+// New creates a [Crypter] with the keys, where the first one is used to encrypt.
+func New(keys ...[]byte) (*Crypter, error) {
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	crypter := &Crypter{keys: make([]key, 0, len(keys))}
+	for _, k := range keys {
+		if len(k) != KeySize {
+			return nil, ErrKeySize
+		}
+
+		block, err := aes.NewCipher(k)
+		if err != nil {
+			return nil, err
+		}
+
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		sum := sha256.Sum256(k)
+		crypter.keys = append(crypter.keys, key{id: sum[:idSize], aead: aead})
+	}
+
+	return crypter, nil
+}
+
+// Parse creates a [Crypter] with the keys encoded as base64 and separated by commas, where the first one is used to
+// encrypt.
+func Parse(value string) (*Crypter, error) {
+	keys := make([][]byte, 0)
+	for _, encoded := range strings.Split(value, ",") {
+		if encoded = strings.TrimSpace(encoded); encoded == "" {
+			continue
+		}
+
+		k, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, ErrKeyEncoding
+		}
+
+		keys = append(keys, k)
+	}
+
+	return New(keys...)
+}
+
+// Generate creates a [Crypter] with a random key, only known by this instance.
+func Generate() (*Crypter, error) {
+	k := make([]byte, KeySize)
+	if _, err := rand.Read(k); err != nil {
+		return nil, err
+	}
+
+	return New(k)
+}
+
+// Encrypt encrypts the data with the first key, encoding it as base64.
+func (c *Crypter) Encrypt(data []byte) (string, error) {
+	k := c.keys[0]
+
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := append(append([]byte{}, k.id...), nonce...)
+	sealed = k.aead.Seal(sealed, nonce, data, k.id)
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt decrypts the data encrypted by any of the keys.
+func (c *Crypter) Decrypt(data string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(data)
+	if err != nil || len(sealed) < idSize {
+		return nil, ErrInvalidData
+	}
+
+	for _, k := range c.keys {
+		if string(k.id) != string(sealed[:idSize]) {
+			continue
+		}
+
+		sealed = sealed[idSize:]
+		if len(sealed) < k.aead.NonceSize() {
+			return nil, ErrInvalidData
+		}
+
+		opened, err := k.aead.Open(nil, sealed[:k.aead.NonceSize()], sealed[k.aead.NonceSize():], k.id)
+		if err != nil {
+			return nil, ErrDecryptFailed
+		}
+
+		return opened, nil
+	}
+
+	return nil, ErrUnknownKey
+}
@@ -0,0 +1,74 @@
+package crypter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCrypter(t *testing.T) {
+	old := bytes.Repeat([]byte{1}, KeySize)
+	current := bytes.Repeat([]byte{2}, KeySize)
+
+	before, err := New(old)
+	require.NoError(t, err)
+
+	after, err := Parse(base64.StdEncoding.EncodeToString(current) + "," + base64.StdEncoding.EncodeToString(old))
+	require.NoError(t, err)
+
+	t.Run("decrypts the data encrypted by another instance", func(t *testing.T) {
+		encrypted, err := before.Encrypt([]byte("password"))
+		require.NoError(t, err)
+
+		other, err := New(old)
+		require.NoError(t, err)
+
+		decrypted, err := other.Decrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("password"), decrypted)
+	})
+
+	t.Run("decrypts the data encrypted before a rotation", func(t *testing.T) {
+		encrypted, err := before.Encrypt([]byte("password"))
+		require.NoError(t, err)
+
+		decrypted, err := after.Decrypt(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("password"), decrypted)
+	})
+
+	t.Run("fails to decrypt the data encrypted with an unknown key", func(t *testing.T) {
+		encrypted, err := after.Encrypt([]byte("password"))
+		require.NoError(t, err)
+
+		_, err = before.Decrypt(encrypted)
+		assert.ErrorIs(t, err, ErrUnknownKey)
+	})
+
+	t.Run("fails to decrypt tampered data", func(t *testing.T) {
+		encrypted, err := before.Encrypt([]byte("password"))
+		require.NoError(t, err)
+
+		sealed, err := base64.StdEncoding.DecodeString(encrypted)
+		require.NoError(t, err)
+
+		sealed[len(sealed)-1] ^= 1
+
+		_, err = before.Decrypt(base64.StdEncoding.EncodeToString(sealed))
+		assert.ErrorIs(t, err, ErrDecryptFailed)
+	})
+
+	t.Run("fails to parse invalid keys", func(t *testing.T) {
+		_, err := Parse("")
+		assert.ErrorIs(t, err, ErrNoKeys)
+
+		_, err = Parse("not base64")
+		assert.ErrorIs(t, err, ErrKeyEncoding)
+
+		_, err = Parse(base64.StdEncoding.EncodeToString([]byte("short")))
+		assert.ErrorIs(t, err, ErrKeySize)
+	})
+}
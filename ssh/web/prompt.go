@@ -0,0 +1,46 @@
+package web
+
+// prompter relays the questions of the SSH server's keyboard-interactive authentication, like the MFA's code, to the
+// client on the connection, as [messageKindPrompt] messages.
+type prompter struct {
+	conn *Conn
+}
+
+// newPrompter creates a [prompter] asking the questions to the client on the connection.
+func newPrompter(conn *Conn) *prompter {
+	return &prompter{conn: conn}
+}
+
+// prompt is a [ssh.KeyboardInteractiveChallenge] answered by the client.
+func (p *prompter) prompt(_, instruction string, questions []string, echos []bool) ([]string, error) {
+	// NOTICE: The server can send a challenge without questions, only to show its instruction.
+	if len(questions) == 0 {
+		return []string{}, nil
+	}
+
+	if _, err := p.conn.WriteMessage(&Message{
+		Kind: messageKindPrompt,
+		Data: Prompt{Instruction: instruction, Questions: questions, Echos: echos},
+	}); err != nil {
+		return nil, err
+	}
+
+	for {
+		var message Message
+		if _, err := p.conn.ReadMessage(&message); err != nil {
+			return nil, err
+		}
+
+		// NOTICE: The messages sent by the client before the session is ready, like its input, are dropped.
+		if message.Kind != messageKindPrompt {
+			continue
+		}
+
+		answers := message.Data.([]string)
+		if len(answers) != len(questions) {
+			return nil, ErrPromptAnswers
+		}
+
+		return answers, nil
+	}
+}
@@ -0,0 +1,84 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// responder is a [Socket] that acts as the browser, answering the prompts with its answers.
+type responder struct {
+	answers []string
+	// prompts are the prompts received from the server.
+	prompts []Prompt
+	// replies are the messages to be read by the server.
+	replies [][]byte
+}
+
+func (r *responder) Read(b []byte) (int, error) {
+	if len(r.replies) == 0 {
+		return 0, io.EOF
+	}
+
+	reply := r.replies[0]
+	r.replies = r.replies[1:]
+
+	return copy(b, reply), nil
+}
+
+func (r *responder) Write(b []byte) (int, error) {
+	var message struct {
+		Kind messageKind `json:"kind"`
+		Data Prompt      `json:"data"`
+	}
+
+	if err := json.Unmarshal(b, &message); err != nil {
+		return 0, err
+	}
+
+	r.prompts = append(r.prompts, message.Data)
+
+	// NOTICE: The input typed before the session is ready comes before the answers.
+	input, _ := json.Marshal(Message{Kind: messageKindInput, Data: []byte("ls")})
+	reply, _ := json.Marshal(Message{Kind: messageKindPrompt, Data: r.answers})
+
+	r.replies = append(r.replies, input, reply)
+
+	return len(b), nil
+}
+
+func (r *responder) Close() error {
+	return nil
+}
+
+func TestPrompter(t *testing.T) {
+	t.Run("answers the questions through the client", func(t *testing.T) {
+		socket := &responder{answers: []string{"123456"}}
+
+		answers, err := newPrompter(NewConn(socket)).prompt("", "MFA required", []string{"Verification code: "}, []bool{false})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"123456"}, answers)
+		assert.Equal(t, []Prompt{{Instruction: "MFA required", Questions: []string{"Verification code: "}, Echos: []bool{false}}}, socket.prompts)
+	})
+
+	t.Run("doesn't ask the client when there are no questions", func(t *testing.T) {
+		socket := new(responder)
+
+		answers, err := newPrompter(NewConn(socket)).prompt("", "welcome", nil, nil)
+		require.NoError(t, err)
+
+		assert.Empty(t, answers)
+		assert.Empty(t, socket.prompts)
+	})
+
+	t.Run("fails when the client doesn't answer every question", func(t *testing.T) {
+		socket := &responder{answers: []string{}}
+
+		_, err := newPrompter(NewConn(socket)).prompt("", "MFA required", []string{"Verification code: "}, []bool{false})
+		assert.ErrorIs(t, err, ErrPromptAnswers)
+	})
+}
@@ -2,15 +2,13 @@ package web
 
 import (
 	"bytes"
-	"crypto/rsa"
-	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
 	"unicode/utf8"
 
+	"github.com/pkg/sftp"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
-	"github.com/shellhub-io/shellhub/ssh/pkg/magickey"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/crypto/ssh"
 )
@@ -30,11 +28,15 @@ func (b *BannerError) Error() string {
 }
 
 // getAuth gets the authentication methods from credentials.
-func getAuth(creds *Credentials, magicKey *rsa.PrivateKey) ([]ssh.AuthMethod, error) {
+func getAuth(conn *Conn, creds *Credentials) ([]ssh.AuthMethod, error) {
 	if creds.isPassword() {
 		return []ssh.AuthMethod{ssh.Password(creds.Password)}, nil
 	}
 
+	if !creds.isPublicKey() {
+		return nil, ErrWebData
+	}
+
 	cli := internalclient.NewClient()
 
 	// Trys to get a device from the API.
@@ -64,27 +66,17 @@ func getAuth(creds *Credentials, magicKey *rsa.PrivateKey) ([]ssh.AuthMethod, er
 		return nil, ErrDataPublicKey
 	}
 
-	digest, err := base64.StdEncoding.DecodeString(creds.Signature)
-	if err != nil {
-		return nil, ErrSignaturePublicKey
-	}
-
-	if err := pubKey.Verify([]byte(creds.Username), &ssh.Signature{ //nolint: exhaustruct
-		Format: pubKey.Type(),
-		Blob:   digest,
-	}); err != nil {
-		return nil, ErrVerifyPublicKey
-	}
-
-	signer, err := ssh.NewSignerFromKey(magicKey)
-	if err != nil {
-		return nil, ErrSignerPublicKey
-	}
-
-	return []ssh.AuthMethod{ssh.PublicKeys(signer)}, nil
+	// NOTICE: The client signs the SSH authentication itself, receiving the data to be signed through the WebSocket,
+	// and answers the MFA's code, when the device's namespace requires it, through the WebSocket too.
+	return []ssh.AuthMethod{
+		ssh.PublicKeysCallback(func() ([]ssh.Signer, error) {
+			return []ssh.Signer{newSigner(conn, pubKey)}, nil
+		}),
+		ssh.KeyboardInteractive(newPrompter(conn).prompt),
+	}, nil
 }
 
-func newSession(conn *Conn, creds *Credentials, dim Dimensions, info Info) error {
+func newSession(address string, conn *Conn, creds *Credentials, dim Dimensions, info Info, terminals *terminals) error {
 	log.WithFields(log.Fields{
 		"user":   creds.Username,
 		"device": creds.Device,
@@ -100,12 +92,12 @@ func newSession(conn *Conn, creds *Credentials, dim Dimensions, info Info) error
 	}).Info("handling web client request end")
 
 	user := fmt.Sprintf("%s@%s", creds.Username, creds.Device)
-	auth, err := getAuth(creds, magickey.GetRerefence())
+	auth, err := getAuth(conn, creds)
 	if err != nil {
 		return ErrGetAuth
 	}
 
-	connection, err := ssh.Dial("tcp", "localhost:2222", &ssh.ClientConfig{ //nolint: exhaustruct
+	connection, err := ssh.Dial("tcp", address, &ssh.ClientConfig{ //nolint: exhaustruct
 		User:            user,
 		Auth:            auth,
 		HostKeyCallback: ssh.InsecureIgnoreHostKey(), //nolint:gosec
@@ -173,67 +165,38 @@ func newSession(conn *Conn, creds *Credentials, dim Dimensions, info Info) error
 		return ErrShell
 	}
 
-	go func() {
-		defer agent.Close()
-
-		for {
-			var message Message
-
-			if _, err := conn.ReadMessage(&message); err != nil {
-				if errors.Is(err, io.EOF) {
-					return
-				}
-
-				log.WithFields(
-					log.Fields{
-						"user":   creds.Username,
-						"device": creds.Device,
-						"ip":     info.IP,
-					}).WithError(err).Error("failed to read the message from the client")
+	// NOTICE: Files are transferred through the SFTP subsystem opened on the same SSH connection.
+	files := newFilesystem(func() (*sftp.Client, error) {
+		return sftp.NewClient(connection)
+	})
 
-				return
-			}
+	defer files.close()
 
-			switch message.Kind {
-			case messageKindInput:
-				buffer := message.Data.([]byte)
+	// NOTICE: The terminal outlives the client's connection, for a grace period, so the client can resume it.
+	term := newTerminal(agent, stdin, files, terminals.grace)
 
-				if _, err := stdin.Write(buffer); err != nil {
-					log.WithError(err).Error("failed to write the message data on the SSH session")
+	terminals.register(creds.Resume, term)
+	defer terminals.unregister(creds.Resume)
 
-					return
-				}
-			case messageKindResize:
-				dim := message.Data.(Dimensions)
-
-				if err := agent.WindowChange(dim.Rows, dim.Cols); err != nil {
-					log.WithFields(
-						log.Fields{
-							"user":   creds.Username,
-							"device": creds.Device,
-							"ip":     info.IP,
-							"cols":   dim.Cols,
-							"rows":   dim.Rows,
-						},
-					).WithError(err).Error("failed to change the seze of window for terminal session")
-
-					return
-				}
-			}
-		}
-	}()
+	go redirToWs(stdout, term) // nolint:errcheck
+	go io.Copy(term, stderr)   //nolint:errcheck
 
-	go redirToWs(stdout, conn) // nolint:errcheck
-	go io.Copy(conn, stderr)   //nolint:errcheck
+	go term.attach(conn, dim, 0, log.WithFields(log.Fields{
+		"user":   creds.Username,
+		"device": creds.Device,
+		"ip":     info.IP,
+	}))
 
 	if err := agent.Wait(); err != nil {
 		log.WithError(err).Warning("client remote command returned a error")
 	}
 
+	term.close()
+
 	return nil
 }
 
-func redirToWs(rd io.Reader, ws io.ReadWriter) error {
+func redirToWs(rd io.Reader, ws io.Writer) error {
 	var buf [32 * 1024]byte
 	var start, end, buflen int
 
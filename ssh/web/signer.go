@@ -0,0 +1,67 @@
+package web
+
+import (
+	"io"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// signer is a [ssh.AlgorithmSigner] whose private key is held by the client on the browser. The data to be signed is
+// sent to the client as a [Challenge], and the client replies with its signature, both through [messageKindSignature]
+// messages.
+type signer struct {
+	conn *Conn
+	key  ssh.PublicKey
+}
+
+var _ ssh.AlgorithmSigner = new(signer)
+
+// newSigner creates a [ssh.Signer] for the public key, whose private key is held by the client on the connection.
+func newSigner(conn *Conn, key ssh.PublicKey) *signer {
+	return &signer{conn: conn, key: key}
+}
+
+func (s *signer) PublicKey() ssh.PublicKey {
+	return s.key
+}
+
+func (s *signer) Sign(rand io.Reader, data []byte) (*ssh.Signature, error) {
+	return s.SignWithAlgorithm(rand, data, "")
+}
+
+func (s *signer) SignWithAlgorithm(_ io.Reader, data []byte, algorithm string) (*ssh.Signature, error) {
+	if algorithm == "" {
+		algorithm = s.key.Type()
+	}
+
+	if _, err := s.conn.WriteMessage(&Message{
+		Kind: messageKindSignature,
+		Data: Challenge{Algorithm: algorithm, Data: data},
+	}); err != nil {
+		return nil, err
+	}
+
+	for {
+		var message Message
+		if _, err := s.conn.ReadMessage(&message); err != nil {
+			return nil, err
+		}
+
+		// NOTICE: The messages sent by the client before the session is ready, like its input, are dropped.
+		if message.Kind != messageKindSignature {
+			continue
+		}
+
+		signature := &ssh.Signature{
+			Format: algorithm,
+			Blob:   message.Data.([]byte),
+		}
+
+		// NOTICE: An invalid signature is refused here, avoiding to count it as a failed authentication attempt.
+		if err := s.key.Verify(data, signature); err != nil {
+			return nil, ErrVerifyPublicKey
+		}
+
+		return signature, nil
+	}
+}
@@ -0,0 +1,105 @@
+package web
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// client is a [Socket] that acts as the browser, signing the challenges with its private key.
+type client struct {
+	signer ssh.Signer
+	// tamper makes the client to reply an invalid signature.
+	tamper bool
+	// closed makes the client to close the connection instead of replying.
+	closed bool
+	// replies are the messages to be read by the server.
+	replies [][]byte
+}
+
+func (c *client) Read(b []byte) (int, error) {
+	if len(c.replies) == 0 {
+		return 0, io.EOF
+	}
+
+	reply := c.replies[0]
+	c.replies = c.replies[1:]
+
+	return copy(b, reply), nil
+}
+
+func (c *client) Write(b []byte) (int, error) {
+	var message struct {
+		Kind messageKind `json:"kind"`
+		Data Challenge   `json:"data"`
+	}
+
+	if err := json.Unmarshal(b, &message); err != nil {
+		return 0, err
+	}
+
+	if c.closed {
+		return len(b), nil
+	}
+
+	signature, err := c.signer.Sign(rand.Reader, message.Data.Data)
+	if err != nil {
+		return 0, err
+	}
+
+	if c.tamper {
+		signature.Blob[0] ^= 1
+	}
+
+	// NOTICE: The input typed before the session is ready comes before the signature.
+	input, _ := json.Marshal(Message{Kind: messageKindInput, Data: []byte("ls")})
+	reply, _ := json.Marshal(Message{Kind: messageKindSignature, Data: signature.Blob})
+
+	c.replies = append(c.replies, input, reply)
+
+	return len(b), nil
+}
+
+func (c *client) Close() error {
+	return nil
+}
+
+func TestSigner(t *testing.T) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	browser, err := ssh.NewSignerFromKey(key)
+	require.NoError(t, err)
+
+	data := []byte("session")
+
+	t.Run("signs the data through the client", func(t *testing.T) {
+		signer := newSigner(NewConn(&client{signer: browser}), browser.PublicKey())
+
+		signature, err := signer.Sign(rand.Reader, data)
+		require.NoError(t, err)
+
+		assert.Equal(t, ssh.KeyAlgoED25519, signature.Format)
+		assert.NoError(t, browser.PublicKey().Verify(data, signature))
+	})
+
+	t.Run("fails when the client's signature is invalid", func(t *testing.T) {
+		signer := newSigner(NewConn(&client{signer: browser, tamper: true}), browser.PublicKey())
+
+		_, err := signer.Sign(rand.Reader, data)
+		assert.ErrorIs(t, err, ErrVerifyPublicKey)
+	})
+
+	t.Run("fails when the client closes the connection", func(t *testing.T) {
+		signer := newSigner(NewConn(&client{signer: browser, closed: true}), browser.PublicKey())
+
+		_, err := signer.Sign(rand.Reader, data)
+		assert.ErrorIs(t, err, ErrConnReadMessageSocketRead)
+	})
+}
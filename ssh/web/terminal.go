@@ -0,0 +1,288 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	log "github.com/sirupsen/logrus"
+)
+
+// terminalBufferSize is the number of bytes of the recent output replayed to a client when it resumes a terminal.
+const terminalBufferSize = 64 * 1024
+
+// terminalsPrefix is the prefix of the cache's keys where the terminals' records are stored.
+const terminalsPrefix = "web/terminals/"
+
+// terminalSession is the SSH session of a terminal.
+type terminalSession interface {
+	WindowChange(h, w int) error
+	Close() error
+}
+
+// terminal is a web terminal whose SSH session outlives the client's WebSocket for a grace period, letting the client
+// to resume it, from any instance, receiving the recent output it missed.
+//
+// The SSH session and the recent output live on the memory of the instance that opened the terminal, where the other
+// instances proxy the resumed WebSockets, so the terminal is lost when that instance stops.
+type terminal struct {
+	session terminalSession
+	stdin   io.Writer
+	files   *filesystem
+	grace   time.Duration
+
+	mu sync.Mutex
+	// conn is the client's connection attached to the terminal, what is nil when the client is detached.
+	conn *Conn
+	// buffer is the recent output of the terminal.
+	buffer []byte
+	// written is the number of bytes of the whole terminal's output, what the buffer is the end of.
+	written uint64
+	// timer closes the terminal when the client doesn't resume it during the grace period.
+	timer  *time.Timer
+	closed bool
+}
+
+func newTerminal(session terminalSession, stdin io.Writer, files *filesystem, grace time.Duration) *terminal {
+	return &terminal{
+		session: session,
+		stdin:   stdin,
+		files:   files,
+		grace:   grace,
+	}
+}
+
+// Write writes the output to the attached client, keeping it on the recent output.
+func (t *terminal) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.written += uint64(len(p))
+
+	t.buffer = append(t.buffer, p...)
+	if len(t.buffer) > terminalBufferSize {
+		t.buffer = t.buffer[len(t.buffer)-terminalBufferSize:]
+	}
+
+	if t.conn != nil {
+		// NOTICE: When the output cannot be written, the client is detached by its reading loop.
+		t.conn.Write(p) //nolint:errcheck
+	}
+
+	return len(p), nil
+}
+
+// attach attaches the client's connection to the terminal, replacing the one attached before, if any, and replaying
+// the recent output the client didn't receive, after the offset of bytes it already received. It blocks, forwarding
+// the client's messages to the SSH session, until the client is detached.
+func (t *terminal) attach(conn *Conn, dim Dimensions, offset uint64, logger *log.Entry) {
+	t.mu.Lock()
+	if t.closed {
+		t.mu.Unlock()
+
+		return
+	}
+
+	if t.conn != nil {
+		t.conn.Close()
+	}
+
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+
+	t.conn = conn
+
+	if replay := t.replay(offset); len(replay) > 0 {
+		conn.Write(replay) //nolint:errcheck
+	}
+
+	t.mu.Unlock()
+
+	defer t.detach(conn)
+
+	transfers := newTransfers(conn, t.files, logger)
+	defer transfers.close()
+
+	if err := t.session.WindowChange(dim.Rows, dim.Cols); err != nil {
+		logger.WithError(err).Warning("failed to change the size of window for the resumed terminal session")
+	}
+
+	for {
+		var message Message
+
+		if _, err := conn.ReadMessage(&message); err != nil {
+			if !errors.Is(err, io.EOF) {
+				logger.WithError(err).Error("failed to read the message from the client")
+			}
+
+			return
+		}
+
+		switch message.Kind {
+		case messageKindInput:
+			buffer := message.Data.([]byte)
+
+			if _, err := t.stdin.Write(buffer); err != nil {
+				logger.WithError(err).Error("failed to write the message data on the SSH session")
+
+				return
+			}
+		case messageKindResize:
+			dim := message.Data.(Dimensions)
+
+			if err := t.session.WindowChange(dim.Rows, dim.Cols); err != nil {
+				logger.WithFields(log.Fields{
+					"cols": dim.Cols,
+					"rows": dim.Rows,
+				}).WithError(err).Error("failed to change the seze of window for terminal session")
+
+				return
+			}
+		case messageKindUpload, messageKindDownload, messageKindChunk, messageKindCancel:
+			transfers.handle(message.Kind, message.Data.(*Transfer))
+		}
+	}
+}
+
+// replay returns the recent output after the offset of bytes the client already received. When the client missed
+// more output than the recent one, the whole recent output is returned, starting on its first whole character.
+func (t *terminal) replay(offset uint64) []byte {
+	if offset >= t.written {
+		return nil
+	}
+
+	start := t.written - uint64(len(t.buffer))
+	if offset > start {
+		return t.buffer[offset-start:]
+	}
+
+	// NOTICE: The replay cannot start in the middle of a character.
+	replay := t.buffer
+	for len(replay) > 0 && !utf8.RuneStart(replay[0]) {
+		replay = replay[1:]
+	}
+
+	return replay
+}
+
+// detach detaches the client's connection from the terminal, closing the SSH session when the client doesn't resume
+// it during the grace period.
+func (t *terminal) detach(conn *Conn) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// NOTICE: The connection was already replaced by a resumed one.
+	if t.conn != conn {
+		return
+	}
+
+	t.conn.Close()
+	t.conn = nil
+
+	if t.closed {
+		return
+	}
+
+	t.timer = time.AfterFunc(t.grace, func() {
+		t.session.Close()
+	})
+}
+
+// close closes the client's connection attached to the terminal, after its SSH session has ended.
+func (t *terminal) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.closed = true
+
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+
+	if t.conn != nil {
+		t.conn.Close()
+	}
+}
+
+// terminalRecord is the record, shared among the instances, of the instance where a terminal is.
+type terminalRecord struct {
+	Instance string
+}
+
+// terminals are the terminals of this instance, shared with the other ones through a cache, where each terminal is
+// known by its resume identifier.
+type terminals struct {
+	cache    cache.Cache
+	instance string
+	grace    time.Duration
+	local    sync.Map
+}
+
+func newTerminals(cache cache.Cache, instance string, grace time.Duration) *terminals {
+	return &terminals{
+		cache:    cache,
+		instance: instance,
+		grace:    grace,
+	}
+}
+
+// register registers the terminal by its resume identifier, recording it on the cache until it is unregistered.
+func (ts *terminals) register(id string, term *terminal) {
+	ts.local.Store(id, term)
+
+	record := func() {
+		// NOTICE: The record outlasts the grace period, so it isn't lost before the terminal is closed.
+		if err := ts.cache.Set(context.Background(), terminalsPrefix+id, &terminalRecord{Instance: ts.instance}, 2*ts.grace+time.Minute); err != nil {
+			log.WithError(err).WithField("id", id).Error("failed to record the web terminal")
+		}
+	}
+
+	record()
+
+	go func() {
+		ticker := time.NewTicker(ts.grace/2 + 30*time.Second)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			if _, ok := ts.local.Load(id); !ok {
+				return
+			}
+
+			record()
+		}
+	}()
+}
+
+// unregister removes the terminal, and its record, identified by the resume identifier.
+func (ts *terminals) unregister(id string) {
+	ts.local.Delete(id)
+
+	if err := ts.cache.Delete(context.Background(), terminalsPrefix+id); err != nil {
+		log.WithError(err).WithField("id", id).Error("failed to remove the web terminal's record")
+	}
+}
+
+// get returns the terminal of this instance identified by the resume identifier.
+func (ts *terminals) get(id string) (*terminal, bool) {
+	term, ok := ts.local.Load(id)
+	if !ok {
+		return nil, false
+	}
+
+	return term.(*terminal), true
+}
+
+// locate returns the instance, other than this one, where the terminal identified by the resume identifier is.
+func (ts *terminals) locate(ctx context.Context, id string) (string, bool) {
+	record := new(terminalRecord)
+	if err := ts.cache.Get(ctx, terminalsPrefix+id, record); err != nil || record.Instance == "" || record.Instance == ts.instance {
+		return "", false
+	}
+
+	return record.Instance, true
+}
@@ -0,0 +1,214 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// session is a [terminalSession] that records its window's changes and whether it was closed.
+type session struct {
+	mu      sync.Mutex
+	windows []Dimensions
+	closed  bool
+}
+
+func (s *session) WindowChange(h, w int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.windows = append(s.windows, Dimensions{Cols: w, Rows: h})
+
+	return nil
+}
+
+func (s *session) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+
+	return nil
+}
+
+func (s *session) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.closed
+}
+
+// input is the session's stdin, safe to be read while the terminal writes on it.
+type input struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (i *input) Write(p []byte) (int, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.buffer.Write(p)
+}
+
+func (i *input) String() string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.buffer.String()
+}
+
+// browser attaches a client to the terminal, that already received the offset of bytes of its output, returning its
+// side of the connection and a channel closed when the client is detached.
+func browser(t *testing.T, term *terminal, dim Dimensions, offset uint64) (net.Conn, chan struct{}) {
+	t.Helper()
+
+	server, client := net.Pipe()
+	t.Cleanup(func() {
+		client.Close()
+	})
+
+	detached := make(chan struct{})
+	go func() {
+		term.attach(NewConn(server), dim, offset, log.NewEntry(log.StandardLogger()))
+		close(detached)
+	}()
+
+	return client, detached
+}
+
+func TestTerminal(t *testing.T) {
+	t.Run("replays the recent output when the client resumes it", func(t *testing.T) {
+		upstream := new(session)
+		stdin := new(input)
+		term := newTerminal(upstream, stdin, nil, time.Minute)
+
+		_, err := term.Write([]byte("$ ls\r\n"))
+		require.NoError(t, err)
+
+		client, _ := browser(t, term, Dimensions{Cols: 80, Rows: 24}, 0)
+
+		replay := make([]byte, len("$ ls\r\n"))
+		_, err = io.ReadFull(client, replay)
+		require.NoError(t, err)
+		assert.Equal(t, "$ ls\r\n", string(replay))
+
+		go term.Write([]byte("file\r\n")) //nolint:errcheck
+
+		output := make([]byte, len("file\r\n"))
+		_, err = io.ReadFull(client, output)
+		require.NoError(t, err)
+		assert.Equal(t, "file\r\n", string(output))
+
+		input, _ := json.Marshal(Message{Kind: messageKindInput, Data: []byte("pwd\n")})
+		_, err = client.Write(input)
+		require.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			return stdin.String() == "pwd\n"
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("replays only the output after the client's offset", func(t *testing.T) {
+		term := newTerminal(new(session), io.Discard, nil, time.Minute)
+
+		_, err := term.Write([]byte("$ ls\r\n"))
+		require.NoError(t, err)
+		_, err = term.Write([]byte("file\r\n"))
+		require.NoError(t, err)
+
+		client, _ := browser(t, term, Dimensions{Cols: 80, Rows: 24}, uint64(len("$ ls\r\n")))
+
+		replay := make([]byte, len("file\r\n"))
+		_, err = io.ReadFull(client, replay)
+		require.NoError(t, err)
+		assert.Equal(t, "file\r\n", string(replay))
+	})
+
+	t.Run("replays the whole recent output when the client missed more than it", func(t *testing.T) {
+		term := newTerminal(new(session), io.Discard, nil, time.Minute)
+
+		_, err := term.Write(bytes.Repeat([]byte("a"), terminalBufferSize))
+		require.NoError(t, err)
+		_, err = term.Write([]byte("b"))
+		require.NoError(t, err)
+
+		assert.Len(t, term.replay(0), terminalBufferSize)
+		assert.Equal(t, []byte("b"), term.replay(terminalBufferSize))
+		assert.Empty(t, term.replay(terminalBufferSize+1))
+	})
+
+	t.Run("keeps the session during the grace period", func(t *testing.T) {
+		upstream := new(session)
+		term := newTerminal(upstream, io.Discard, nil, 100*time.Millisecond)
+
+		client, detached := browser(t, term, Dimensions{Cols: 80, Rows: 24}, 0)
+		client.Close()
+		<-detached
+
+		// NOTICE: The client resumes the terminal before the grace period ends.
+		resumed, _ := browser(t, term, Dimensions{Cols: 100, Rows: 50}, 0)
+		defer resumed.Close()
+
+		time.Sleep(200 * time.Millisecond)
+		assert.False(t, upstream.isClosed())
+
+		upstream.mu.Lock()
+		assert.Equal(t, []Dimensions{{Cols: 80, Rows: 24}, {Cols: 100, Rows: 50}}, upstream.windows)
+		upstream.mu.Unlock()
+	})
+
+	t.Run("closes the session when the grace period ends", func(t *testing.T) {
+		upstream := new(session)
+		term := newTerminal(upstream, io.Discard, nil, 10*time.Millisecond)
+
+		client, detached := browser(t, term, Dimensions{Cols: 80, Rows: 24}, 0)
+		client.Close()
+		<-detached
+
+		assert.Eventually(t, upstream.isClosed, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("keeps only the most recent output", func(t *testing.T) {
+		term := newTerminal(new(session), io.Discard, nil, time.Minute)
+
+		_, err := term.Write(bytes.Repeat([]byte("a"), terminalBufferSize))
+		require.NoError(t, err)
+		_, err = term.Write([]byte("b"))
+		require.NoError(t, err)
+
+		assert.Len(t, term.buffer, terminalBufferSize)
+		assert.Equal(t, byte('b'), term.buffer[terminalBufferSize-1])
+	})
+}
+
+func TestTerminalsLocate(t *testing.T) {
+	storage := new(memoryCache)
+
+	first := newTerminals(storage, "ssh-1:8080", time.Minute)
+	second := newTerminals(storage, "ssh-2:8080", time.Minute)
+
+	term := newTerminal(new(session), io.Discard, nil, time.Minute)
+	first.register("resume", term)
+
+	_, ok := first.locate(context.Background(), "resume")
+	assert.False(t, ok)
+
+	instance, ok := second.locate(context.Background(), "resume")
+	assert.True(t, ok)
+	assert.Equal(t, "ssh-1:8080", instance)
+
+	first.unregister("resume")
+
+	_, ok = second.locate(context.Background(), "resume")
+	assert.False(t, ok)
+}
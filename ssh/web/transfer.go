@@ -0,0 +1,318 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/pkg/sftp"
+	log "github.com/sirupsen/logrus"
+)
+
+// transferChunkSize is the size of the file's chunks sent to the client on downloads.
+const transferChunkSize = 32 * 1024
+
+// filesystem is the device's filesystem, reached through the SFTP subsystem opened, once, on the terminal's SSH
+// connection when the first file is transferred.
+type filesystem struct {
+	open func() (*sftp.Client, error)
+
+	once   sync.Once
+	client *sftp.Client
+	err    error
+}
+
+func newFilesystem(open func() (*sftp.Client, error)) *filesystem {
+	return &filesystem{open: open}
+}
+
+// get returns the SFTP client, opening the subsystem when it wasn't opened yet.
+func (f *filesystem) get() (*sftp.Client, error) {
+	if f == nil {
+		return nil, ErrTransferUnavailable
+	}
+
+	f.once.Do(func() {
+		f.client, f.err = f.open()
+	})
+
+	return f.client, f.err
+}
+
+// close closes the SFTP subsystem, if it was opened, making the filesystem unavailable.
+func (f *filesystem) close() {
+	if f == nil {
+		return
+	}
+
+	f.once.Do(func() {
+		f.err = ErrTransferUnavailable
+	})
+
+	if f.client != nil {
+		f.client.Close()
+	}
+}
+
+// upload is a file being uploaded by the client.
+type upload struct {
+	file        *sftp.File
+	size        int64
+	transferred int64
+}
+
+// transfers are the file transfers of a client's connection, what are cancelled when the client is detached.
+type transfers struct {
+	conn   *Conn
+	files  *filesystem
+	logger *log.Entry
+
+	mu        sync.Mutex
+	uploads   map[string]*upload
+	downloads map[string]context.CancelFunc
+}
+
+func newTransfers(conn *Conn, files *filesystem, logger *log.Entry) *transfers {
+	return &transfers{
+		conn:      conn,
+		files:     files,
+		logger:    logger,
+		uploads:   make(map[string]*upload),
+		downloads: make(map[string]context.CancelFunc),
+	}
+}
+
+// handle handles a file transfer's message from the client.
+func (ts *transfers) handle(kind messageKind, transfer *Transfer) {
+	switch kind {
+	case messageKindUpload:
+		ts.upload(transfer)
+	case messageKindDownload:
+		ts.download(transfer)
+	case messageKindChunk:
+		ts.chunk(transfer)
+	case messageKindCancel:
+		ts.cancel(transfer.ID)
+	}
+}
+
+// send sends a file transfer's message to the client.
+func (ts *transfers) send(kind messageKind, transfer *Transfer) error {
+	_, err := ts.conn.WriteMessage(&Message{Kind: kind, Data: transfer})
+
+	return err
+}
+
+// fail informs the client that the transfer failed.
+func (ts *transfers) fail(id string, err error) {
+	ts.logger.WithError(err).WithField("transfer", id).Warning("failed to transfer the file")
+
+	ts.send(messageKindCancel, &Transfer{ID: id, Error: err.Error()}) //nolint:errcheck
+}
+
+// exists checks if there is a transfer with the identifier. It must be called with the mutex locked.
+func (ts *transfers) exists(id string) bool {
+	_, uploading := ts.uploads[id]
+	_, downloading := ts.downloads[id]
+
+	return uploading || downloading
+}
+
+// upload creates the file to be uploaded, replacing the existing one, if any.
+func (ts *transfers) upload(transfer *Transfer) {
+	client, err := ts.files.get()
+	if err != nil {
+		ts.fail(transfer.ID, err)
+
+		return
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.exists(transfer.ID) {
+		ts.fail(transfer.ID, ErrTransferExists)
+
+		return
+	}
+
+	file, err := client.Create(transfer.Path)
+	if err != nil {
+		ts.fail(transfer.ID, err)
+
+		return
+	}
+
+	ts.uploads[transfer.ID] = &upload{file: file, size: transfer.Size}
+
+	ts.send(messageKindProgress, &Transfer{ID: transfer.ID, Size: transfer.Size}) //nolint:errcheck
+}
+
+// chunk writes a chunk of the file being uploaded, closing it when the chunk is the last one.
+func (ts *transfers) chunk(transfer *Transfer) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	upload, ok := ts.uploads[transfer.ID]
+	if !ok {
+		ts.fail(transfer.ID, ErrTransferNotFound)
+
+		return
+	}
+
+	if len(transfer.Data) > 0 {
+		n, err := upload.file.Write(transfer.Data)
+		if err != nil {
+			delete(ts.uploads, transfer.ID)
+			ts.discard(upload)
+			ts.fail(transfer.ID, err)
+
+			return
+		}
+
+		upload.transferred += int64(n)
+	}
+
+	if transfer.Done {
+		delete(ts.uploads, transfer.ID)
+
+		if err := upload.file.Close(); err != nil {
+			ts.fail(transfer.ID, err)
+
+			return
+		}
+	}
+
+	ts.send(messageKindProgress, &Transfer{ //nolint:errcheck
+		ID:          transfer.ID,
+		Size:        upload.size,
+		Transferred: upload.transferred,
+		Done:        transfer.Done,
+	})
+}
+
+// discard closes and removes a file whose upload wasn't completed.
+func (ts *transfers) discard(upload *upload) {
+	upload.file.Close()
+
+	if client, err := ts.files.get(); err == nil {
+		client.Remove(upload.file.Name()) //nolint:errcheck
+	}
+}
+
+// download sends the file's chunks to the client, until the file ends or the download is cancelled.
+func (ts *transfers) download(transfer *Transfer) {
+	client, err := ts.files.get()
+	if err != nil {
+		ts.fail(transfer.ID, err)
+
+		return
+	}
+
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.exists(transfer.ID) {
+		ts.fail(transfer.ID, ErrTransferExists)
+
+		return
+	}
+
+	info, err := client.Stat(transfer.Path)
+	if err != nil {
+		ts.fail(transfer.ID, err)
+
+		return
+	}
+
+	if info.IsDir() {
+		ts.fail(transfer.ID, ErrTransferDirectory)
+
+		return
+	}
+
+	file, err := client.Open(transfer.Path)
+	if err != nil {
+		ts.fail(transfer.ID, err)
+
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ts.downloads[transfer.ID] = cancel
+
+	if err := ts.send(messageKindProgress, &Transfer{ID: transfer.ID, Size: info.Size()}); err != nil {
+		cancel()
+	}
+
+	go func() {
+		defer file.Close()
+
+		defer func() {
+			ts.mu.Lock()
+			delete(ts.downloads, transfer.ID)
+			ts.mu.Unlock()
+
+			cancel()
+		}()
+
+		buffer := make([]byte, transferChunkSize)
+
+		var transferred int64
+		for ctx.Err() == nil {
+			n, err := file.Read(buffer)
+			if n > 0 {
+				transferred += int64(n)
+
+				if err := ts.send(messageKindChunk, &Transfer{ID: transfer.ID, Data: buffer[:n], Transferred: transferred}); err != nil {
+					return
+				}
+			}
+
+			if errors.Is(err, io.EOF) {
+				ts.send(messageKindChunk, &Transfer{ID: transfer.ID, Transferred: transferred, Done: true}) //nolint:errcheck
+
+				return
+			}
+
+			if err != nil {
+				ts.fail(transfer.ID, err)
+
+				return
+			}
+		}
+	}()
+}
+
+// cancel cancels the transfer, removing the file when it is an upload.
+func (ts *transfers) cancel(id string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if upload, ok := ts.uploads[id]; ok {
+		delete(ts.uploads, id)
+		ts.discard(upload)
+
+		return
+	}
+
+	if cancel, ok := ts.downloads[id]; ok {
+		cancel()
+	}
+}
+
+// close cancels every transfer of the client's connection.
+func (ts *transfers) close() {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	for id, upload := range ts.uploads {
+		delete(ts.uploads, id)
+		ts.discard(upload)
+	}
+
+	for _, cancel := range ts.downloads {
+		cancel()
+	}
+}
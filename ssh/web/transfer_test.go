@@ -0,0 +1,183 @@
+package web
+
+import (
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/pkg/sftp"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryFilesystem creates a [filesystem] served by an in-memory SFTP server.
+func memoryFilesystem(t *testing.T) (*filesystem, *sftp.Client) {
+	t.Helper()
+
+	server, client := net.Pipe()
+
+	go sftp.NewRequestServer(server, sftp.InMemHandler()).Serve() //nolint:errcheck
+
+	sftpClient, err := sftp.NewClientPipe(client, client)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		sftpClient.Close()
+	})
+
+	return newFilesystem(func() (*sftp.Client, error) {
+		return sftpClient, nil
+	}), sftpClient
+}
+
+// transferring creates the [transfers] of a client's connection, returning a decoder of the messages sent to the
+// client.
+func transferring(t *testing.T, files *filesystem) (*transfers, *json.Decoder) {
+	t.Helper()
+
+	server, client := net.Pipe()
+	t.Cleanup(func() {
+		client.Close()
+	})
+
+	return newTransfers(NewConn(server), files, log.NewEntry(log.StandardLogger())), json.NewDecoder(client)
+}
+
+// receive decodes the next message sent to the client.
+func receive(t *testing.T, decoder *json.Decoder) (messageKind, *Transfer) {
+	t.Helper()
+
+	var message struct {
+		Kind messageKind `json:"kind"`
+		Data Transfer    `json:"data"`
+	}
+
+	require.NoError(t, decoder.Decode(&message))
+
+	return message.Kind, &message.Data
+}
+
+func TestTransfersUpload(t *testing.T) {
+	t.Run("writes the file's chunks informing the progress", func(t *testing.T) {
+		files, client := memoryFilesystem(t)
+		ts, decoder := transferring(t, files)
+
+		go ts.handle(messageKindUpload, &Transfer{ID: "upload", Path: "/file.txt", Size: 11})
+		kind, transfer := receive(t, decoder)
+		assert.Equal(t, messageKindProgress, kind)
+		assert.Equal(t, &Transfer{ID: "upload", Size: 11}, transfer)
+
+		go ts.handle(messageKindChunk, &Transfer{ID: "upload", Data: []byte("hello ")})
+		kind, transfer = receive(t, decoder)
+		assert.Equal(t, messageKindProgress, kind)
+		assert.Equal(t, &Transfer{ID: "upload", Size: 11, Transferred: 6}, transfer)
+
+		go ts.handle(messageKindChunk, &Transfer{ID: "upload", Data: []byte("world"), Done: true})
+		kind, transfer = receive(t, decoder)
+		assert.Equal(t, messageKindProgress, kind)
+		assert.Equal(t, &Transfer{ID: "upload", Size: 11, Transferred: 11, Done: true}, transfer)
+
+		file, err := client.Open("/file.txt")
+		require.NoError(t, err)
+		defer file.Close()
+
+		data, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "hello world", string(data))
+	})
+
+	t.Run("removes the file when the upload is cancelled", func(t *testing.T) {
+		files, client := memoryFilesystem(t)
+		ts, decoder := transferring(t, files)
+
+		go ts.handle(messageKindUpload, &Transfer{ID: "upload", Path: "/file.txt", Size: 11})
+		receive(t, decoder)
+
+		ts.handle(messageKindCancel, &Transfer{ID: "upload"})
+
+		_, err := client.Stat("/file.txt")
+		assert.Error(t, err)
+	})
+
+	t.Run("fails when the transfer doesn't exist", func(t *testing.T) {
+		files, _ := memoryFilesystem(t)
+		ts, decoder := transferring(t, files)
+
+		go ts.handle(messageKindChunk, &Transfer{ID: "upload", Data: []byte("data")})
+		kind, transfer := receive(t, decoder)
+		assert.Equal(t, messageKindCancel, kind)
+		assert.Equal(t, &Transfer{ID: "upload", Error: ErrTransferNotFound.Error()}, transfer)
+	})
+
+	t.Run("fails when the terminal cannot transfer files", func(t *testing.T) {
+		ts, decoder := transferring(t, nil)
+
+		go ts.handle(messageKindUpload, &Transfer{ID: "upload", Path: "/file.txt"})
+		kind, transfer := receive(t, decoder)
+		assert.Equal(t, messageKindCancel, kind)
+		assert.Equal(t, &Transfer{ID: "upload", Error: ErrTransferUnavailable.Error()}, transfer)
+	})
+}
+
+func TestTransfersDownload(t *testing.T) {
+	t.Run("sends the file's chunks", func(t *testing.T) {
+		files, client := memoryFilesystem(t)
+		ts, decoder := transferring(t, files)
+
+		data := make([]byte, transferChunkSize+10)
+		for i := range data {
+			data[i] = byte(i)
+		}
+
+		file, err := client.Create("/file.bin")
+		require.NoError(t, err)
+		_, err = file.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		go ts.handle(messageKindDownload, &Transfer{ID: "download", Path: "/file.bin"})
+		kind, transfer := receive(t, decoder)
+		assert.Equal(t, messageKindProgress, kind)
+		assert.Equal(t, &Transfer{ID: "download", Size: int64(len(data))}, transfer)
+
+		var received []byte
+		for {
+			kind, transfer := receive(t, decoder)
+			require.Equal(t, messageKindChunk, kind)
+
+			received = append(received, transfer.Data...)
+			assert.Equal(t, int64(len(received)), transfer.Transferred)
+
+			if transfer.Done {
+				break
+			}
+		}
+
+		assert.Equal(t, data, received)
+	})
+
+	t.Run("fails when the file doesn't exist", func(t *testing.T) {
+		files, _ := memoryFilesystem(t)
+		ts, decoder := transferring(t, files)
+
+		go ts.handle(messageKindDownload, &Transfer{ID: "download", Path: "/missing"})
+		kind, transfer := receive(t, decoder)
+		assert.Equal(t, messageKindCancel, kind)
+		assert.Equal(t, "download", transfer.ID)
+		assert.NotEmpty(t, transfer.Error)
+	})
+
+	t.Run("fails when the path is a directory", func(t *testing.T) {
+		files, client := memoryFilesystem(t)
+		ts, decoder := transferring(t, files)
+
+		require.NoError(t, client.Mkdir("/directory"))
+
+		go ts.handle(messageKindDownload, &Transfer{ID: "download", Path: "/directory"})
+		kind, transfer := receive(t, decoder)
+		assert.Equal(t, messageKindCancel, kind)
+		assert.Equal(t, &Transfer{ID: "download", Error: ErrTransferDirectory.Error()}, transfer)
+	})
+}
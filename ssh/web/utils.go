@@ -1,11 +1,9 @@
 package web
 
 import (
-	"crypto/rand"
-	"crypto/rsa"
-	"crypto/sha256"
-	"encoding/hex"
 	"errors"
+
+	"github.com/shellhub-io/shellhub/ssh/web/pkg/crypter"
 )
 
 type Credentials struct {
@@ -15,37 +13,34 @@ type Credentials struct {
 	Username string `json:"username"`
 	// Password is the password in the device's OS.
 	Password string `json:"password"`
-	// Fingerprint is the identifier of the public key used in the device's OS.
+	// Fingerprint is the identifier of the public key used in the device's OS. The SSH authentication is signed by the
+	// client through the WebSocket.
 	Fingerprint string `json:"fingerprint"`
-	Signature   string `json:"signature"`
+	// Resume is the identifier used by the client to resume the terminal after its connection is lost.
+	Resume string `json:"-"`
 }
 
-func (c *Credentials) encryptPassword(key *rsa.PrivateKey) error {
+func (c *Credentials) encryptPassword(crypter *crypter.Crypter) error {
 	if c.Password == "" {
 		return ErrCreditialsNoPassword
 	}
 
-	signed, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, &key.PublicKey, []byte(c.Password), nil)
+	encrypted, err := crypter.Encrypt([]byte(c.Password))
 	if err != nil {
-		return errors.New("failed to sign the session's password")
+		return errors.New("failed to encrypt the session's password")
 	}
 
-	c.Password = hex.EncodeToString(signed)
+	c.Password = encrypted
 
 	return nil
 }
 
-func (c *Credentials) decryptPassword(key *rsa.PrivateKey) error {
+func (c *Credentials) decryptPassword(crypter *crypter.Crypter) error {
 	if c.Password == "" {
 		return ErrCreditialsNoPassword
 	}
 
-	decoded, err := hex.DecodeString(c.Password)
-	if err != nil {
-		return errors.New("failed to decode the session's password")
-	}
-
-	decrypted, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, key, decoded, nil)
+	decrypted, err := crypter.Decrypt(c.Password)
 	if err != nil {
 		return errors.New("failed to decrypt the session's password")
 	}
@@ -55,8 +50,9 @@ func (c *Credentials) decryptPassword(key *rsa.PrivateKey) error {
 	return nil
 }
 
-func (c *Credentials) isPublicKey() bool { // nolint: unused
-	return c.Fingerprint != "" && c.Signature != ""
+// isPublicKey checks if connection is using public key method.
+func (c *Credentials) isPublicKey() bool {
+	return c.Fingerprint != ""
 }
 
 // isPassword checks if connection is using password method.
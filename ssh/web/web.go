@@ -3,25 +3,52 @@ package web
 import (
 	"encoding/json"
 	"net/http"
+	"net/http/httputil"
+	"net/url"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
 	"github.com/shellhub-io/shellhub/ssh/pkg/magickey"
+	"github.com/shellhub-io/shellhub/ssh/web/pkg/crypter"
 	"github.com/shellhub-io/shellhub/ssh/web/pkg/token"
+	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/websocket"
 )
 
+// BridgeOptions are the options of the bridge between the web terminal and the SSH server.
+type BridgeOptions struct {
+	// Address is where the bridge connects to the SSH server of this instance.
+	Address string
+	// Cache stores the credentials, until the WebSocket is opened, and the records of the terminals.
+	Cache cache.Cache
+	// Crypter encrypts the credentials while they are stored.
+	Crypter *crypter.Crypter
+	// Instance is the address where the other instances of the service reach this one.
+	Instance string
+	// Grace is how long a terminal is kept after its WebSocket is lost, waiting for the client to resume it.
+	Grace time.Duration
+}
+
 // NewSSHServerBridge creates routes into a [echo.Router] to connect a webscoket to SSH using Shell session.
-func NewSSHServerBridge(router *echo.Router) {
+//
+// The credentials sent to the bridge are encrypted by the crypter and stored on the cache until the WebSocket is
+// opened. When both are shared among the instances of the service, the WebSocket can be opened on any of them, and a
+// terminal can be resumed from any of them too.
+func NewSSHServerBridge(router *echo.Router, opts *BridgeOptions) {
 	const WebsocketSSHBridgeRoute = "/ws/ssh"
 
-	manager := newManager(30 * time.Second)
+	manager := newManager(opts.Cache, 30*time.Second)
+	terminals := newTerminals(opts.Cache, opts.Instance, opts.Grace)
 
 	// NOTICE: this is the route that users send your credentials securely.
 	router.Add(http.MethodPost, WebsocketSSHBridgeRoute, echo.WrapHandler(
 		http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
 			type Success struct {
 				Token string `json:"token"`
+				// Resume is the identifier used to resume the terminal after the WebSocket is lost.
+				Resume string `json:"resume"`
 			}
 
 			type Fail struct {
@@ -54,16 +81,21 @@ func NewSSHServerBridge(router *echo.Router) {
 				return
 			}
 
-			request.encryptPassword(key) //nolint:errcheck
+			request.encryptPassword(opts.Crypter) //nolint:errcheck
+			request.Resume = uuid.Generate()
 
 			// NOTICE: saved credentials are delete after a time period.
-			manager.save(token.ID, &request)
+			if err := manager.save(req.Context(), token.ID, &request); err != nil {
+				response(res, http.StatusInternalServerError, Fail{Error: err.Error()})
+
+				return
+			}
 
-			response(res, http.StatusOK, Success{Token: token.ID})
+			response(res, http.StatusOK, Success{Token: token.ID, Resume: request.Resume})
 		})),
 	)
 
-	router.Add(http.MethodGet, WebsocketSSHBridgeRoute, echo.WrapHandler(websocket.Handler(func(wsconn *websocket.Conn) {
+	bridge := websocket.Handler(func(wsconn *websocket.Conn) {
 		defer wsconn.Close()
 
 		// exit sends the error's message to the client on the browser.
@@ -71,6 +103,38 @@ func NewSSHServerBridge(router *echo.Router) {
 			wsconn.Write([]byte(err.Error())) //nolint:errcheck
 		}
 
+		if resume, err := getResume(wsconn.Request()); err == nil {
+			term, ok := terminals.get(resume)
+			if !ok {
+				exit(wsconn, ErrBridgeTerminalNotFound)
+
+				return
+			}
+
+			cols, rows, err := getDimensions(wsconn.Request())
+			if err != nil {
+				exit(wsconn, ErrWebSocketGetDimensions)
+
+				return
+			}
+
+			offset, err := getOffset(wsconn.Request())
+			if err != nil {
+				exit(wsconn, ErrWebSocketGetOffset)
+
+				return
+			}
+
+			conn := NewConn(wsconn)
+			defer conn.Close()
+
+			go conn.KeepAlive()
+
+			term.attach(conn, Dimensions{cols, rows}, offset, log.WithField("resume", resume))
+
+			return
+		}
+
 		token, err := getToken(wsconn.Request())
 		if err != nil {
 			exit(wsconn, ErrWebSocketGetToken)
@@ -92,9 +156,11 @@ func NewSSHServerBridge(router *echo.Router) {
 			return
 		}
 
-		creds, ok := manager.get(token)
+		creds, ok := manager.get(wsconn.Request().Context(), token)
 		if !ok {
 			exit(wsconn, ErrBridgeCredentialsNotFound)
+
+			return
 		}
 
 		conn := NewConn(wsconn)
@@ -102,17 +168,36 @@ func NewSSHServerBridge(router *echo.Router) {
 
 		go conn.KeepAlive()
 
-		creds.decryptPassword(magickey.GetRerefence()) //nolint:errcheck
+		creds.decryptPassword(opts.Crypter) //nolint:errcheck
 
 		if err := newSession(
+			opts.Address,
 			conn,
 			creds,
 			Dimensions{cols, rows},
 			Info{IP: ip},
+			terminals,
 		); err != nil {
 			exit(wsconn, err)
 
 			return
 		}
+	})
+
+	router.Add(http.MethodGet, WebsocketSSHBridgeRoute, echo.WrapHandler(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		// NOTICE: A terminal is resumed on the instance where its SSH session is, so, when it is on another one, the
+		// WebSocket is proxied to it. The session and its recent output only live on that instance's memory, so the
+		// terminal cannot be resumed once that instance is gone.
+		if resume, err := getResume(req); err == nil {
+			if _, ok := terminals.get(resume); !ok {
+				if instance, ok := terminals.locate(req.Context(), resume); ok {
+					httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: instance}).ServeHTTP(res, req)
+
+					return
+				}
+			}
+		}
+
+		bridge.ServeHTTP(res, req)
 	})))
 }
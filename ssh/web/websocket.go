@@ -16,6 +16,32 @@ func getToken(req *http.Request) (string, error) {
 	return token, nil
 }
 
+func getResume(req *http.Request) (string, error) {
+	resume := req.URL.Query().Get("resume")
+
+	if resume == "" {
+		return "", ErrGetResume
+	}
+
+	return resume, nil
+}
+
+// getOffset returns the number of bytes of the terminal's output the client already received, from where the output
+// is replayed when it resumes the terminal. When the client doesn't send it, the whole recent output is replayed.
+func getOffset(req *http.Request) (uint64, error) {
+	text := req.URL.Query().Get("offset")
+	if text == "" {
+		return 0, nil
+	}
+
+	offset, err := strconv.ParseUint(text, 10, 64)
+	if err != nil {
+		return 0, errors.Join(ErrGetOffset, err)
+	}
+
+	return offset, nil
+}
+
 func getDimensions(req *http.Request) (int, int, error) {
 	toUint8 := func(text string) (uint64, error) {
 		integer, err := strconv.ParseUint(text, 10, 8)